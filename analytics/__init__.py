@@ -0,0 +1,34 @@
+"""Portfolio analytics module."""
+from .calibration import DistributionCalibrator
+from .bayesian import bayesian_return_update
+from .compliance import ComplianceEngine, ComplianceRule
+from .lookthrough import lookthrough_exposure
+from .smoothing import ReturnDesmoother, interpolate_nav
+from .shrinkage import shrink_fund_estimates
+from .risk_metrics import return_risk_metrics, var_contributions
+from .dependence import tail_dependence
+from .benchmarks import composite_benchmark
+from .peers import peer_quartile, rank_funds
+from .inflation import CPISeries, xirr, nominal_and_real_irr
+from .allocation import allocation_drift
+from .reconciliation import reconcile_cash
+from .stress import reverse_stress_test
+from .limits import portfolio_risk_snapshot, evaluate_limits
+from .liquidity import LiquidityScenario, LiquidityStressTest
+from .forecast_accuracy import forecast_accuracy
+from .decision import new_fund_decision
+from .assumptions import AssumptionSet
+from .model_registry import validate_model_parameters, simulation_inputs
+from .groups import rollup_groups
+
+__all__ = [
+    'DistributionCalibrator', 'ComplianceEngine', 'ComplianceRule',
+    'lookthrough_exposure', 'ReturnDesmoother', 'interpolate_nav', 'return_risk_metrics',
+    'composite_benchmark', 'peer_quartile', 'rank_funds', 'CPISeries', 'xirr',
+    'nominal_and_real_irr', 'allocation_drift',
+    'reconcile_cash', 'reverse_stress_test', 'tail_dependence',
+    'var_contributions', 'portfolio_risk_snapshot', 'evaluate_limits',
+    'LiquidityScenario', 'LiquidityStressTest', 'forecast_accuracy',
+    'bayesian_return_update', 'shrink_fund_estimates', 'new_fund_decision',
+    'AssumptionSet', 'validate_model_parameters', 'simulation_inputs', 'rollup_groups'
+]
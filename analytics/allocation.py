@@ -0,0 +1,108 @@
+"""
+Target Allocation Drift
+
+Compares current portfolio weights with target weights per bucket (sector,
+strategy, ...) and suggests the trades that would restore the targets.
+
+Formulas:
+--------
+- Current weight:  w_i = V_i / Σ V
+- Drift:           d_i = w_i - w*_i
+- Relative drift:  d_i / w*_i
+- Trade to target: T_i = w*_i Σ V - V_i   (positive = add exposure)
+
+A bucket is flagged when |d_i| exceeds its drift threshold. Buckets held
+but without a target are treated as a target of zero. Private fund
+exposure cannot be sold down quickly, so positive trades are labelled as
+new commitments and negative trades as secondary sales or paused commitments.
+"""
+
+from typing import Dict, Optional
+
+
+def allocation_drift(
+    values: Dict[str, float],
+    targets: Dict[str, float],
+    thresholds: Optional[Dict[str, float]] = None,
+    default_threshold: float = 0.05
+) -> Dict[str, any]:
+    """
+    Drift of current allocation from targets.
+
+    Parameters:
+        values: Bucket -> current value (e.g. NAV by sector)
+        targets: Bucket -> target weight (must sum to 1)
+        thresholds: Bucket -> allowed absolute drift (overrides default)
+        default_threshold: Allowed absolute drift for buckets without one
+
+    Returns:
+        Dictionary with per-bucket weights, drift and suggested trades, the
+        list of alerts and the total turnover needed to return to target
+    """
+    if not targets:
+        raise ValueError("At least one target weight is required")
+    if any(w < 0 for w in targets.values()):
+        raise ValueError("Target weights must be non-negative")
+    if abs(sum(targets.values()) - 1.0) > 1e-6:
+        raise ValueError(f"Target weights must sum to 1 (got {sum(targets.values()):.4f})")
+    if any(v < 0 for v in values.values()):
+        raise ValueError("Current values must be non-negative")
+
+    total = float(sum(values.values()))
+    if total <= 0:
+        raise ValueError("Portfolio has no value to allocate")
+
+    thresholds = thresholds or {}
+    buckets = {}
+    alerts = []
+
+    for bucket in sorted(set(values) | set(targets)):
+        value = float(values.get(bucket, 0.0))
+        target = float(targets.get(bucket, 0.0))
+        threshold = float(thresholds.get(bucket, default_threshold))
+
+        weight = value / total
+        drift = weight - target
+        trade = target * total - value
+
+        buckets[bucket] = {
+            'value': value,
+            'weight': weight,
+            'target_weight': target,
+            'drift': drift,
+            'relative_drift': drift / target if target > 0 else None,
+            'threshold': threshold,
+            'breach': abs(drift) > threshold,
+            'suggested_trade': trade,
+            'action': _action(trade),
+        }
+
+        if abs(drift) > threshold:
+            alerts.append({
+                'bucket': bucket,
+                'drift': drift,
+                'threshold': threshold,
+                'direction': 'overweight' if drift > 0 else 'underweight',
+                'suggested_trade': trade,
+                'action': _action(trade),
+            })
+
+    alerts.sort(key=lambda a: abs(a['drift']), reverse=True)
+
+    return {
+        'total_value': total,
+        'buckets': buckets,
+        'alerts': alerts,
+        'n_breaches': len(alerts),
+        'max_abs_drift': max(abs(b['drift']) for b in buckets.values()),
+        'turnover_to_target': sum(abs(b['suggested_trade']) for b in buckets.values()) / 2,
+    }
+
+
+def _action(trade: float) -> str:
+    """Describe a trade in private-markets terms."""
+    if trade > 0:
+        return 'commit'
+    if trade < 0:
+        return 'reduce'
+    return 'hold'
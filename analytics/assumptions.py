@@ -0,0 +1,162 @@
+"""
+Assumption Sets
+
+Named parameter sets shared across simulation and optimization requests,
+so every run in a team starts from the same capital market and pacing
+assumptions.
+
+Format:
+------
+    {
+        'assets':           ['US Buyout', 'Venture', ...],
+        'expected_returns': [0.12, 0.15, ...],          (annual)
+        'volatilities':     [0.20, 0.30, ...],          (annual)
+        'correlations':     [[1.0, 0.6, ...], ...]      (or one common correlation),
+        'pacing':           {'call_rate': 0.25, 'distribution_rate': 0.15, ...}
+    }
+
+Market assumptions and pacing are each optional, but a set needs at least
+one of them. Pacing keys are the base-case LiquidityScenario fields in
+PACING_FIELDS; stress overrides are applied on top of them.
+
+Scenario returns:
+----------------
+Optimizers that work on return scenarios get a sample whose mean and
+covariance match the assumptions exactly. Standard normal draws Z are
+centred and whitened with the Cholesky factor of their sample covariance,
+then recoloured with Σ = D C D (D = diag(volatilities), C = correlations):
+
+    R = μ / f + Z_w A^T / √f,    A A^T = Σ
+"""
+
+import numpy as np
+from dataclasses import dataclass, field
+from typing import Dict, List, Optional
+
+PACING_FIELDS = (
+    'call_rate', 'call_volatility', 'distribution_rate',
+    'private_return', 'private_beta', 'private_volatility'
+)
+
+
+@dataclass
+class AssumptionSet:
+    """
+    Validated capital market and pacing assumptions.
+
+    Attributes:
+        assets: Asset (or asset class) names
+        expected_returns: Annual expected return per asset
+        volatilities: Annual volatility per asset
+        correlations: Correlation matrix aligned with assets
+        pacing: Pacing overrides keyed by PACING_FIELDS
+
+    Example:
+        >>> assumptions = AssumptionSet.from_parameters({
+        ...     'assets': ['Buyout', 'Venture'], 'expected_returns': [0.12, 0.15],
+        ...     'volatilities': [0.2, 0.3], 'correlations': 0.6,
+        ... })
+        >>> returns = assumptions.scenario_returns(n_periods=252, frequency=252, seed=42)
+    """
+    assets: List[str] = field(default_factory=list)
+    expected_returns: np.ndarray = field(default_factory=lambda: np.zeros(0))
+    volatilities: np.ndarray = field(default_factory=lambda: np.zeros(0))
+    correlations: np.ndarray = field(default_factory=lambda: np.zeros((0, 0)))
+    pacing: Dict[str, float] = field(default_factory=dict)
+
+    @classmethod
+    def from_parameters(cls, parameters: Dict) -> 'AssumptionSet':
+        """Build and validate a set from its stored (JSON) form."""
+        assets = list(parameters.get('assets') or [])
+        pacing = dict(parameters.get('pacing') or {})
+        if not assets and not pacing:
+            raise ValueError("An assumption set needs market assumptions, pacing, or both")
+
+        unknown = [k for k in pacing if k not in PACING_FIELDS]
+        if unknown:
+            raise ValueError(f"Unknown pacing parameters: {', '.join(unknown)}")
+        if not all(isinstance(v, (int, float)) and np.isfinite(v) for v in pacing.values()):
+            raise ValueError("Pacing parameters must be finite numbers")
+
+        if not assets:
+            return cls(pacing=pacing)
+
+        n = len(assets)
+        if len(set(assets)) != n:
+            raise ValueError("Asset names must be unique")
+        mu = np.asarray(parameters.get('expected_returns'), dtype=float)
+        vol = np.asarray(parameters.get('volatilities'), dtype=float)
+        if mu.shape != (n,) or vol.shape != (n,):
+            raise ValueError("expected_returns and volatilities must have one value per asset")
+        if not np.all(np.isfinite(mu)) or not np.all(np.isfinite(vol)) or np.any(vol < 0):
+            raise ValueError("expected_returns must be finite and volatilities non-negative")
+
+        corr = _correlation_matrix(parameters.get('correlations', 0.0), n)
+        return cls(assets=assets, expected_returns=mu, volatilities=vol, correlations=corr, pacing=pacing)
+
+    def to_parameters(self) -> Dict[str, any]:
+        """Stored (JSON) form of the set."""
+        parameters = {}
+        if self.assets:
+            parameters.update({
+                'assets': self.assets,
+                'expected_returns': self.expected_returns.tolist(),
+                'volatilities': self.volatilities.tolist(),
+                'correlations': self.correlations.tolist(),
+            })
+        if self.pacing:
+            parameters['pacing'] = self.pacing
+        return parameters
+
+    def covariance(self) -> np.ndarray:
+        """Annual covariance matrix Σ = D C D."""
+        return self.correlations * np.outer(self.volatilities, self.volatilities)
+
+    def scenario_returns(self, n_periods: int = 252, frequency: int = 252, seed: Optional[int] = None) -> np.ndarray:
+        """
+        Periodic return scenarios with exactly the assumed mean and covariance.
+
+        Parameters:
+            n_periods: Number of scenarios (must exceed the number of assets)
+            frequency: Periods per year
+            seed: Random seed
+
+        Returns:
+            Returns matrix (n_periods × n_assets)
+        """
+        n = len(self.assets)
+        if n == 0:
+            raise ValueError("Assumption set has no market assumptions")
+        if n_periods <= n:
+            raise ValueError("n_periods must exceed the number of assets")
+
+        rng = np.random.default_rng(seed)
+        z = rng.standard_normal((n_periods, n))
+        z -= z.mean(axis=0)
+        whitened = z @ np.linalg.inv(np.linalg.cholesky(np.atleast_2d(np.cov(z, rowvar=False)))).T
+
+        # Eigen-factor rather than Cholesky so singular (PSD) covariances work
+        eigenvalues, eigenvectors = np.linalg.eigh(self.covariance())
+        factor = eigenvectors * np.sqrt(np.maximum(eigenvalues, 0.0))
+
+        return self.expected_returns / frequency + whitened @ factor.T / np.sqrt(frequency)
+
+
+def _correlation_matrix(correlations, n: int) -> np.ndarray:
+    """Full correlation matrix from a matrix or a single common correlation."""
+    if np.isscalar(correlations):
+        rho = float(correlations)
+        if n > 1 and not -1.0 / (n - 1) <= rho <= 1:
+            raise ValueError(f"Common correlation must be between {-1.0 / (n - 1):.4f} and 1")
+        corr = np.full((n, n), rho)
+        np.fill_diagonal(corr, 1.0)
+        return corr
+
+    corr = np.asarray(correlations, dtype=float)
+    if corr.shape != (n, n):
+        raise ValueError(f"correlations must be a {n}x{n} matrix")
+    if not np.allclose(corr, corr.T) or not np.allclose(np.diag(corr), 1.0) or np.any(np.abs(corr) > 1):
+        raise ValueError("correlations must be symmetric with unit diagonal and entries in [-1, 1]")
+    if np.min(np.linalg.eigvalsh(corr)) < -1e-10:
+        raise ValueError("correlations must be positive semi-definite")
+    return corr
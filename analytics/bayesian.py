@@ -0,0 +1,140 @@
+"""
+Bayesian Updating of Return Assumptions
+
+Combines prior return and volatility assumptions with observed periodic
+returns through the conjugate normal-inverse-gamma model, so simulation
+inputs move with the evidence instead of being fixed by hand.
+
+Model:
+-----
+    r_t | μ, σ² ~ N(μ, σ²)
+    μ | σ²      ~ N(m₀, σ² / κ₀)
+    σ²          ~ IG(α₀, β₀)
+
+A prior stated as an annual mean and volatility with confidence in
+pseudo-observations (n_μ for the mean, n_σ for the volatility) maps to
+m₀ = mean / f, κ₀ = n_μ, α₀ = n_σ / 2, β₀ = α₀ · vol² / f.
+
+Update with n returns, sample mean x̄ and S = Σ (r_t - x̄)²:
+
+    κₙ = κ₀ + n
+    mₙ = (κ₀ m₀ + n x̄) / κₙ
+    αₙ = α₀ + n / 2
+    βₙ = β₀ + S / 2 + κ₀ n (x̄ - m₀)² / (2 κₙ)
+
+Posterior marginals: μ ~ t_{2αₙ}(mₙ, βₙ / (αₙ κₙ)), σ² ~ IG(αₙ, βₙ).
+Posterior predictive: r ~ t_{2αₙ}(mₙ, βₙ (κₙ + 1) / (αₙ κₙ)).
+The posterior NIG parameters can be passed back in as the next prior.
+"""
+
+import numpy as np
+from scipy import stats
+from typing import Dict, Optional, Sequence
+
+
+def bayesian_return_update(
+    returns: Sequence[float],
+    prior: Dict[str, any],
+    frequency: int = 4,
+    credible_level: float = 0.9
+) -> Dict[str, any]:
+    """
+    Posterior return and volatility assumptions from a prior and observed returns.
+
+    Parameters:
+        returns: Observed periodic returns
+        prior: Either {'mean', 'volatility', 'mean_confidence',
+            'volatility_confidence'} (annual values, confidence in
+            pseudo-observations) or {'nig': {'m', 'kappa', 'alpha', 'beta'}}
+            in per-period units (e.g. a previous posterior)
+        frequency: Periods per year
+        credible_level: Probability mass of the reported credible intervals
+
+    Returns:
+        Dictionary with prior and posterior summaries (annualized mean and
+        volatility, credible intervals, NIG parameters), the weight given to
+        the data, and 'simulation_inputs' (annual mu and sigma of the
+        posterior predictive plus its Student-t degrees of freedom)
+    """
+    if not 0 < credible_level < 1:
+        raise ValueError("credible_level must be between 0 and 1")
+    x = np.asarray(returns, dtype=float).ravel()
+    if len(x) < 2:
+        raise ValueError("Need at least 2 returns")
+    if not np.all(np.isfinite(x)):
+        raise ValueError("Returns must be finite")
+
+    m0, kappa0, alpha0, beta0 = _prior_nig(prior, frequency)
+
+    n = len(x)
+    mean = float(np.mean(x))
+    ss = float(np.sum((x - mean) ** 2))
+
+    kappa_n = kappa0 + n
+    m_n = (kappa0 * m0 + n * mean) / kappa_n
+    alpha_n = alpha0 + n / 2
+    beta_n = beta0 + ss / 2 + kappa0 * n * (mean - m0) ** 2 / (2 * kappa_n)
+
+    posterior = _summary(m_n, kappa_n, alpha_n, beta_n, frequency, credible_level)
+    predictive_scale = np.sqrt(beta_n * (kappa_n + 1) / (alpha_n * kappa_n))
+    dof = 2 * alpha_n
+
+    return {
+        'frequency': frequency,
+        'credible_level': credible_level,
+        'n_observations': n,
+        'sample': {
+            'annualized_mean': mean * frequency,
+            'annualized_volatility': float(np.std(x, ddof=1) * np.sqrt(frequency)),
+        },
+        'prior': _summary(m0, kappa0, alpha0, beta0, frequency, credible_level),
+        'posterior': posterior,
+        'data_weight': n / kappa_n,
+        'simulation_inputs': {
+            'mu': m_n * frequency,
+            # Predictive variance of a t distribution: scale² · ν / (ν - 2)
+            'sigma': float(predictive_scale * np.sqrt(frequency * dof / (dof - 2))) if dof > 2 else None,
+            'df': dof,
+        },
+    }
+
+
+def _prior_nig(prior: Dict[str, any], frequency: int):
+    """Per-period NIG parameters (m, κ, α, β) from either prior format."""
+    if 'nig' in prior:
+        nig = prior['nig']
+        m0, kappa0, alpha0, beta0 = (float(nig[k]) for k in ('m', 'kappa', 'alpha', 'beta'))
+    else:
+        kappa0 = float(prior.get('mean_confidence', 4))
+        alpha0 = float(prior.get('volatility_confidence', 4)) / 2
+        if prior['volatility'] <= 0:
+            raise ValueError("Prior volatility must be positive")
+        m0 = prior['mean'] / frequency
+        beta0 = alpha0 * prior['volatility'] ** 2 / frequency
+
+    if kappa0 <= 0 or alpha0 <= 0 or beta0 <= 0:
+        raise ValueError("Prior confidence and volatility must be positive")
+    return m0, kappa0, alpha0, beta0
+
+
+def _summary(
+    m: float,
+    kappa: float,
+    alpha: float,
+    beta: float,
+    frequency: int,
+    credible_level: float
+) -> Dict[str, any]:
+    """Annualized point estimates and credible intervals of an NIG distribution."""
+    tail = (1 - credible_level) / 2
+    mean_interval = stats.t.ppf([tail, 1 - tail], df=2 * alpha, loc=m, scale=np.sqrt(beta / (alpha * kappa)))
+    var_interval = stats.invgamma.ppf([tail, 1 - tail], a=alpha, scale=beta)
+    expected_var: Optional[float] = beta / (alpha - 1) if alpha > 1 else None
+
+    return {
+        'annualized_mean': m * frequency,
+        'annualized_mean_interval': [float(v * frequency) for v in mean_interval],
+        'annualized_volatility': float(np.sqrt(expected_var * frequency)) if expected_var is not None else None,
+        'annualized_volatility_interval': [float(np.sqrt(v * frequency)) for v in var_interval],
+        'nig': {'m': m, 'kappa': kappa, 'alpha': alpha, 'beta': beta},
+    }
@@ -0,0 +1,184 @@
+"""
+Exposure Limits and Compliance Rules
+
+Evaluates a set of positions (current or what-if) against portfolio
+construction rules and reports every violation.
+
+Rule Types:
+----------
+- max_group_weight:    Σ weight of positions sharing an attribute value ≤ limit
+                       (e.g. max 30% per sector, max 15% per manager)
+- max_position_weight: every single position ≤ limit
+- min_holdings:        number of positions with non-zero value ≥ limit
+- min_groups:          number of distinct attribute values held ≥ limit
+
+Weights are position value divided by total portfolio value.
+"""
+
+from dataclasses import dataclass
+from typing import Dict, List, Optional
+
+
+RULE_TYPES = ('max_group_weight', 'max_position_weight', 'min_holdings', 'min_groups')
+
+
+@dataclass
+class ComplianceRule:
+    """
+    Portfolio compliance rule.
+
+    Attributes:
+        name: Human-readable rule name
+        rule_type: One of RULE_TYPES
+        limit: Weight (fraction) or count limit
+        attribute: Position attribute to group by (group rules only)
+    """
+    name: str
+    rule_type: str
+    limit: float
+    attribute: Optional[str] = None
+
+    def __post_init__(self):
+        if self.rule_type not in RULE_TYPES:
+            raise ValueError(f"Unknown rule_type: {self.rule_type}")
+        if self.rule_type in ('max_group_weight', 'min_groups') and not self.attribute:
+            raise ValueError(f"Rule '{self.name}' requires an attribute")
+        if self.limit < 0:
+            raise ValueError(f"Rule '{self.name}' limit must be non-negative")
+
+
+class ComplianceEngine:
+    """
+    Rule engine for exposure limits.
+
+    Positions are dictionaries with a 'value' key plus any attributes
+    referenced by rules (e.g. 'sector', 'manager') and an optional 'name'.
+
+    Example:
+        >>> engine = ComplianceEngine([
+        ...     ComplianceRule('Sector cap', 'max_group_weight', 0.30, attribute='sector'),
+        ...     ComplianceRule('Diversification', 'min_holdings', 10),
+        ... ])
+        >>> report = engine.evaluate(positions)
+        >>> report['compliant']
+        False
+    """
+
+    def __init__(self, rules: List[ComplianceRule]):
+        """
+        Initialize compliance engine.
+
+        Parameters:
+            rules: Rules to evaluate
+        """
+        self.rules = list(rules)
+
+    def evaluate(self, positions: List[Dict]) -> Dict[str, any]:
+        """
+        Evaluate positions against all rules.
+
+        Parameters:
+            positions: List of position dictionaries
+
+        Returns:
+            Dictionary with overall status, per-rule results and violations
+        """
+        total = sum(float(p['value']) for p in positions)
+        if total <= 0:
+            raise ValueError("Positions must have a positive total value")
+
+        results = []
+        for rule in self.rules:
+            results.extend(self._evaluate_rule(rule, positions, total))
+
+        violations = [r for r in results if not r['passed']]
+
+        return {
+            'compliant': not violations,
+            'total_value': total,
+            'results': results,
+            'violations': violations,
+        }
+
+    def what_if(self, positions: List[Dict], changes: List[Dict]) -> Dict[str, any]:
+        """
+        Evaluate a proposed change before it is made.
+
+        Changes are position dictionaries whose 'value' is added to the
+        position with the same 'name' (or appended as a new position).
+
+        Parameters:
+            positions: Current positions
+            changes: Proposed changes
+
+        Returns:
+            Dictionary with 'before' and 'after' evaluations and the
+            violations introduced and resolved by the change
+        """
+        proposed = {p.get('name', i): dict(p) for i, p in enumerate(positions)}
+        for change in changes:
+            key = change.get('name')
+            if key in proposed:
+                proposed[key]['value'] = float(proposed[key]['value']) + float(change['value'])
+            else:
+                proposed[key if key is not None else len(proposed)] = dict(change)
+
+        before = self.evaluate(positions)
+        after = self.evaluate(list(proposed.values()))
+
+        before_keys = {(v['rule'], v.get('group')) for v in before['violations']}
+        after_keys = {(v['rule'], v.get('group')) for v in after['violations']}
+
+        return {
+            'before': before,
+            'after': after,
+            'new_violations': [v for v in after['violations'] if (v['rule'], v.get('group')) not in before_keys],
+            'resolved_violations': [v for v in before['violations'] if (v['rule'], v.get('group')) not in after_keys],
+        }
+
+    @staticmethod
+    def _evaluate_rule(rule: ComplianceRule, positions: List[Dict], total: float) -> List[Dict]:
+        """Evaluate one rule, returning one result per checked group or position."""
+        if rule.rule_type == 'max_group_weight':
+            groups: Dict[str, float] = {}
+            for p in positions:
+                key = str(p.get(rule.attribute, 'Unassigned'))
+                groups[key] = groups.get(key, 0.0) + float(p['value'])
+
+            return [
+                {
+                    'rule': rule.name,
+                    'rule_type': rule.rule_type,
+                    'group': key,
+                    'value': value / total,
+                    'limit': rule.limit,
+                    'passed': value / total <= rule.limit + 1e-12,
+                }
+                for key, value in sorted(groups.items())
+            ]
+
+        if rule.rule_type == 'max_position_weight':
+            return [
+                {
+                    'rule': rule.name,
+                    'rule_type': rule.rule_type,
+                    'group': str(p.get('name', i)),
+                    'value': float(p['value']) / total,
+                    'limit': rule.limit,
+                    'passed': float(p['value']) / total <= rule.limit + 1e-12,
+                }
+                for i, p in enumerate(positions)
+            ]
+
+        if rule.rule_type == 'min_holdings':
+            count = sum(1 for p in positions if float(p['value']) > 0)
+        else:  # min_groups
+            count = len({p.get(rule.attribute) for p in positions if float(p['value']) > 0})
+
+        return [{
+            'rule': rule.name,
+            'rule_type': rule.rule_type,
+            'value': count,
+            'limit': rule.limit,
+            'passed': count >= rule.limit,
+        }]
@@ -0,0 +1,113 @@
+"""
+Forecast Accuracy Tracking
+
+Scores stored fund forecasts against the actuals that arrive later, per
+model, model version and fund, and compares each model with a naive
+forecast.
+
+Matching:
+--------
+A forecast made on prediction_date for target_date is scored against the
+first actual observed on or after target_date. Forecasts whose target date
+has no actual yet are counted as pending.
+
+Naive baseline:
+--------------
+The fund's latest actual known on prediction_date (a random-walk forecast).
+A model adds value when its MAE on the same forecasts is below the naive
+MAE; skill = 1 - MAE_model / MAE_naive.
+
+Error metrics are those of backtesting.forecast_error_metrics (RMSE, MAE,
+bias = mean(forecast - actual), MAPE).
+"""
+
+import numpy as np
+from bisect import bisect_left, bisect_right
+from collections import defaultdict
+from typing import Dict, List
+
+from backtesting import forecast_error_metrics
+
+
+def forecast_accuracy(forecasts: List[Dict], actuals: List[Dict]) -> Dict[str, any]:
+    """
+    Error metrics of forecasts against realized values.
+
+    Parameters:
+        forecasts: Rows with 'fund', 'model', 'version', 'prediction_date',
+            'target_date' (dates) and 'predicted'
+        actuals: Rows with 'fund', 'date' and 'actual'
+
+    Returns:
+        Dictionary with metrics by model (including a per-version breakdown,
+        the naive baseline on the same forecasts and the skill score), by
+        fund and model, and counts of scored and pending forecasts
+    """
+    history = defaultdict(list)
+    for row in sorted(actuals, key=lambda r: r['date']):
+        history[row['fund']].append((row['date'], float(row['actual'])))
+    dates = {fund: [d for d, _ in obs] for fund, obs in history.items()}
+
+    scored = []
+    pending = 0
+    for f in forecasts:
+        obs = history.get(f['fund'], [])
+        later = bisect_left(dates.get(f['fund'], []), f['target_date'])
+        if later == len(obs):
+            pending += 1
+            continue
+        known = bisect_right(dates[f['fund']], f['prediction_date'])
+        scored.append({
+            **f,
+            'predicted': float(f['predicted']),
+            'actual': obs[later][1],
+            'naive': obs[known - 1][1] if known > 0 else None,
+        })
+
+    by_model = defaultdict(list)
+    by_fund = defaultdict(lambda: defaultdict(list))
+    for s in scored:
+        by_model[s['model']].append(s)
+        by_fund[s['fund']][s['model']].append(s)
+
+    models = {}
+    for model, rows in by_model.items():
+        versions = defaultdict(list)
+        for r in rows:
+            versions[r['version'] or 'unversioned'].append(r)
+        models[model] = {
+            **_with_baseline(rows),
+            'by_version': {v: _metrics(vr) for v, vr in sorted(versions.items())},
+        }
+
+    return {
+        'n_forecasts': len(forecasts),
+        'n_scored': len(scored),
+        'n_pending': pending,
+        'by_model': dict(sorted(models.items(), key=lambda m: m[1]['metrics']['mae'])),
+        'by_fund': {
+            str(fund): {model: _metrics(rows) for model, rows in fund_models.items()}
+            for fund, fund_models in by_fund.items()
+        },
+    }
+
+
+def _metrics(rows: List[Dict]) -> Dict[str, float]:
+    """forecast_error_metrics on scored rows."""
+    return forecast_error_metrics([r['actual'] for r in rows], [r['predicted'] for r in rows])
+
+
+def _with_baseline(rows: List[Dict]) -> Dict[str, any]:
+    """Model metrics plus the naive baseline on forecasts that have one."""
+    comparable = [r for r in rows if r['naive'] is not None]
+    result = {'metrics': _metrics(rows), 'naive': None, 'skill': None, 'beats_naive': None}
+    if not comparable:
+        return result
+
+    model_mae = float(np.mean([abs(r['predicted'] - r['actual']) for r in comparable]))
+    naive = forecast_error_metrics([r['actual'] for r in comparable], [r['naive'] for r in comparable])
+    result['naive'] = naive
+    if naive['mae'] > 0:
+        result['skill'] = 1 - model_mae / naive['mae']
+    result['beats_naive'] = model_mae < naive['mae']
+    return result
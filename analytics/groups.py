@@ -0,0 +1,91 @@
+"""
+Portfolio Group Hierarchies
+
+Rolls fund figures up a tree of portfolio groups (e.g. Private Equity →
+Buyout → US Buyout), so every node reports its own funds plus everything
+beneath it.
+
+Rollup:
+------
+Additive figures (fund count, commitments, invested capital, NAV) are
+summed over the subtree. The commitment-weighted IRR is recomputed from the
+summed parts rather than averaged across child nodes:
+
+    IRR_g = Σ IRR_i C_i / Σ C_i    over subtree funds i with an IRR
+
+and each node's share of NAV is its rolled-up NAV over the total NAV of
+all root nodes.
+"""
+
+from typing import Dict, List
+
+ADDITIVE_FIELDS = (
+    'num_funds', 'total_committed', 'total_invested', 'total_nav',
+    'irr_weighted', 'irr_committed'
+)
+
+
+def rollup_groups(groups: List[Dict]) -> List[Dict]:
+    """
+    Build the group tree and roll figures up it.
+
+    Parameters:
+        groups: Nodes with 'group_id', 'parent_id' (None for a root), any
+            descriptive fields, and the ADDITIVE_FIELDS of the funds
+            assigned directly to the node (irr_weighted = Σ IRR_i C_i and
+            irr_committed = Σ C_i over funds with an IRR)
+
+    Returns:
+        Root nodes, each with 'direct' and 'rollup' figures, 'depth',
+        'path' (names from the root) and nested 'children'
+    """
+    nodes = {}
+    for g in groups:
+        if g['group_id'] in nodes:
+            raise ValueError(f"Duplicate group id {g['group_id']}")
+        direct = {k: float(g.get(k) or 0.0) for k in ADDITIVE_FIELDS}
+        direct['num_funds'] = int(direct['num_funds'])
+        nodes[g['group_id']] = {
+            **{k: v for k, v in g.items() if k not in ADDITIVE_FIELDS},
+            'direct': direct,
+            'children': [],
+        }
+
+    roots = []
+    for node in nodes.values():
+        parent_id = node.get('parent_id')
+        if parent_id is None:
+            roots.append(node)
+        elif parent_id not in nodes:
+            raise ValueError(f"Group {node['group_id']} has unknown parent {parent_id}")
+        else:
+            nodes[parent_id]['children'].append(node)
+
+    visited = set()
+
+    def visit(node: Dict, depth: int, path: List[str]) -> Dict[str, float]:
+        visited.add(node['group_id'])
+        node['depth'] = depth
+        node['path'] = path + [node.get('group_name', str(node['group_id']))]
+        node['children'].sort(key=lambda c: (c.get('group_name') or '', c['group_id']))
+
+        totals = dict(node['direct'])
+        for child in node['children']:
+            for k, v in visit(child, depth + 1, node['path']).items():
+                totals[k] += v
+        node['rollup'] = totals
+        return totals
+
+    roots.sort(key=lambda r: (r.get('group_name') or '', r['group_id']))
+    grand_nav = sum(visit(root, 0, [])['total_nav'] for root in roots)
+    if len(visited) != len(nodes):
+        raise ValueError("Group hierarchy contains a cycle")
+
+    for node in nodes.values():
+        for figures in (node['direct'], node['rollup']):
+            committed = figures.pop('irr_committed')
+            weighted = figures.pop('irr_weighted')
+            figures['commitment_weighted_irr'] = weighted / committed if committed > 0 else None
+        node['rollup']['nav_share'] = node['rollup']['total_nav'] / grand_nav if grand_nav > 0 else None
+
+    return roots
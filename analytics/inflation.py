@@ -0,0 +1,181 @@
+"""
+Inflation Adjustment
+
+Restates nominal returns, cash flows and IRRs in real terms using a CPI
+index series.
+
+Formulas:
+--------
+- Period inflation:  π_t = CPI(d_t) / CPI(d_{t-1}) - 1
+- Real return:       (1 + r_t) / (1 + π_t) - 1          (Fisher)
+- Real cash flow:    CF_t × CPI(d_base) / CPI(d_t)
+- Real IRR:          IRR of the real cash flows
+
+CPI levels between observation dates are linearly interpolated; dates
+outside the series are rejected rather than extrapolated.
+"""
+
+import numpy as np
+from datetime import date
+from scipy.optimize import brentq
+from typing import Dict, List, Optional
+
+
+class CPISeries:
+    """
+    Consumer price index series for deflating nominal figures.
+
+    Attributes:
+        dates (List[date]): Observation dates, ascending
+        levels (np.ndarray): Index levels
+        name (str): Series name (e.g. 'CPI-U')
+
+    Example:
+        >>> cpi = CPISeries([date(2020, 1, 31), date(2024, 1, 31)], [258.7, 308.4])
+        >>> cpi.deflate_returns([date(2023, 1, 31), date(2024, 1, 31)], [0.10])
+    """
+
+    def __init__(self, dates: List[date], levels: List[float], name: str = 'CPI-U'):
+        """
+        Initialize CPI series.
+
+        Parameters:
+            dates: Observation dates
+            levels: Index level at each date
+            name: Series name
+        """
+        if len(dates) < 2 or len(dates) != len(levels):
+            raise ValueError("Need at least 2 CPI observations with one level per date")
+
+        order = np.argsort([d.toordinal() for d in dates])
+        self.dates = [dates[i] for i in order]
+        self.levels = np.asarray(levels, dtype=float)[order]
+        self.name = name
+        self._ordinals = np.array([d.toordinal() for d in self.dates], dtype=float)
+
+        if np.any(self.levels <= 0):
+            raise ValueError("CPI levels must be positive")
+        if np.any(np.diff(self._ordinals) == 0):
+            raise ValueError("CPI dates must be unique")
+
+    def level(self, when: date) -> float:
+        """
+        Interpolated index level on a date.
+
+        Parameters:
+            when: Date within the series range
+
+        Returns:
+            CPI level
+        """
+        ordinal = when.toordinal()
+        if ordinal < self._ordinals[0] or ordinal > self._ordinals[-1]:
+            raise ValueError(
+                f"{when.isoformat()} is outside the {self.name} series "
+                f"({self.dates[0].isoformat()} to {self.dates[-1].isoformat()})"
+            )
+        return float(np.interp(ordinal, self._ordinals, self.levels))
+
+    def deflate_returns(self, dates: List[date], returns: List[float]) -> np.ndarray:
+        """
+        Convert nominal period returns to real returns.
+
+        Parameters:
+            dates: Period boundary dates (one more than returns)
+            returns: Nominal return for each period
+
+        Returns:
+            Real period returns
+        """
+        returns = np.asarray(returns, dtype=float)
+        if len(dates) != len(returns) + 1:
+            raise ValueError("dates must have one more entry than returns (period boundaries)")
+
+        levels = np.array([self.level(d) for d in dates])
+        inflation = levels[1:] / levels[:-1] - 1
+        return (1 + returns) / (1 + inflation) - 1
+
+    def deflate_cash_flows(
+        self,
+        dates: List[date],
+        amounts: List[float],
+        base_date: Optional[date] = None
+    ) -> np.ndarray:
+        """
+        Restate cash flows in base-date purchasing power.
+
+        Parameters:
+            dates: Cash flow dates
+            amounts: Nominal amounts
+            base_date: Date whose prices are used (default: last cash flow date)
+
+        Returns:
+            Real amounts
+        """
+        base = self.level(base_date or max(dates))
+        return np.array([a * base / self.level(d) for d, a in zip(dates, amounts)])
+
+    def deflate_rate(self, nominal_rate: float, start: date, end: date) -> float:
+        """
+        Real equivalent of an annualized nominal rate over [start, end].
+
+        Parameters:
+            nominal_rate: Annualized nominal rate
+            start: Start of the period
+            end: End of the period
+
+        Returns:
+            Annualized real rate
+        """
+        years = (end - start).days / 365.25
+        if years <= 0:
+            raise ValueError("end must be after start")
+        annual_inflation = (self.level(end) / self.level(start)) ** (1 / years) - 1
+        return (1 + nominal_rate) / (1 + annual_inflation) - 1
+
+
+def xirr(dates: List[date], amounts: List[float]) -> float:
+    """
+    Internal rate of return for dated cash flows (Actual/365).
+
+    Parameters:
+        dates: Cash flow dates
+        amounts: Cash flow amounts (contributions negative)
+
+    Returns:
+        Annualized IRR
+    """
+    amounts = np.asarray(amounts, dtype=float)
+    if not (np.any(amounts < 0) and np.any(amounts > 0)):
+        raise ValueError("IRR needs at least one negative and one positive cash flow")
+
+    start = min(dates)
+    years = np.array([(d - start).days / 365.0 for d in dates])
+
+    def npv(rate):
+        return np.sum(amounts / (1 + rate) ** years)
+
+    return float(brentq(npv, -0.9999, 100.0))
+
+
+def nominal_and_real_irr(
+    dates: List[date],
+    amounts: List[float],
+    cpi: Optional[CPISeries] = None
+) -> Dict[str, any]:
+    """
+    IRR of dated cash flows, with the real IRR when a CPI series is given.
+
+    Parameters:
+        dates: Cash flow dates
+        amounts: Nominal amounts (contributions negative)
+        cpi: CPI series covering the cash flow dates
+
+    Returns:
+        Dictionary with 'nominal_irr' and, if cpi is given, 'real_irr'
+    """
+    result = {'nominal_irr': xirr(dates, amounts)}
+    if cpi is not None:
+        result['real_irr'] = xirr(dates, cpi.deflate_cash_flows(dates, amounts))
+        result['cpi_series'] = cpi.name
+    return result
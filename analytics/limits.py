@@ -0,0 +1,142 @@
+"""
+Portfolio Risk Limits
+
+Computes the portfolio-level metrics that risk limits are set on and checks
+them against configured limits.
+
+Formulas:
+--------
+With NAV weights w_i, fund volatilities σ_i and a common pairwise
+correlation ρ between funds:
+
+- Volatility:         σ_p² = ρ (Σ w_i σ_i)² + (1 - ρ) Σ w_i² σ_i²
+- VaR_α (parametric): z_α σ_p √h       (fraction of NAV, horizon h years)
+- Concentration:      max_i w_i, largest sector weight, HHI = Σ w_i²
+
+A limit is breached when the metric exceeds limit_value, and in warning when
+it exceeds the optional warning_value.
+"""
+
+import numpy as np
+from scipy.stats import norm
+from typing import Dict, List, Optional
+
+LIMIT_METRICS = (
+    'portfolio_var', 'portfolio_volatility', 'max_fund_weight', 'max_sector_weight', 'hhi'
+)
+
+
+def portfolio_risk_snapshot(
+    funds: List[Dict],
+    correlation: float = 0.5,
+    confidence_level: float = 0.95,
+    horizon_years: float = 1.0
+) -> Dict[str, any]:
+    """
+    Portfolio volatility, VaR and concentration from fund NAVs and volatilities.
+
+    Parameters:
+        funds: Rows with 'name', 'nav', 'sector' and 'volatility' (annual;
+            funds without one count towards concentration only)
+        correlation: Assumed pairwise correlation between funds
+        confidence_level: VaR confidence level
+        horizon_years: VaR horizon in years
+
+    Returns:
+        Dictionary with 'metrics' (one value per LIMIT_METRICS entry), the
+        largest fund and sector, and the number of funds used
+    """
+    if not -1 <= correlation <= 1:
+        raise ValueError("correlation must be between -1 and 1")
+    if not 0 < confidence_level < 1:
+        raise ValueError("confidence_level must be between 0 and 1")
+
+    funds = [f for f in funds if f['nav'] and f['nav'] > 0]
+    if not funds:
+        raise ValueError("No funds with a positive NAV")
+
+    navs = np.array([float(f['nav']) for f in funds])
+    weights = navs / navs.sum()
+
+    sectors = {}
+    for f, w in zip(funds, weights):
+        sectors[f['sector']] = sectors.get(f['sector'], 0.0) + w
+    top_sector = max(sectors, key=sectors.get)
+    top_fund = int(np.argmax(weights))
+
+    # Volatility over funds that report one, renormalized
+    has_vol = np.array([f.get('volatility') is not None for f in funds])
+    if has_vol.any():
+        w = weights[has_vol] / weights[has_vol].sum()
+        sigma = np.array([float(f['volatility']) for f in funds if f.get('volatility') is not None])
+        ws = w * sigma
+        variance = correlation * ws.sum() ** 2 + (1 - correlation) * np.sum(ws ** 2)
+        vol = float(np.sqrt(max(variance, 0.0)))
+        var = float(norm.ppf(confidence_level) * vol * np.sqrt(horizon_years))
+    else:
+        vol = var = None
+
+    return {
+        'metrics': {
+            'portfolio_var': var,
+            'portfolio_volatility': vol,
+            'max_fund_weight': float(weights[top_fund]),
+            'max_sector_weight': float(sectors[top_sector]),
+            'hhi': float(np.sum(weights ** 2)),
+        },
+        'largest_fund': funds[top_fund]['name'],
+        'largest_sector': top_sector,
+        'n_funds': len(funds),
+        'n_funds_with_volatility': int(has_vol.sum()),
+        'assumptions': {
+            'correlation': correlation,
+            'confidence_level': confidence_level,
+            'horizon_years': horizon_years,
+        },
+    }
+
+
+def evaluate_limits(metrics: Dict[str, Optional[float]], limits: List[Dict]) -> Dict[str, any]:
+    """
+    Check metrics against risk limits.
+
+    Parameters:
+        metrics: Metric name -> current value
+        limits: Rows with 'metric', 'limit_value' and optional 'warning_value'
+
+    Returns:
+        Dictionary with per-limit status ('ok', 'warning', 'breach' or
+        'unavailable') and utilization, plus counts of warnings and breaches
+    """
+    evaluations = []
+    for limit in limits:
+        metric = limit['metric']
+        if metric not in LIMIT_METRICS:
+            raise ValueError(f"Unknown limit metric: {metric}")
+        limit_value = float(limit['limit_value'])
+        warning_value = limit.get('warning_value')
+        if warning_value is not None and float(warning_value) > limit_value:
+            raise ValueError(f"{metric}: warning_value must not exceed limit_value")
+
+        value = metrics.get(metric)
+        if value is None:
+            status = 'unavailable'
+        elif value > limit_value:
+            status = 'breach'
+        elif warning_value is not None and value > float(warning_value):
+            status = 'warning'
+        else:
+            status = 'ok'
+
+        evaluations.append({
+            **limit,
+            'value': value,
+            'status': status,
+            'utilization': value / limit_value if value is not None and limit_value > 0 else None,
+        })
+
+    return {
+        'limits': evaluations,
+        'n_breaches': sum(1 for e in evaluations if e['status'] == 'breach'),
+        'n_warnings': sum(1 for e in evaluations if e['status'] == 'warning'),
+    }
@@ -0,0 +1,251 @@
+"""
+Liquidity Stress Test
+
+Nets simulated capital calls and distributions against a simulated liquid
+portfolio to estimate the probability of a funding shortfall by quarter,
+and tracks the private allocation as liquid assets fall (the denominator
+effect).
+
+Model (quarterly, dt = 1/4):
+-----
+Liquid portfolio return (GBM, with an optional one-off first-quarter shock):
+
+    1 + r_L = exp((μ_L - σ_L²/2) dt + σ_L √dt Z_L)
+
+Private NAV return, marked with a dampened market beta:
+
+    r_P = μ_P dt + β (r_L - μ_L dt) + σ_P √dt Z_P
+
+Capital calls on the remaining unfunded commitment U, accelerated by a
+multiplier a and lognormally noisy, with shocks correlated with the market
+(ρ < 0: calls speed up when markets fall):
+
+    C = U min(1, a c dt exp(σ_c Z_C - σ_c²/2)),   Z_C = ρ Z_L + √(1-ρ²) ε
+
+Distributions D = P d dt (1 - h) with haircut h. Balances roll forward as
+
+    P' = P (1 + r_P) + C - D
+    L' = L (1 + r_L) - C + D - s L dt     (s: annual spending rate)
+
+A shortfall occurs in a quarter when L' falls below the liquidity floor.
+"""
+
+import numpy as np
+from dataclasses import asdict, dataclass
+from typing import Dict, Optional
+
+PERCENTILES = (5, 50, 95)
+
+
+@dataclass
+class LiquidityScenario:
+    """
+    Market and cash flow assumptions for a liquidity stress test.
+
+    Attributes:
+        call_rate: Annual fraction of unfunded commitments called
+        call_acceleration: Multiplier on the call rate
+        call_volatility: Lognormal volatility of the quarterly call rate
+        call_market_correlation: Correlation of call shocks with liquid returns
+        distribution_rate: Annual fraction of private NAV distributed
+        distribution_haircut: Fractional cut to distributions
+        liquid_return: Annual expected return of liquid assets
+        liquid_volatility: Annual volatility of liquid assets
+        liquid_shock: Immediate drawdown applied in the first quarter (e.g. -0.25)
+        private_return: Annual expected return of private NAV
+        private_beta: Sensitivity of private marks to liquid return surprises
+        private_volatility: Annual idiosyncratic volatility of private NAV
+        spending_rate: Annual outflow as a fraction of liquid assets
+    """
+    call_rate: float = 0.25
+    call_acceleration: float = 1.0
+    call_volatility: float = 0.3
+    call_market_correlation: float = -0.3
+    distribution_rate: float = 0.15
+    distribution_haircut: float = 0.0
+    liquid_return: float = 0.06
+    liquid_volatility: float = 0.16
+    liquid_shock: float = 0.0
+    private_return: float = 0.08
+    private_beta: float = 0.5
+    private_volatility: float = 0.08
+    spending_rate: float = 0.0
+
+    def __post_init__(self):
+        if self.call_rate < 0 or self.call_acceleration < 0 or self.distribution_rate < 0:
+            raise ValueError("Call and distribution rates must be non-negative")
+        if not 0 <= self.distribution_haircut <= 1:
+            raise ValueError("distribution_haircut must be between 0 and 1")
+        if not -1 <= self.call_market_correlation <= 1:
+            raise ValueError("call_market_correlation must be between -1 and 1")
+        if not -1 < self.liquid_shock <= 0:
+            raise ValueError("liquid_shock must be in (-1, 0]")
+        if min(self.call_volatility, self.liquid_volatility, self.private_volatility, self.spending_rate) < 0:
+            raise ValueError("Volatilities and spending_rate must be non-negative")
+
+    @classmethod
+    def stressed(cls, **overrides) -> 'LiquidityScenario':
+        """GFC-style preset: faster calls, halved distributions, a 25% liquid drawdown."""
+        preset = dict(
+            call_acceleration=1.5,
+            call_market_correlation=-0.5,
+            distribution_haircut=0.5,
+            liquid_return=0.0,
+            liquid_volatility=0.25,
+            liquid_shock=-0.25,
+        )
+        preset.update(overrides)
+        return cls(**preset)
+
+
+class LiquidityStressTest:
+    """
+    Monte Carlo of capital calls against liquid assets.
+
+    Attributes:
+        liquid_assets (float): Initial liquid portfolio value
+        private_nav (float): Initial private markets NAV
+        unfunded_commitments (float): Initial unfunded commitments
+        n_paths (int): Number of simulated paths
+        seed (int): Random seed
+
+    Example:
+        >>> test = LiquidityStressTest(liquid_assets=400e6, private_nav=500e6, unfunded_commitments=300e6)
+        >>> stress = test.run(LiquidityScenario.stressed(), quarters=12, max_private_weight=0.6)
+        >>> stress['prob_shortfall']
+    """
+
+    def __init__(
+        self,
+        liquid_assets: float,
+        private_nav: float,
+        unfunded_commitments: float,
+        n_paths: int = 10000,
+        seed: Optional[int] = None
+    ):
+        """
+        Initialize stress test.
+
+        Parameters:
+            liquid_assets: Liquid portfolio value
+            private_nav: Private markets NAV
+            unfunded_commitments: Remaining commitments that can be called
+            n_paths: Number of simulated paths
+            seed: Random seed for reproducibility
+        """
+        if liquid_assets <= 0:
+            raise ValueError("liquid_assets must be positive")
+        if private_nav < 0 or unfunded_commitments < 0:
+            raise ValueError("private_nav and unfunded_commitments must be non-negative")
+
+        self.liquid_assets = liquid_assets
+        self.private_nav = private_nav
+        self.unfunded_commitments = unfunded_commitments
+        self.n_paths = n_paths
+        self.seed = seed
+
+    def run(
+        self,
+        scenario: LiquidityScenario,
+        quarters: int = 12,
+        liquidity_floor: float = 0.0,
+        max_private_weight: Optional[float] = None
+    ) -> Dict[str, any]:
+        """
+        Simulate the scenario quarter by quarter.
+
+        The same seed gives the same random draws for every scenario, so
+        runs can be compared directly.
+
+        Parameters:
+            scenario: Market and cash flow assumptions
+            quarters: Number of quarters to simulate
+            liquidity_floor: Minimum liquid balance; falling below it is a shortfall
+            max_private_weight: Policy cap on private / (private + liquid)
+
+        Returns:
+            Dictionary with the overall shortfall probability and, per
+            quarter, the probability of a shortfall in that quarter and by
+            that quarter, the expected shortfall size, percentiles of calls,
+            net cash flow, liquid assets, coverage of remaining commitments
+            and private weight, and the probability of exceeding the cap
+        """
+        if quarters < 1:
+            raise ValueError("quarters must be at least 1")
+        dt = 0.25
+        s = scenario
+        rng = np.random.default_rng(self.seed)
+
+        liquid = np.full(self.n_paths, float(self.liquid_assets))
+        private = np.full(self.n_paths, float(self.private_nav))
+        unfunded = np.full(self.n_paths, float(self.unfunded_commitments))
+        ever_short = np.zeros(self.n_paths, dtype=bool)
+
+        by_quarter = []
+        for q in range(1, quarters + 1):
+            z_liquid, z_private, z_other = rng.standard_normal((3, self.n_paths))
+            z_call = s.call_market_correlation * z_liquid + np.sqrt(1 - s.call_market_correlation ** 2) * z_other
+
+            r_liquid = np.expm1(
+                (s.liquid_return - 0.5 * s.liquid_volatility ** 2) * dt + s.liquid_volatility * np.sqrt(dt) * z_liquid
+            )
+            if q == 1:
+                r_liquid = (1 + r_liquid) * (1 + s.liquid_shock) - 1
+            r_private = (
+                s.private_return * dt
+                + s.private_beta * (r_liquid - s.liquid_return * dt)
+                + s.private_volatility * np.sqrt(dt) * z_private
+            )
+
+            call_fraction = np.minimum(
+                1.0,
+                s.call_acceleration * s.call_rate * dt
+                * np.exp(s.call_volatility * z_call - 0.5 * s.call_volatility ** 2)
+            )
+            calls = unfunded * call_fraction
+            distributions = np.maximum(private, 0) * s.distribution_rate * dt * (1 - s.distribution_haircut)
+
+            unfunded = unfunded - calls
+            private = np.maximum(private * (1 + r_private), 0) + calls - distributions
+            liquid = liquid * (1 + r_liquid) - calls + distributions - s.spending_rate * dt * np.maximum(liquid, 0)
+
+            short = liquid < liquidity_floor
+            ever_short |= short
+            total = private + np.maximum(liquid, 0)
+            private_weight = np.divide(private, total, out=np.ones_like(total), where=total > 0)
+
+            row = {
+                'quarter': q,
+                'prob_shortfall': float(np.mean(short)),
+                'cumulative_prob_shortfall': float(np.mean(ever_short)),
+                'expected_shortfall': float(np.mean(liquidity_floor - liquid[short])) if short.any() else 0.0,
+                'calls': _percentiles(calls),
+                'net_cash_flow': _percentiles(distributions - calls),
+                'liquid_assets': _percentiles(liquid),
+                'unfunded_commitments': float(np.mean(unfunded)),
+                'private_weight': _percentiles(private_weight),
+            }
+            if self.unfunded_commitments > 0:
+                coverage = np.divide(liquid, unfunded, out=np.full_like(liquid, np.inf), where=unfunded > 0)
+                row['coverage_ratio'] = _percentiles(np.minimum(coverage, 1e6))
+            if max_private_weight is not None:
+                row['prob_over_max_private_weight'] = float(np.mean(private_weight > max_private_weight))
+            by_quarter.append(row)
+
+        worst = max(by_quarter, key=lambda r: r['prob_shortfall'])
+        return {
+            'scenario': asdict(scenario),
+            'quarters': quarters,
+            'n_paths': self.n_paths,
+            'liquidity_floor': liquidity_floor,
+            'max_private_weight': max_private_weight,
+            'initial_private_weight': self.private_nav / (self.private_nav + self.liquid_assets),
+            'prob_shortfall': float(np.mean(ever_short)),
+            'worst_quarter': worst['quarter'] if worst['prob_shortfall'] > 0 else None,
+            'by_quarter': by_quarter,
+        }
+
+
+def _percentiles(x: np.ndarray) -> Dict[str, float]:
+    """Selected percentiles keyed as strings."""
+    return {str(p): float(v) for p, v in zip(PERCENTILES, np.percentile(x, PERCENTILES))}
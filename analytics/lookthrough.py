@@ -0,0 +1,115 @@
+"""
+Look-Through Exposure Analytics
+
+Aggregates exposure to underlying portfolio companies across all funds,
+deduplicating companies held by more than one fund.
+
+Method:
+------
+Our exposure to company c through fund f is pro-rata to our NAV in f:
+
+    E_{f,c} = V_{f,c} × NAV_f / Σ_c' V_{f,c'}
+
+where V_{f,c} is the fund's current valuation of c. Exposures are then
+summed per company, sector and geography. Concentration is summarized by
+the Herfindahl-Hirschman index HHI = Σ w_c² and the top-10 company share.
+"""
+
+from collections import defaultdict
+from typing import Dict, List
+
+
+def lookthrough_exposure(
+    funds: List[Dict],
+    companies: List[Dict],
+    investments: List[Dict]
+) -> Dict[str, any]:
+    """
+    Compute look-through company, sector and geography exposure.
+
+    Parameters:
+        funds: Fund rows with 'fund_id', 'current_nav' and optionally 'sector'
+        companies: Company rows with 'company_id', 'company_name', 'sector', 'geography'
+        investments: Position rows with 'fund_id', 'company_id', 'current_valuation'
+
+    Returns:
+        Dictionary with exposure by company, sector and geography, the
+        fund-level (reported) sector split for comparison, and concentration
+        statistics
+    """
+    company_by_id = {c['company_id']: c for c in companies}
+    nav_by_fund = {f['fund_id']: float(f.get('current_nav') or 0.0) for f in funds}
+
+    fund_valuation = defaultdict(float)
+    for inv in investments:
+        fund_valuation[inv['fund_id']] += float(inv.get('current_valuation') or 0.0)
+
+    company_exposure = defaultdict(float)
+    company_funds = defaultdict(set)
+    for inv in investments:
+        fund_id = inv['fund_id']
+        valuation = float(inv.get('current_valuation') or 0.0)
+        if valuation <= 0 or fund_valuation[fund_id] <= 0:
+            continue
+
+        scale = nav_by_fund.get(fund_id, 0.0) / fund_valuation[fund_id]
+        company_exposure[inv['company_id']] += valuation * scale
+        company_funds[inv['company_id']].add(fund_id)
+
+    total = sum(company_exposure.values())
+    if total <= 0:
+        raise ValueError("No unrealized company exposure to look through")
+
+    by_company = []
+    sector_exposure = defaultdict(float)
+    geography_exposure = defaultdict(float)
+    for company_id, exposure in company_exposure.items():
+        company = company_by_id.get(company_id, {})
+        sector = company.get('sector') or 'Unknown'
+        geography = company.get('geography') or 'Unknown'
+
+        sector_exposure[sector] += exposure
+        geography_exposure[geography] += exposure
+
+        by_company.append({
+            'company_id': company_id,
+            'company_name': company.get('company_name'),
+            'sector': sector,
+            'geography': geography,
+            'exposure': exposure,
+            'weight': exposure / total,
+            'num_funds': len(company_funds[company_id]),
+            'fund_ids': sorted(company_funds[company_id]),
+        })
+
+    by_company.sort(key=lambda c: c['exposure'], reverse=True)
+
+    # Fund-level sector labels, for comparison with the look-through split
+    reported_sector = defaultdict(float)
+    for f in funds:
+        reported_sector[f.get('sector') or 'Unknown'] += nav_by_fund[f['fund_id']]
+    reported_total = sum(reported_sector.values())
+
+    weights = [c['weight'] for c in by_company]
+
+    return {
+        'total_exposure': total,
+        'by_company': by_company,
+        'by_sector': _breakdown(sector_exposure, total),
+        'by_geography': _breakdown(geography_exposure, total),
+        'reported_sector': _breakdown(reported_sector, reported_total) if reported_total > 0 else [],
+        'n_companies': len(by_company),
+        'n_positions': sum(c['num_funds'] for c in by_company),
+        'n_shared_companies': sum(1 for c in by_company if c['num_funds'] > 1),
+        'top10_weight': sum(weights[:10]),
+        'hhi': sum(w ** 2 for w in weights),
+    }
+
+
+def _breakdown(exposure: Dict[str, float], total: float) -> List[Dict]:
+    """Exposure and weight per key, largest first."""
+    return sorted(
+        ({'name': k, 'exposure': v, 'weight': v / total} for k, v in exposure.items()),
+        key=lambda row: row['exposure'],
+        reverse=True
+    )
@@ -0,0 +1,135 @@
+"""
+Model Registry Parameters
+
+Validates calibrated model parameters before they are registered and turns
+registered fits into simulation inputs, so simulations read their inputs
+from one stored source.
+
+Model Types:
+-----------
+- normal, lognormal, student_t, garch: parameters as returned by
+  DistributionCalibrator (per period at the registered frequency)
+- regime_switching: {'regimes': [{'name', 'mu', 'sigma'}, ...],
+                     'transition_matrix': K×K row-stochastic matrix}
+- factor_loadings:  {'factors': [...], 'loadings': {entity: [β per factor]},
+                     optional 'residual_volatility': {entity: σ}}
+
+Simulation inputs (annual drift μ_A and volatility σ_A, f periods per year):
+---------------------------------------------------------------------------
+    normal:            μ_A = μ f,                σ_A = σ √f
+    lognormal:         μ_A = (μ + σ²/2) f,       σ_A = σ √f
+    student_t:         μ_A = loc f,              σ_A = scale √(ν / (ν - 2)) √f   (ν > 2)
+    garch:             μ_A = μ f,                σ_A = √(ω / (1 - α - β)) √f
+    regime_switching:  stationary mixture π P = π of the regimes,
+                       μ = Σ π_k μ_k,  σ² = Σ π_k (σ_k² + μ_k²) - μ²  (then annualized)
+
+Factor loadings have no single-asset drift or volatility and are used as
+stored.
+"""
+
+import numpy as np
+from typing import Dict
+
+MODEL_TYPES = ('normal', 'lognormal', 'student_t', 'garch', 'regime_switching', 'factor_loadings')
+
+REQUIRED_PARAMETERS = {
+    'normal': ('mu', 'sigma'),
+    'lognormal': ('mu', 'sigma'),
+    'student_t': ('df', 'loc', 'scale'),
+    'garch': ('mu', 'omega', 'alpha', 'beta'),
+    'regime_switching': ('regimes', 'transition_matrix'),
+    'factor_loadings': ('factors', 'loadings'),
+}
+
+
+def validate_model_parameters(model_type: str, parameters: Dict) -> None:
+    """
+    Check that parameters are complete and consistent for a model type.
+
+    Parameters:
+        model_type: One of MODEL_TYPES
+        parameters: Model parameters
+
+    Raises:
+        ValueError: Unknown model type or invalid parameters
+    """
+    if model_type not in MODEL_TYPES:
+        raise ValueError(f"Unknown model type: {model_type}")
+    missing = [k for k in REQUIRED_PARAMETERS[model_type] if parameters.get(k) is None]
+    if missing:
+        raise ValueError(f"{model_type} parameters missing: {', '.join(missing)}")
+
+    if model_type in ('normal', 'lognormal') and parameters['sigma'] <= 0:
+        raise ValueError("sigma must be positive")
+    if model_type == 'student_t' and (parameters['df'] <= 0 or parameters['scale'] <= 0):
+        raise ValueError("df and scale must be positive")
+    if model_type == 'garch':
+        if parameters['omega'] <= 0 or parameters['alpha'] < 0 or parameters['beta'] < 0:
+            raise ValueError("GARCH requires omega > 0 and alpha, beta >= 0")
+        if parameters['alpha'] + parameters['beta'] >= 1:
+            raise ValueError("GARCH requires alpha + beta < 1 (stationarity)")
+
+    if model_type == 'regime_switching':
+        regimes = parameters['regimes']
+        P = np.asarray(parameters['transition_matrix'], dtype=float)
+        if P.shape != (len(regimes), len(regimes)) or len(regimes) < 2:
+            raise ValueError("transition_matrix must be K×K for K >= 2 regimes")
+        if np.any(P < 0) or not np.allclose(P.sum(axis=1), 1.0):
+            raise ValueError("transition_matrix rows must be non-negative and sum to 1")
+        if any(r.get('mu') is None or r.get('sigma') is None or r['sigma'] < 0 for r in regimes):
+            raise ValueError("Each regime needs mu and a non-negative sigma")
+
+    if model_type == 'factor_loadings':
+        n_factors = len(parameters['factors'])
+        if n_factors == 0 or not parameters['loadings']:
+            raise ValueError("factor_loadings needs factors and at least one set of loadings")
+        bad = [name for name, betas in parameters['loadings'].items() if len(betas) != n_factors]
+        if bad:
+            raise ValueError(f"Loadings must have one value per factor: {', '.join(bad)}")
+
+
+def simulation_inputs(model_type: str, parameters: Dict, frequency: int) -> Dict[str, float]:
+    """
+    Annual drift and volatility implied by a registered model.
+
+    Parameters:
+        model_type: One of MODEL_TYPES except 'factor_loadings'
+        parameters: Model parameters (per period)
+        frequency: Periods per year of the calibration data
+
+    Returns:
+        Dictionary with annual 'mu' and 'sigma'
+    """
+    validate_model_parameters(model_type, parameters)
+    p = parameters
+
+    if model_type == 'normal':
+        mu, sigma = p['mu'], p['sigma']
+    elif model_type == 'lognormal':
+        mu, sigma = p['mu'] + 0.5 * p['sigma'] ** 2, p['sigma']
+    elif model_type == 'student_t':
+        if p['df'] <= 2:
+            raise ValueError("Student-t volatility is undefined for df <= 2")
+        mu, sigma = p['loc'], p['scale'] * np.sqrt(p['df'] / (p['df'] - 2))
+    elif model_type == 'garch':
+        mu, sigma = p['mu'], np.sqrt(p['omega'] / (1 - p['alpha'] - p['beta']))
+    elif model_type == 'regime_switching':
+        pi = stationary_distribution(p['transition_matrix'])
+        means = np.array([float(r['mu']) for r in p['regimes']])
+        sigmas = np.array([float(r['sigma']) for r in p['regimes']])
+        mu = float(pi @ means)
+        sigma = np.sqrt(max(float(pi @ (sigmas ** 2 + means ** 2)) - mu ** 2, 0.0))
+    else:
+        raise ValueError(f"{model_type} models do not define a single drift and volatility")
+
+    return {'mu': float(mu * frequency), 'sigma': float(sigma * np.sqrt(frequency))}
+
+
+def stationary_distribution(transition_matrix) -> np.ndarray:
+    """Stationary distribution π (π P = π, Σ π = 1) of a Markov chain."""
+    P = np.asarray(transition_matrix, dtype=float)
+    k = P.shape[0]
+    A = np.vstack([P.T - np.eye(k), np.ones(k)])
+    b = np.append(np.zeros(k), 1.0)
+    pi, *_ = np.linalg.lstsq(A, b, rcond=None)
+    return np.clip(pi, 0.0, None) / np.clip(pi, 0.0, None).sum()
@@ -0,0 +1,109 @@
+"""
+Peer Universe Quartile Benchmarking
+
+Ranks funds against peer universe quartile breakpoints by vintage year and
+strategy (Preqin/Cambridge-style).
+
+Quartiles:
+---------
+- 1: value ≥ upper quartile
+- 2: median ≤ value < upper quartile
+- 3: lower quartile ≤ value < median
+- 4: value < lower quartile
+
+A fund is compared to breakpoints for its own strategy when available and
+to the all-strategy ('All') universe for its vintage otherwise.
+"""
+
+from typing import Dict, List, Optional, Tuple
+
+
+METRICS = ('IRR', 'TVPI')
+
+
+def peer_quartile(value: float, upper: float, median: float, lower: float) -> int:
+    """
+    Quartile rank of a value against peer breakpoints.
+
+    Parameters:
+        value: Fund metric
+        upper: Upper quartile breakpoint
+        median: Median breakpoint
+        lower: Lower quartile breakpoint
+
+    Returns:
+        Quartile 1 (top) to 4 (bottom)
+    """
+    if not lower <= median <= upper:
+        raise ValueError("Breakpoints must satisfy lower ≤ median ≤ upper")
+
+    if value >= upper:
+        return 1
+    if value >= median:
+        return 2
+    if value >= lower:
+        return 3
+    return 4
+
+
+def rank_funds(funds: List[Dict], breakpoints: List[Dict]) -> Dict[str, any]:
+    """
+    Rank funds against a peer universe.
+
+    Parameters:
+        funds: Fund rows with 'fund_id', 'fund_name', 'vintage', 'strategy',
+            'irr' and 'tvpi'
+        breakpoints: Rows with 'vintage', 'strategy', 'metric' (IRR or TVPI),
+            'upper_quartile', 'median' and 'lower_quartile'
+
+    Returns:
+        Dictionary with per-fund quartile rankings and the quartile
+        distribution of the portfolio for each metric
+    """
+    lookup: Dict[Tuple[int, str, str], Dict] = {}
+    for bp in breakpoints:
+        if bp['metric'] not in METRICS:
+            raise ValueError(f"Unknown metric: {bp['metric']}")
+        lookup[(int(bp['vintage']), bp['strategy'], bp['metric'])] = bp
+
+    rankings = []
+    distribution = {metric: {q: 0 for q in (1, 2, 3, 4)} for metric in METRICS}
+
+    for fund in funds:
+        row = {
+            'fund_id': fund['fund_id'],
+            'fund_name': fund.get('fund_name'),
+            'vintage': fund['vintage'],
+            'strategy': fund.get('strategy'),
+        }
+
+        for metric in METRICS:
+            value = fund.get(metric.lower())
+            bp = _find_breakpoints(lookup, int(fund['vintage']), fund.get('strategy'), metric)
+
+            quartile = None
+            if value is not None and bp is not None:
+                quartile = peer_quartile(
+                    float(value), float(bp['upper_quartile']), float(bp['median']), float(bp['lower_quartile'])
+                )
+                distribution[metric][quartile] += 1
+
+            row[metric.lower()] = float(value) if value is not None else None
+            row[f'{metric.lower()}_quartile'] = quartile
+            row[f'{metric.lower()}_peer_group'] = bp['strategy'] if bp is not None else None
+
+        rankings.append(row)
+
+    return {
+        'rankings': rankings,
+        'distribution': distribution,
+        'n_funds': len(rankings),
+        'n_unranked': sum(1 for r in rankings if r['irr_quartile'] is None and r['tvpi_quartile'] is None),
+    }
+
+
+def _find_breakpoints(lookup: Dict, vintage: int, strategy: Optional[str], metric: str) -> Optional[Dict]:
+    """Strategy-specific breakpoints, falling back to the all-strategy universe."""
+    if strategy is not None and (vintage, strategy, metric) in lookup:
+        return lookup[(vintage, strategy, metric)]
+    return lookup.get((vintage, 'All', metric))
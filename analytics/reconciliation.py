@@ -0,0 +1,185 @@
+"""
+Cash Reconciliation
+
+Matches bank/custodian cash transactions against recorded capital calls
+and distributions and reports the breaks.
+
+Matching:
+--------
+A bank item and a recorded item are candidates when their signed amounts
+agree within the amount tolerance, their dates are within the date window
+and their references are at least min_reference_similarity alike.
+Candidates are scored
+
+    score = 0.5 (1 - |Δamount| / tolerance) + 0.3 (1 - |Δdays| / window) + 0.2 similarity
+
+where similarity is the fuzzy (difflib) ratio of the normalized references
+with their tokens sorted, so word order does not matter.
+Pairs are assigned greedily by descending score (ties keep input order),
+so each item is matched at most once.
+
+Breaks:
+------
+- amount_mismatch:   similar reference and date window, amount outside tolerance
+- unmatched_bank:    bank item with no recorded counterpart
+- unmatched_record:  recorded item with no bank counterpart
+
+An item that was a candidate but lost the greedy assignment to a better
+pair is reported as unmatched, not as an amount mismatch.
+"""
+
+import re
+from datetime import date
+from difflib import SequenceMatcher
+from typing import Dict, List
+
+AMOUNT_WEIGHT = 0.5
+DATE_WEIGHT = 0.3
+REFERENCE_WEIGHT = 0.2
+
+
+def reconcile_cash(
+    bank_items: List[Dict],
+    recorded_items: List[Dict],
+    amount_tolerance: float = 1.0,
+    date_window_days: int = 5,
+    min_reference_similarity: float = 0.6
+) -> Dict[str, any]:
+    """
+    Match bank transactions to recorded cash flows.
+
+    Parameters:
+        bank_items: Rows with 'id', 'date', signed 'amount' and 'reference'
+        recorded_items: Rows with 'id', 'date', signed 'amount' and
+            'reference' (e.g. fund name and flow description)
+        amount_tolerance: Largest absolute amount difference for a match
+        date_window_days: Largest date difference for a match
+        min_reference_similarity: Reference similarity needed to match two
+            items, or to report a near miss as an amount mismatch rather
+            than two unmatched items
+
+    Returns:
+        Dictionary with matched pairs, breaks and a summary
+    """
+    if amount_tolerance < 0 or date_window_days < 0:
+        raise ValueError("amount_tolerance and date_window_days must be non-negative")
+    if not 0 <= min_reference_similarity <= 1:
+        raise ValueError("min_reference_similarity must be between 0 and 1")
+
+    candidates = []
+    for b in bank_items:
+        for r in recorded_items:
+            amount_diff = abs(float(b['amount']) - float(r['amount']))
+            day_diff = abs((_as_date(b['date']) - _as_date(r['date'])).days)
+            if amount_diff > amount_tolerance or day_diff > date_window_days:
+                continue
+            similarity = _similarity(b.get('reference'), r.get('reference'))
+            if similarity < min_reference_similarity:
+                continue
+            score = (
+                AMOUNT_WEIGHT * (1 - amount_diff / amount_tolerance if amount_tolerance > 0 else 1)
+                + DATE_WEIGHT * (1 - day_diff / date_window_days if date_window_days > 0 else 1)
+                + REFERENCE_WEIGHT * similarity
+            )
+            candidates.append((score, b['id'], r['id'], amount_diff, day_diff, similarity))
+
+    matches, used_bank, used_record = [], set(), set()
+    for score, bank_id, record_id, amount_diff, day_diff, similarity in sorted(candidates, key=lambda c: -c[0]):
+        if bank_id in used_bank or record_id in used_record:
+            continue
+        used_bank.add(bank_id)
+        used_record.add(record_id)
+        matches.append({
+            'bank_id': bank_id,
+            'record_id': record_id,
+            'score': score,
+            'amount_difference': amount_diff,
+            'day_difference': day_diff,
+            'reference_similarity': similarity,
+        })
+
+    open_bank = [b for b in bank_items if b['id'] not in used_bank]
+    open_records = [r for r in recorded_items if r['id'] not in used_record]
+
+    breaks = []
+    for b in open_bank:
+        best = None
+        for r in open_records:
+            if r['id'] in used_record:
+                continue
+            # Within tolerance means the pair lost the greedy assignment, not an amount break
+            if abs(float(b['amount']) - float(r['amount'])) <= amount_tolerance:
+                continue
+            if abs((_as_date(b['date']) - _as_date(r['date'])).days) > date_window_days:
+                continue
+            similarity = _similarity(b.get('reference'), r.get('reference'))
+            if similarity >= min_reference_similarity and (best is None or similarity > best[0]):
+                best = (similarity, r)
+        if best is not None:
+            similarity, r = best
+            used_bank.add(b['id'])
+            used_record.add(r['id'])
+            breaks.append({
+                'type': 'amount_mismatch',
+                'bank_id': b['id'],
+                'record_id': r['id'],
+                'date': _as_date(b['date']).isoformat(),
+                'bank_amount': float(b['amount']),
+                'record_amount': float(r['amount']),
+                'difference': float(b['amount']) - float(r['amount']),
+                'reference_similarity': similarity,
+            })
+
+    for b in bank_items:
+        if b['id'] not in used_bank:
+            breaks.append({
+                'type': 'unmatched_bank',
+                'bank_id': b['id'],
+                'date': _as_date(b['date']).isoformat(),
+                'bank_amount': float(b['amount']),
+                'reference': b.get('reference'),
+            })
+    for r in recorded_items:
+        if r['id'] not in used_record:
+            breaks.append({
+                'type': 'unmatched_record',
+                'record_id': r['id'],
+                'date': _as_date(r['date']).isoformat(),
+                'record_amount': float(r['amount']),
+                'reference': r.get('reference'),
+            })
+
+    return {
+        'matches': matches,
+        'breaks': breaks,
+        'summary': {
+            'n_bank': len(bank_items),
+            'n_recorded': len(recorded_items),
+            'n_matched': len(matches),
+            'n_breaks': len(breaks),
+            'break_counts': {
+                t: sum(1 for br in breaks if br['type'] == t)
+                for t in ('amount_mismatch', 'unmatched_bank', 'unmatched_record')
+            },
+            'unreconciled_bank_amount': sum(br.get('bank_amount', 0.0) for br in breaks),
+            'unreconciled_record_amount': sum(br.get('record_amount', 0.0) for br in breaks),
+        },
+    }
+
+
+def _normalize(reference) -> str:
+    """Sorted lowercase alphanumeric tokens of a reference string."""
+    return ' '.join(sorted(re.findall(r'[a-z0-9]+', str(reference or '').lower())))
+
+
+def _similarity(a, b) -> float:
+    """Fuzzy similarity of two references in [0, 1]."""
+    a, b = _normalize(a), _normalize(b)
+    if not a or not b:
+        return 0.0
+    return SequenceMatcher(None, a, b).ratio()
+
+
+def _as_date(value) -> date:
+    """Accept date objects or ISO date strings."""
+    return value if isinstance(value, date) else date.fromisoformat(str(value)[:10])
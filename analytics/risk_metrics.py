@@ -0,0 +1,213 @@
+"""
+Return Series Risk Metrics
+
+Volatility, Sharpe ratio, historical VaR/CVaR and benchmark correlation for
+a periodic return series, optionally reported before and after removing
+appraisal smoothing (see analytics.smoothing).
+
+Formulas:
+--------
+- Volatility:  σ_ann = σ × √f
+- Sharpe:      (μ × f - r_f) / σ_ann
+- VaR_α:       -quantile(r, 1 - α)
+- CVaR_α:      -E[r | r ≤ quantile(r, 1 - α)]
+- Beta:        Cov(r, b) / Var(b)
+- Alpha:       μ_r f - [r_f + β (μ_b f - r_f)]   (Jensen)
+
+where f is the number of periods per year.
+
+VaR Decomposition:
+-----------------
+From simulated joint outcomes R (scenarios × holdings) and positions w, the
+portfolio outcome is P = R w and VaR_α = -quantile(P, 1 - α).
+
+- Marginal VaR:     ∂VaR/∂w_i = -E[R_i | P = -VaR]   (Gaussian kernel around the quantile)
+- Component VaR:    w_i × marginal VaR_i              (Euler; sums to VaR)
+- Component CVaR:   -w_i E[R_i | P ≤ -VaR]            (sums to CVaR)
+- Incremental VaR:  VaR(P) - VaR(P - w_i R_i)         (full revaluation without holding i)
+
+The kernel estimate of the marginals is rescaled so the components add up
+to the simulated VaR exactly.
+"""
+
+import numpy as np
+from typing import Dict, Optional, Sequence
+
+from .smoothing import ReturnDesmoother
+
+
+def return_risk_metrics(
+    returns: np.ndarray,
+    benchmark: Optional[np.ndarray] = None,
+    frequency: int = 4,
+    risk_free_rate: float = 0.02,
+    confidence_level: float = 0.95,
+    desmooth: bool = False,
+    method: str = 'getmansky',
+    lags: int = 2
+) -> Dict[str, any]:
+    """
+    Compute risk metrics, optionally alongside de-smoothed equivalents.
+
+    Parameters:
+        returns: Reported periodic returns
+        benchmark: Benchmark returns over the same periods (optional)
+        frequency: Periods per year
+        risk_free_rate: Annual risk-free rate
+        confidence_level: VaR/CVaR confidence level
+        desmooth: Also compute metrics on de-smoothed returns
+        method: De-smoothing method ('geltner' or 'getmansky')
+        lags: Smoothing lags for the Getmansky model
+
+    Returns:
+        Dictionary with 'raw' metrics and, when desmooth is set,
+        'desmoothed' metrics and the de-smoothing model parameters
+    """
+    returns = np.asarray(returns, dtype=float).ravel()
+    if benchmark is not None:
+        benchmark = np.asarray(benchmark, dtype=float).ravel()
+        if len(benchmark) != len(returns):
+            raise ValueError("benchmark must have the same length as returns")
+    if not 0 < confidence_level < 1:
+        raise ValueError("confidence_level must be between 0 and 1")
+
+    result = {
+        'frequency': frequency,
+        'confidence_level': confidence_level,
+        'raw': _metrics(returns, benchmark, frequency, risk_free_rate, confidence_level),
+    }
+
+    if desmooth:
+        desmoother = ReturnDesmoother(returns, frequency=frequency)
+        adjusted = desmoother.getmansky(lags=lags) if method == 'getmansky' else desmoother.desmooth(method)
+        adjusted_returns = np.array(adjusted['returns'])
+
+        # Geltner drops the first observation; align the benchmark to match
+        aligned_benchmark = None
+        if benchmark is not None:
+            aligned_benchmark = benchmark[len(benchmark) - len(adjusted_returns):]
+
+        result['desmoothed'] = _metrics(
+            adjusted_returns, aligned_benchmark, frequency, risk_free_rate, confidence_level
+        )
+        result['desmoothing'] = {'method': adjusted['method'], 'params': adjusted['params']}
+
+    return result
+
+
+def _metrics(
+    returns: np.ndarray,
+    benchmark: Optional[np.ndarray],
+    frequency: int,
+    risk_free_rate: float,
+    confidence_level: float
+) -> Dict[str, any]:
+    """Metrics for a single return series."""
+    if len(returns) < 2:
+        raise ValueError("Need at least 2 returns")
+
+    vol = float(np.std(returns, ddof=1) * np.sqrt(frequency))
+    annual_return = float(np.mean(returns) * frequency)
+
+    threshold = np.percentile(returns, (1 - confidence_level) * 100)
+    tail = returns[returns <= threshold]
+
+    metrics = {
+        'annualized_return': annual_return,
+        'volatility': vol,
+        'sharpe_ratio': (annual_return - risk_free_rate) / vol if vol > 0 else None,
+        'var': float(-threshold),
+        'cvar': float(-np.mean(tail)),
+    }
+
+    if benchmark is not None:
+        bench_var = np.var(benchmark, ddof=1)
+        metrics['correlation'] = float(np.corrcoef(returns, benchmark)[0, 1])
+        beta = float(np.cov(returns, benchmark, ddof=1)[0, 1] / bench_var) if bench_var > 0 else None
+        metrics['beta'] = beta
+        metrics['alpha'] = (
+            annual_return - (risk_free_rate + beta * (float(np.mean(benchmark)) * frequency - risk_free_rate))
+            if beta is not None else None
+        )
+
+    return metrics
+
+
+def var_contributions(
+    scenarios: Dict[str, Sequence[float]],
+    positions: Dict[str, float],
+    confidence_level: float = 0.95,
+    bandwidth: Optional[float] = None
+) -> Dict[str, any]:
+    """
+    Marginal, component and incremental VaR per holding from simulated outcomes.
+
+    Parameters:
+        scenarios: Holding name -> simulated returns, aligned across holdings
+        positions: Holding name -> position value (or portfolio weight)
+        confidence_level: VaR confidence level
+        bandwidth: Kernel bandwidth for marginal VaR, in units of the
+            portfolio outcome (default: Silverman's rule)
+
+    Returns:
+        Dictionary with portfolio VaR and CVaR (in the units of positions)
+        and per-holding marginal, component and incremental VaR, component
+        CVaR and each holding's share of VaR, sorted by component VaR
+    """
+    if not 0 < confidence_level < 1:
+        raise ValueError("confidence_level must be between 0 and 1")
+    names = list(positions)
+    missing = [n for n in names if n not in scenarios]
+    if missing:
+        raise ValueError(f"No scenarios for holdings: {', '.join(missing)}")
+    if len({len(scenarios[n]) for n in names}) != 1:
+        raise ValueError("All holdings must have the same number of scenarios")
+
+    r = np.column_stack([np.asarray(scenarios[n], dtype=float) for n in names])
+    w = np.array([float(positions[n]) for n in names])
+    n_scenarios = r.shape[0]
+    if n_scenarios * (1 - confidence_level) < 10:
+        raise ValueError("Too few scenarios for the VaR confidence level")
+
+    portfolio = r @ w
+    q = np.percentile(portfolio, (1 - confidence_level) * 100)
+    var = float(-q)
+    tail = portfolio <= q
+    cvar = float(-np.mean(portfolio[tail]))
+
+    if bandwidth is None:
+        bandwidth = 1.06 * np.std(portfolio) * n_scenarios ** -0.2
+    if bandwidth <= 0:
+        raise ValueError("Portfolio outcomes have no dispersion")
+    kernel = np.exp(-0.5 * ((portfolio - q) / bandwidth) ** 2)
+    marginal = -(kernel @ r) / kernel.sum()
+
+    # Rescale the kernel estimate so the Euler components sum to VaR
+    euler_total = float(w @ marginal)
+    if euler_total != 0:
+        marginal *= var / euler_total
+    component = w * marginal
+    component_cvar = -w * r[tail].mean(axis=0)
+
+    holdings = []
+    for i, name in enumerate(names):
+        without = portfolio - w[i] * r[:, i]
+        holdings.append({
+            'name': name,
+            'position': float(w[i]),
+            'marginal_var': float(marginal[i]),
+            'component_var': float(component[i]),
+            'var_share': float(component[i] / var) if var != 0 else None,
+            'incremental_var': float(var + np.percentile(without, (1 - confidence_level) * 100)),
+            'component_cvar': float(component_cvar[i]),
+        })
+    holdings.sort(key=lambda h: -h['component_var'])
+
+    return {
+        'confidence_level': confidence_level,
+        'n_scenarios': n_scenarios,
+        'bandwidth': float(bandwidth),
+        'var': var,
+        'cvar': cvar,
+        'holdings': holdings,
+    }
@@ -0,0 +1,186 @@
+"""
+Reverse Stress Testing
+
+Finds the most plausible combination of factor shocks that produces a
+given portfolio loss, instead of asking what loss a chosen scenario causes.
+
+Model:
+-----
+Each holding i has value V_i, factor sensitivities β_ik and optional
+convexity γ_ik. A shock vector s gives the portfolio return
+
+    R(s) = Σ_i V_i (Σ_k β_ik s_k + ½ Σ_k γ_ik s_k²) / Σ_i V_i
+
+Plausibility is measured by the Mahalanobis distance of the shock under
+the factor covariance Σ = D C D (vols D, correlation C):
+
+    d(s) = √(s' Σ⁻¹ s)
+
+Search:
+------
+    minimize d(s)²  subject to  -R(s) ≥ L,  lower_k ≤ s_k ≤ upper_k
+
+solved with SLSQP. For a linear book the answer is closed form,
+s* = L Σb / (b'Σb) with b = -∂R/∂s, which is also the starting point.
+Plausibility is reported as P(χ²_K ≥ d²), the chance that a random
+normal shock is at least this far out.
+"""
+
+import numpy as np
+from scipy.optimize import minimize
+from scipy.stats import chi2
+from typing import Dict, List, Optional, Tuple
+
+
+def reverse_stress_test(
+    holdings: List[Dict],
+    factors: List[Dict],
+    loss_threshold: float,
+    correlation: Optional[List[List[float]]] = None
+) -> Dict[str, any]:
+    """
+    Smallest (most plausible) factor shock that breaches a loss threshold.
+
+    Parameters:
+        holdings: Rows with 'name', 'value', 'exposures' (factor -> β) and
+            optional 'convexity' (factor -> γ)
+        factors: Rows with 'name', 'volatility' and optional 'lower' /
+            'upper' bounds on the shock
+        loss_threshold: Portfolio loss to breach, as a fraction (0.2 = 20%)
+        correlation: Factor correlation matrix (default: identity)
+
+    Returns:
+        Dictionary with the breaching shock per factor (absolute and in
+        volatility units), its Mahalanobis distance and plausibility, the
+        loss by holding and by factor, and the single-factor shock that
+        would breach on its own for each factor
+    """
+    if not 0 < loss_threshold < 1:
+        raise ValueError("loss_threshold must be a fraction between 0 and 1")
+    if not holdings or not factors:
+        raise ValueError("At least one holding and one factor are required")
+
+    names = [f['name'] for f in factors]
+    if len(set(names)) != len(names):
+        raise ValueError("Factor names must be unique")
+    vols = np.array([float(f['volatility']) for f in factors])
+    if np.any(vols <= 0):
+        raise ValueError("Factor volatilities must be positive")
+
+    corr = np.eye(len(factors)) if correlation is None else np.asarray(correlation, dtype=float)
+    if corr.shape != (len(factors), len(factors)) or not np.allclose(corr, corr.T):
+        raise ValueError("correlation must be a symmetric matrix matching the factors")
+    cov = corr * np.outer(vols, vols)
+    try:
+        cov_inv = np.linalg.inv(cov)
+        np.linalg.cholesky(cov)
+    except np.linalg.LinAlgError:
+        raise ValueError("correlation matrix must be positive definite")
+
+    values, beta, gamma = _holding_arrays(holdings, names)
+    total = values.sum()
+    if total <= 0:
+        raise ValueError("Portfolio value must be positive")
+    w = values / total
+
+    def portfolio_return(s):
+        return float(w @ (beta @ s + 0.5 * gamma @ (s * s)))
+
+    def return_gradient(s):
+        return w @ beta + (w @ gamma) * s
+
+    bounds = [(f.get('lower'), f.get('upper')) for f in factors]
+
+    # Linear closed form as the starting point
+    b = -(w @ beta)
+    if np.allclose(b, 0) and np.allclose(w @ gamma, 0):
+        raise ValueError("Portfolio has no exposure to the given factors")
+    x0 = loss_threshold * cov @ b / (b @ cov @ b) if b @ cov @ b > 0 else -np.sign(w @ gamma) * vols
+
+    result = minimize(
+        lambda s: s @ cov_inv @ s,
+        x0,
+        jac=lambda s: 2 * cov_inv @ s,
+        constraints=[{
+            'type': 'ineq',
+            'fun': lambda s: -portfolio_return(s) - loss_threshold,
+            'jac': lambda s: -return_gradient(s),
+        }],
+        bounds=bounds,
+        method='SLSQP',
+        options={'maxiter': 500, 'ftol': 1e-12}
+    )
+
+    shock = result.x
+    loss = -portfolio_return(shock)
+    if not result.success or loss < loss_threshold * (1 - 1e-6):
+        raise ValueError(
+            f"No shock within the factor bounds breaches a {loss_threshold:.1%} loss ({result.message})"
+        )
+
+    distance = float(np.sqrt(shock @ cov_inv @ shock))
+    holding_loss = -(values * (beta @ shock + 0.5 * gamma @ (shock * shock)))
+    factor_loss = -(w @ beta * shock + 0.5 * (w @ gamma) * shock * shock)
+
+    return {
+        'loss_threshold': loss_threshold,
+        'portfolio_loss': loss,
+        'portfolio_value': float(total),
+        'shock': {n: float(s) for n, s in zip(names, shock)},
+        'shock_in_vols': {n: float(s / v) for n, s, v in zip(names, shock, vols)},
+        'mahalanobis_distance': distance,
+        'plausibility': float(chi2.sf(distance ** 2, df=len(factors))),
+        'loss_by_holding': {
+            h['name']: float(l) for h, l in sorted(zip(holdings, holding_loss), key=lambda x: -x[1])
+        },
+        'loss_by_factor': {n: float(l) for n, l in zip(names, factor_loss)},
+        'single_factor_breach': {
+            n: _single_factor_breach(w, beta[:, k], gamma[:, k], loss_threshold, bounds[k], vols[k])
+            for k, n in enumerate(names)
+        },
+    }
+
+
+def _holding_arrays(holdings: List[Dict], names: List[str]) -> Tuple[np.ndarray, np.ndarray, np.ndarray]:
+    """Values and (holding x factor) sensitivity matrices."""
+    index = {n: k for k, n in enumerate(names)}
+    values = np.zeros(len(holdings))
+    beta = np.zeros((len(holdings), len(names)))
+    gamma = np.zeros((len(holdings), len(names)))
+
+    for i, h in enumerate(holdings):
+        values[i] = float(h['value'])
+        for key, matrix in (('exposures', beta), ('convexity', gamma)):
+            for factor, sensitivity in (h.get(key) or {}).items():
+                if factor not in index:
+                    raise ValueError(f"Holding {h['name']} references unknown factor {factor}")
+                matrix[i, index[factor]] = float(sensitivity)
+
+    return values, beta, gamma
+
+
+def _single_factor_breach(
+    w: np.ndarray,
+    beta: np.ndarray,
+    gamma: np.ndarray,
+    loss_threshold: float,
+    bounds: Tuple[Optional[float], Optional[float]],
+    volatility: float
+) -> Optional[Dict[str, float]]:
+    """Smallest shock to one factor alone that breaches the threshold (None if none does)."""
+    # Loss L(s) = -(b s + ½ c s²); solve ½ c s² + b s + L = 0 for the root nearest zero
+    b, c = float(w @ beta), float(w @ gamma)
+    if abs(c) < 1e-12:
+        roots = [-loss_threshold / b] if abs(b) > 1e-12 else []
+    else:
+        disc = b * b - 2 * c * loss_threshold
+        roots = [] if disc < 0 else [(-b + sign * np.sqrt(disc)) / c for sign in (1, -1)]
+
+    lower = -np.inf if bounds[0] is None else bounds[0]
+    upper = np.inf if bounds[1] is None else bounds[1]
+    feasible = [s for s in roots if lower <= s <= upper]
+    if not feasible:
+        return None
+
+    shock = min(feasible, key=abs)
+    return {'shock': float(shock), 'shock_in_vols': float(shock / volatility)}
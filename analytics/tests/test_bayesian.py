@@ -0,0 +1,91 @@
+"""
+Tests for Bayesian updating of return assumptions.
+
+Tests include:
+- The normal-inverse-gamma update against hand-computed parameters
+- Mapping an annual prior to per-period NIG parameters
+- Updating in two batches equals updating once with all returns
+- Posterior predictive simulation inputs
+- Input validation
+"""
+
+import pytest
+import numpy as np
+from analytics import bayesian_return_update
+
+NIG_PRIOR = {'nig': {'m': 0.0, 'kappa': 2.0, 'alpha': 3.0, 'beta': 0.001}}
+
+
+class TestConjugateUpdate:
+    """Test the closed-form posterior."""
+
+    def test_hand_computed_posterior(self):
+        """x̄ = 0.02, S = 0.0002: κ = 4, m = 0.01, α = 4, β = 0.001 + 0.0001 + 0.0002."""
+        result = bayesian_return_update([0.01, 0.03], NIG_PRIOR, frequency=4)
+        nig = result['posterior']['nig']
+        assert nig['kappa'] == pytest.approx(4.0)
+        assert nig['m'] == pytest.approx(0.01)
+        assert nig['alpha'] == pytest.approx(4.0)
+        assert nig['beta'] == pytest.approx(0.0013)
+        assert result['data_weight'] == pytest.approx(0.5)
+
+    def test_annualized_summary(self):
+        """Annual mean is m f; volatility is √(f β / (α - 1)); the mean interval is centered."""
+        posterior = bayesian_return_update([0.01, 0.03], NIG_PRIOR, frequency=4)['posterior']
+        assert posterior['annualized_mean'] == pytest.approx(0.04)
+        assert posterior['annualized_volatility'] == pytest.approx(np.sqrt(4 * 0.0013 / 3))
+        low, high = posterior['annualized_mean_interval']
+        assert (low + high) / 2 == pytest.approx(0.04)
+        assert low < 0.04 < high
+
+    def test_annual_prior_mapping(self):
+        """m₀ = mean / f, κ₀ = n_μ, α₀ = n_σ / 2, β₀ = α₀ vol² / f."""
+        prior = {'mean': 0.08, 'volatility': 0.2, 'mean_confidence': 4, 'volatility_confidence': 6}
+        nig = bayesian_return_update([0.01, 0.03], prior, frequency=4)['prior']['nig']
+        assert nig == pytest.approx({'m': 0.02, 'kappa': 4.0, 'alpha': 3.0, 'beta': 0.03})
+
+    def test_sequential_equals_batch(self):
+        """The posterior is a valid prior for the next batch."""
+        rng = np.random.default_rng(3)
+        x = rng.normal(0.02, 0.05, 40)
+        prior = {'mean': 0.08, 'volatility': 0.2}
+
+        first = bayesian_return_update(x[:15], prior, frequency=4)
+        second = bayesian_return_update(x[15:], {'nig': first['posterior']['nig']}, frequency=4)
+        batch = bayesian_return_update(x, prior, frequency=4)
+        assert second['posterior']['nig'] == pytest.approx(batch['posterior']['nig'])
+
+    def test_data_dominates_weak_prior(self):
+        """With n ≫ κ₀ the posterior mean approaches the sample mean."""
+        rng = np.random.default_rng(5)
+        x = rng.normal(0.03, 0.05, 4000)
+        result = bayesian_return_update(x, {'mean': 0.0, 'volatility': 0.2, 'mean_confidence': 1}, frequency=4)
+        assert result['data_weight'] == pytest.approx(4000 / 4001)
+        assert result['posterior']['annualized_mean'] == pytest.approx(result['sample']['annualized_mean'], rel=1e-3)
+
+
+class TestSimulationInputs:
+    """Test the posterior predictive inputs."""
+
+    def test_predictive_t(self):
+        """ν = 2α, σ = √(β (κ + 1) / (α κ)) · √(f ν / (ν - 2))."""
+        inputs = bayesian_return_update([0.01, 0.03], NIG_PRIOR, frequency=4)['simulation_inputs']
+        assert inputs['df'] == pytest.approx(8.0)
+        assert inputs['mu'] == pytest.approx(0.04)
+        assert inputs['sigma'] == pytest.approx(np.sqrt(0.0013 * 5 / 16) * np.sqrt(4 * 8 / 6))
+
+
+class TestValidation:
+    """Test input validation."""
+
+    def test_too_few_returns(self):
+        with pytest.raises(ValueError):
+            bayesian_return_update([0.01], NIG_PRIOR)
+
+    def test_non_positive_volatility(self):
+        with pytest.raises(ValueError):
+            bayesian_return_update([0.01, 0.02], {'mean': 0.08, 'volatility': 0.0})
+
+    def test_credible_level(self):
+        with pytest.raises(ValueError):
+            bayesian_return_update([0.01, 0.02], NIG_PRIOR, credible_level=1.0)
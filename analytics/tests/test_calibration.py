@@ -0,0 +1,87 @@
+"""
+Tests for distribution calibration.
+
+Tests include:
+- Normal and lognormal fits match their closed-form MLEs and log-likelihoods
+- AIC and BIC follow from the log-likelihood and parameter count
+- Unfittable models are reported in fit_all rather than raised
+- GARCH fits respect stationarity
+- Input validation
+"""
+
+import pytest
+import numpy as np
+from analytics import DistributionCalibrator
+
+
+@pytest.fixture
+def returns():
+    rng = np.random.default_rng(42)
+    return rng.normal(0.005, 0.04, 500)
+
+
+class TestClosedForm:
+    """Test fits that have closed-form maximum likelihood estimates."""
+
+    def test_normal_mle(self, returns):
+        """μ is the mean, σ the ddof=0 std, ln L = -n/2 (ln 2πσ² + 1)."""
+        fit = DistributionCalibrator(returns, frequency=12).fit_normal()
+        n, sigma = len(returns), np.std(returns)
+
+        assert fit['params']['mu'] == pytest.approx(np.mean(returns))
+        assert fit['params']['sigma'] == pytest.approx(sigma)
+        assert fit['log_likelihood'] == pytest.approx(-n / 2 * (np.log(2 * np.pi * sigma ** 2) + 1))
+        assert fit['params']['annualized_volatility'] == pytest.approx(sigma * np.sqrt(12))
+
+    def test_lognormal_mle(self, returns):
+        """Parameters are the mean and std of ln(1 + r)."""
+        fit = DistributionCalibrator(returns).fit_lognormal()
+        log_gross = np.log1p(returns)
+
+        assert fit['params']['mu'] == pytest.approx(np.mean(log_gross))
+        assert fit['params']['sigma'] == pytest.approx(np.std(log_gross))
+
+    def test_information_criteria(self, returns):
+        """AIC = 2k - 2 ln L and BIC = k ln n - 2 ln L with k = 2."""
+        fit = DistributionCalibrator(returns).fit_normal()
+        assert fit['aic'] == pytest.approx(4 - 2 * fit['log_likelihood'])
+        assert fit['bic'] == pytest.approx(2 * np.log(len(returns)) - 2 * fit['log_likelihood'])
+        assert fit['n_obs'] == len(returns)
+
+
+class TestFitAll:
+    """Test model selection across fits."""
+
+    def test_lognormal_error_is_reported(self, returns):
+        """A return of -100% or worse rules out the lognormal fit only."""
+        data = returns.copy()
+        data[0] = -1.0
+        result = DistributionCalibrator(data).fit_all(['normal', 'lognormal'])
+
+        assert 'error' in result['fits']['lognormal']
+        assert result['best'] == 'normal'
+
+    def test_unknown_model(self, returns):
+        """Only supported models can be requested."""
+        with pytest.raises(ValueError, match="Unknown model"):
+            DistributionCalibrator(returns).fit_all(['weibull'])
+
+    def test_garch_is_stationary(self, returns):
+        """α + β stays below one."""
+        fit = DistributionCalibrator(returns).fit_garch()
+        assert fit['params']['persistence'] < 1
+        assert fit['params']['omega'] > 0
+
+
+class TestValidation:
+    """Test rejected inputs."""
+
+    def test_too_few_observations(self):
+        """At least 10 returns are needed."""
+        with pytest.raises(ValueError):
+            DistributionCalibrator(np.linspace(-0.01, 0.01, 9))
+
+    def test_zero_variance(self):
+        """A constant series cannot be calibrated."""
+        with pytest.raises(ValueError):
+            DistributionCalibrator(np.full(20, 0.01))
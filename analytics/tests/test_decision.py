@@ -0,0 +1,77 @@
+"""
+Tests for new fund decision support.
+
+Tests include:
+- Invalid candidates are rejected (zero commitment, duplicate name,
+  commitment above liquid assets)
+- The candidate is fully called from liquid assets in every part of the
+  comparison, and the memo says so
+"""
+
+import pytest
+from analytics import new_fund_decision
+
+FUNDS = [
+    {'name': 'Fund A', 'nav': 60e6, 'sector': 'Technology', 'expected_return': 0.12, 'volatility': 0.20},
+    {'name': 'Fund B', 'nav': 40e6, 'sector': 'Healthcare', 'expected_return': 0.10, 'volatility': 0.15},
+]
+CANDIDATE = {
+    'name': 'Fund C', 'sector': 'Energy', 'commitment': 20e6,
+    'expected_return': 0.14, 'volatility': 0.25,
+}
+
+
+def decide(**overrides):
+    kwargs = dict(
+        funds=FUNDS, candidate=CANDIDATE, liquid_assets=50e6,
+        unfunded_commitments=30e6, n_paths=2000, seed=7,
+    )
+    kwargs.update(overrides)
+    return new_fund_decision(**kwargs)
+
+
+class TestValidation:
+    """Test rejected inputs."""
+
+    def test_zero_commitment(self):
+        """A commitment of zero is not a decision."""
+        with pytest.raises(ValueError, match="commitment must be positive"):
+            decide(candidate={**CANDIDATE, 'commitment': 0})
+
+    def test_candidate_already_held(self):
+        """The candidate must be a new fund."""
+        with pytest.raises(ValueError, match="already in the portfolio"):
+            decide(candidate={**CANDIDATE, 'name': 'Fund A'})
+
+    def test_commitment_above_liquid_assets(self):
+        """Liquid assets must cover the call."""
+        with pytest.raises(ValueError, match="below liquid_assets"):
+            decide(liquid_assets=20e6)
+
+
+class TestDeployment:
+    """Test the single deployment assumption."""
+
+    def test_liquidity_runs_on_called_portfolio(self):
+        """The commitment moves from liquid assets to private NAV; unfunded is unchanged."""
+        result = decide()
+        liquidity = result['liquidity']
+
+        assert result['deployment'] == 'fully_called_from_liquid_assets'
+        assert liquidity['without']['liquid_assets'] == pytest.approx(50e6)
+        assert liquidity['with']['liquid_assets'] == pytest.approx(30e6)
+        assert liquidity['without']['private_nav'] == pytest.approx(100e6)
+        assert liquidity['with']['private_nav'] == pytest.approx(120e6)
+
+    def test_exposure_adds_commitment(self):
+        """Total exposure grows by exactly the commitment."""
+        exposure = decide()['exposure']
+        assert exposure['with']['total_exposure'] - exposure['without']['total_exposure'] == pytest.approx(20e6)
+        assert exposure['with']['sector_weights']['Energy'] == pytest.approx(20e6 / 150e6)
+
+    def test_memo_states_assumption(self):
+        """The first memo point states the deployment assumption."""
+        memo = decide()['memo_points']
+        assert len(memo) == 5
+        assert 'called at once' in memo[0]
+        assert '30,000,000 after' in memo[0]
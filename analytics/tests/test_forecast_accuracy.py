@@ -0,0 +1,73 @@
+"""
+Tests for forecast accuracy tracking.
+
+Tests include:
+- Forecasts without an actual on or after the target date are pending
+- Scoring uses the first actual on or after the target date
+- The naive baseline is the latest actual known at prediction time
+"""
+
+import pytest
+from datetime import date
+from analytics import forecast_accuracy
+
+ACTUALS = [
+    {'fund': 1, 'date': date(2023, 12, 31), 'actual': 0.10},
+    {'fund': 1, 'date': date(2024, 6, 30), 'actual': 0.12},
+]
+
+
+def forecast(target, predicted, prediction=date(2024, 1, 15), version='v1'):
+    return {
+        'fund': 1, 'model': 'arima', 'version': version,
+        'prediction_date': prediction, 'target_date': target, 'predicted': predicted,
+    }
+
+
+class TestForecastAccuracy:
+    """Test matching, pending forecasts and the naive baseline."""
+
+    def test_pending_forecast(self):
+        """A target date after the last actual is pending, not scored."""
+        result = forecast_accuracy(
+            [forecast(date(2024, 6, 30), 0.13), forecast(date(2025, 6, 30), 0.14)],
+            ACTUALS
+        )
+
+        assert result['n_forecasts'] == 2
+        assert result['n_scored'] == 1
+        assert result['n_pending'] == 1
+        assert result['by_model']['arima']['metrics']['n'] == 1
+
+    def test_only_pending_forecasts(self):
+        """Nothing is scored before any actual arrives."""
+        result = forecast_accuracy([forecast(date(2025, 6, 30), 0.14)], ACTUALS)
+        assert result['n_pending'] == 1
+        assert result['by_model'] == {}
+
+    def test_first_actual_on_or_after_target(self):
+        """A quarter-end target between observations takes the next one."""
+        result = forecast_accuracy([forecast(date(2024, 3, 31), 0.11)], ACTUALS)
+        metrics = result['by_model']['arima']['metrics']
+        assert metrics['bias'] == pytest.approx(0.11 - 0.12)
+
+    def test_skill_against_naive(self):
+        """Model error 0.01 against a naive error of 0.02 gives skill 0.5."""
+        result = forecast_accuracy([forecast(date(2024, 6, 30), 0.13)], ACTUALS)
+        model = result['by_model']['arima']
+
+        assert model['metrics']['mae'] == pytest.approx(0.01)
+        assert model['naive']['mae'] == pytest.approx(0.02)
+        assert model['skill'] == pytest.approx(0.5)
+        assert model['beats_naive'] is True
+
+    def test_no_naive_before_first_actual(self):
+        """A forecast made before any actual has no baseline."""
+        result = forecast_accuracy(
+            [forecast(date(2024, 6, 30), 0.13, prediction=date(2023, 6, 30))],
+            ACTUALS
+        )
+        model = result['by_model']['arima']
+        assert model['naive'] is None
+        assert model['skill'] is None
+        assert model['beats_naive'] is None
@@ -0,0 +1,103 @@
+"""
+Tests for inflation adjustment.
+
+Tests include:
+- XIRR against closed-form one- and two-period rates
+- CPI interpolation, ordering and range checks
+- Fisher real returns, rebased cash flows and real rates
+- Real IRR of cash flows deflated at constant inflation
+"""
+
+import pytest
+import numpy as np
+from datetime import date
+from analytics import CPISeries, xirr, nominal_and_real_irr
+
+D0 = date(2021, 1, 1)
+D1 = date(2022, 1, 1)   # 365 days later
+D2 = date(2023, 1, 1)   # 730 days after D0
+
+
+class TestXirr:
+    """Test the dated-cash-flow IRR."""
+
+    def test_one_year(self):
+        """-100 then +110 a year (365 days) later is 10%."""
+        assert xirr([D0, D1], [-100, 110]) == pytest.approx(0.10)
+
+    def test_two_years(self):
+        """-100 then +121 two years later is 10% a year."""
+        assert xirr([D0, D2], [-100, 121]) == pytest.approx(0.10)
+
+    def test_annuity(self):
+        """60 at the end of each of two years on 100: 1/(1+r) solves 3x² + 3x - 5 = 0."""
+        x = (-3 + np.sqrt(69)) / 6
+        assert xirr([D0, D1, D2], [-100, 60, 60]) == pytest.approx(1 / x - 1)
+
+    def test_order_does_not_matter(self):
+        """Time is measured from the earliest date."""
+        assert xirr([D2, D0, D1], [60, -100, 60]) == pytest.approx(xirr([D0, D1, D2], [-100, 60, 60]))
+
+    def test_needs_both_signs(self):
+        with pytest.raises(ValueError):
+            xirr([D0, D1], [100, 110])
+
+
+class TestCPISeries:
+    """Test CPI levels."""
+
+    def test_linear_interpolation(self):
+        """Halfway between observations is halfway between levels."""
+        cpi = CPISeries([date(2021, 1, 1), date(2021, 1, 11)], [100.0, 110.0])
+        assert cpi.level(date(2021, 1, 6)) == pytest.approx(105.0)
+
+    def test_sorts_observations(self):
+        """Observations are sorted by date with their levels."""
+        cpi = CPISeries([D1, D0], [110.0, 100.0])
+        assert cpi.dates == [D0, D1]
+        assert cpi.level(D0) == pytest.approx(100.0)
+
+    def test_outside_range(self):
+        """Dates outside the series are not extrapolated."""
+        cpi = CPISeries([D0, D1], [100.0, 110.0])
+        with pytest.raises(ValueError, match="outside"):
+            cpi.level(D2)
+
+    def test_invalid_series(self):
+        """Levels must be positive and dates unique."""
+        with pytest.raises(ValueError):
+            CPISeries([D0, D1], [100.0, 0.0])
+        with pytest.raises(ValueError):
+            CPISeries([D0, D0], [100.0, 101.0])
+        with pytest.raises(ValueError):
+            CPISeries([D0], [100.0])
+
+
+class TestDeflation:
+    """Test real returns, cash flows and rates."""
+
+    def test_fisher_returns(self):
+        """10% nominal with 10% inflation is 0% real; 21% is 10% real."""
+        cpi = CPISeries([D0, D1, D2], [100.0, 110.0, 121.0])
+        real = cpi.deflate_returns([D0, D1, D2], [0.10, 0.21])
+        assert real == pytest.approx([0.0, 0.10])
+
+    def test_cash_flows_in_base_prices(self):
+        """Amounts are restated at the last date's prices by default."""
+        cpi = CPISeries([D0, D1], [100.0, 110.0])
+        assert cpi.deflate_cash_flows([D0, D1], [-100, 50]) == pytest.approx([-110.0, 50.0])
+        assert cpi.deflate_cash_flows([D0, D1], [-100, 55], base_date=D0) == pytest.approx([-100.0, 50.0])
+
+    def test_real_rate(self):
+        """With 2% annual inflation, 5% nominal is 1.05 / 1.02 - 1 real."""
+        years = (D2 - D0).days / 365.25
+        cpi = CPISeries([D0, D2], [100.0, 100.0 * 1.02 ** years])
+        assert cpi.deflate_rate(0.05, D0, D2) == pytest.approx(1.05 / 1.02 - 1)
+
+    def test_real_irr(self):
+        """21% nominal over a year of 10% inflation is 10% real."""
+        cpi = CPISeries([D0, D1], [100.0, 110.0])
+        result = nominal_and_real_irr([D0, D1], [-100, 121], cpi)
+        assert result['nominal_irr'] == pytest.approx(0.21)
+        assert result['real_irr'] == pytest.approx(0.10)
+        assert result['cpi_series'] == 'CPI-U'
@@ -0,0 +1,75 @@
+"""
+Tests for the liquidity stress test.
+
+Tests include:
+- A noiseless scenario rolls balances forward by the closed-form recursion
+- Calls that exceed liquid assets are a certain shortfall
+- The same seed gives the same draws
+"""
+
+import pytest
+from analytics import LiquidityScenario, LiquidityStressTest
+
+# No market or call noise, so every path is the same
+FLAT = dict(
+    call_volatility=0.0, liquid_return=0.0, liquid_volatility=0.0,
+    private_return=0.0, private_beta=0.0, private_volatility=0.0,
+)
+
+
+class TestDeterministic:
+    """Test the recursion with all volatilities at zero."""
+
+    def test_first_quarter_balances(self):
+        """C = U c dt and D = P d dt; L' = L - C + D."""
+        scenario = LiquidityScenario(call_rate=0.4, distribution_rate=0.2, **FLAT)
+        result = LiquidityStressTest(100.0, 200.0, 80.0, n_paths=10, seed=1).run(scenario, quarters=1)
+        q1 = result['by_quarter'][0]
+
+        calls = 80.0 * 0.4 * 0.25
+        distributions = 200.0 * 0.2 * 0.25
+        assert q1['calls']['50'] == pytest.approx(calls)
+        assert q1['net_cash_flow']['50'] == pytest.approx(distributions - calls)
+        assert q1['liquid_assets']['5'] == pytest.approx(100.0 - calls + distributions)
+        assert q1['unfunded_commitments'] == pytest.approx(80.0 - calls)
+        assert result['prob_shortfall'] == 0.0
+        assert result['worst_quarter'] is None
+
+    def test_certain_shortfall(self):
+        """Calls of the whole commitment against a small liquid balance."""
+        scenario = LiquidityScenario(call_rate=4.0, distribution_rate=0.0, **FLAT)
+        result = LiquidityStressTest(10.0, 100.0, 50.0, n_paths=10, seed=1).run(scenario, quarters=2)
+
+        assert result['prob_shortfall'] == 1.0
+        assert result['worst_quarter'] == 1
+        assert result['by_quarter'][0]['expected_shortfall'] == pytest.approx(40.0)
+
+    def test_initial_private_weight(self):
+        """P / (P + L) before any flows."""
+        result = LiquidityStressTest(300.0, 100.0, 0.0, n_paths=10).run(LiquidityScenario(**FLAT), quarters=1)
+        assert result['initial_private_weight'] == pytest.approx(0.25)
+
+
+class TestValidation:
+    """Test rejected inputs."""
+
+    def test_non_positive_liquid_assets(self):
+        """Liquid assets must be positive."""
+        with pytest.raises(ValueError):
+            LiquidityStressTest(0.0, 100.0, 50.0)
+
+    def test_invalid_shock(self):
+        """A liquid shock is a drawdown in (-1, 0]."""
+        with pytest.raises(ValueError):
+            LiquidityScenario(liquid_shock=0.1)
+
+
+class TestSeeding:
+    """Test reproducibility."""
+
+    def test_same_seed_same_result(self):
+        """Runs with one seed are identical."""
+        scenario = LiquidityScenario.stressed()
+        a = LiquidityStressTest(400.0, 500.0, 300.0, n_paths=500, seed=3).run(scenario, quarters=4)
+        b = LiquidityStressTest(400.0, 500.0, 300.0, n_paths=500, seed=3).run(scenario, quarters=4)
+        assert a['by_quarter'] == b['by_quarter']
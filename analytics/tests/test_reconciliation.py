@@ -0,0 +1,84 @@
+"""
+Tests for cash reconciliation.
+
+Tests include:
+- Greedy ties go to the earlier item; the loser is unmatched, not a mismatch
+- Candidates need similar references
+- Near misses on amount are reported as amount mismatches
+"""
+
+import pytest
+from analytics import reconcile_cash
+
+
+def item(id, amount, reference, day=10):
+    return {'id': id, 'date': f'2024-03-{day:02d}', 'amount': amount, 'reference': reference}
+
+
+class TestMatching:
+    """Test candidate gating and greedy assignment."""
+
+    def test_tie_goes_to_first_bank_item(self):
+        """Two identical bank items compete for one record; the first wins."""
+        bank = [item('b1', -5000.0, 'Fund A capital call'), item('b2', -5000.0, 'Fund A capital call')]
+        recorded = [item(1, -5000.0, 'Fund A Capital Call')]
+
+        result = reconcile_cash(bank, recorded)
+
+        assert [(m['bank_id'], m['record_id']) for m in result['matches']] == [('b1', 1)]
+        assert result['matches'][0]['score'] == pytest.approx(1.0)
+        assert [(br['type'], br['bank_id']) for br in result['breaks']] == [('unmatched_bank', 'b2')]
+        assert result['summary']['break_counts']['amount_mismatch'] == 0
+
+    def test_higher_score_wins_regardless_of_order(self):
+        """The closer date scores higher, so it takes the record."""
+        bank = [item('b1', -5000.0, 'Fund A call', day=13), item('b2', -5000.0, 'Fund A call', day=10)]
+        recorded = [item(1, -5000.0, 'Fund A call', day=10)]
+
+        result = reconcile_cash(bank, recorded)
+
+        assert result['matches'][0]['bank_id'] == 'b2'
+        assert result['matches'][0]['score'] == pytest.approx(1.0)
+
+    def test_dissimilar_references_do_not_match(self):
+        """Equal amount and date are not enough without a similar reference."""
+        bank = [item('b1', 2500.0, 'Harbor Growth distribution')]
+        recorded = [item(1, 2500.0, 'Summit Credit management fee')]
+
+        result = reconcile_cash(bank, recorded, min_reference_similarity=0.6)
+
+        assert result['matches'] == []
+        assert sorted(br['type'] for br in result['breaks']) == ['unmatched_bank', 'unmatched_record']
+
+    def test_invalid_similarity_floor(self):
+        """The similarity floor is a ratio."""
+        with pytest.raises(ValueError):
+            reconcile_cash([], [], min_reference_similarity=1.5)
+
+
+class TestBreaks:
+    """Test the reported breaks and summary."""
+
+    def test_amount_mismatch(self):
+        """A similar reference in the window with the wrong amount is a mismatch."""
+        bank = [item('b1', -5200.0, 'Fund A capital call')]
+        recorded = [item(1, -5000.0, 'Fund A capital call')]
+
+        result = reconcile_cash(bank, recorded, amount_tolerance=1.0)
+
+        assert len(result['breaks']) == 1
+        br = result['breaks'][0]
+        assert br['type'] == 'amount_mismatch'
+        assert br['difference'] == pytest.approx(-200.0)
+        assert result['summary']['unreconciled_bank_amount'] == pytest.approx(-5200.0)
+        assert result['summary']['unreconciled_record_amount'] == pytest.approx(-5000.0)
+
+    def test_score_weights(self):
+        """Half the tolerance and half the window off with equal references."""
+        bank = [item('b1', 100.5, 'Fund B distribution', day=12)]
+        recorded = [item(1, 100.0, 'distribution Fund B', day=10)]
+
+        result = reconcile_cash(bank, recorded, amount_tolerance=1.0, date_window_days=4)
+
+        assert result['matches'][0]['reference_similarity'] == pytest.approx(1.0)
+        assert result['matches'][0]['score'] == pytest.approx(0.5 * 0.5 + 0.3 * 0.5 + 0.2)
@@ -0,0 +1,99 @@
+"""
+Tests for VaR contributions.
+
+Tests include:
+- Euler rescaling: component VaRs sum to portfolio VaR
+- Component VaR is proportional to position for identical holdings
+- A single holding carries all of VaR, and its incremental VaR is VaR
+- A holding with no risk has zero incremental VaR
+- Component CVaRs sum to portfolio CVaR
+- Input validation
+"""
+
+import pytest
+import numpy as np
+from analytics import var_contributions
+
+
+@pytest.fixture
+def scenarios():
+    rng = np.random.default_rng(7)
+    equity = rng.normal(0.06, 0.18, 5000)
+    credit = 0.4 * equity + rng.normal(0.04, 0.06, 5000)
+    return {'equity': equity, 'credit': credit}
+
+
+def by_name(result):
+    return {h['name']: h for h in result['holdings']}
+
+
+class TestEuler:
+    """Test that contributions add up to the portfolio figures."""
+
+    def test_components_sum_to_var(self, scenarios):
+        """The kernel marginals are rescaled so Σ w_i m_i = VaR."""
+        result = var_contributions(scenarios, {'equity': 60.0, 'credit': 40.0})
+        assert sum(h['component_var'] for h in result['holdings']) == pytest.approx(result['var'])
+        assert sum(h['var_share'] for h in result['holdings']) == pytest.approx(1.0)
+
+    def test_portfolio_var_is_quantile(self, scenarios):
+        """VaR is minus the (1 - c) percentile of Σ w_i r_i."""
+        result = var_contributions(scenarios, {'equity': 60.0, 'credit': 40.0}, confidence_level=0.99)
+        portfolio = 60.0 * scenarios['equity'] + 40.0 * scenarios['credit']
+        assert result['var'] == pytest.approx(-np.percentile(portfolio, 1))
+
+    def test_identical_holdings_split_by_position(self, scenarios):
+        """Equal scenarios give equal marginals, so components are 1:3."""
+        same = {'a': scenarios['equity'], 'b': scenarios['equity']}
+        holdings = by_name(var_contributions(same, {'a': 1.0, 'b': 3.0}))
+        assert holdings['a']['marginal_var'] == pytest.approx(holdings['b']['marginal_var'])
+        assert holdings['a']['var_share'] == pytest.approx(0.25)
+        assert holdings['b']['var_share'] == pytest.approx(0.75)
+
+    def test_component_cvar_sums_to_cvar(self, scenarios):
+        """-w_i E[r_i | tail] sums to -E[portfolio | tail]."""
+        result = var_contributions(scenarios, {'equity': 60.0, 'credit': 40.0})
+        assert sum(h['component_cvar'] for h in result['holdings']) == pytest.approx(result['cvar'])
+        assert result['cvar'] >= result['var']
+
+
+class TestIncremental:
+    """Test incremental VaR against removing the holding."""
+
+    def test_single_holding(self, scenarios):
+        """Alone, a holding's marginal VaR is VaR / w and removing it leaves no VaR."""
+        result = var_contributions({'equity': scenarios['equity']}, {'equity': 50.0})
+        holding = result['holdings'][0]
+        assert holding['component_var'] == pytest.approx(result['var'])
+        assert holding['marginal_var'] == pytest.approx(result['var'] / 50.0)
+        assert holding['incremental_var'] == pytest.approx(result['var'])
+
+    def test_riskless_holding(self, scenarios):
+        """A zero-return holding does not change the portfolio outcomes."""
+        cash = {'equity': scenarios['equity'], 'cash': np.zeros(5000)}
+        holdings = by_name(var_contributions(cash, {'equity': 60.0, 'cash': 40.0}))
+        assert holdings['cash']['incremental_var'] == pytest.approx(0.0, abs=1e-12)
+        assert holdings['cash']['component_var'] == pytest.approx(0.0, abs=1e-12)
+
+    def test_incremental_is_var_change(self, scenarios):
+        """Incremental VaR is VaR minus the VaR of the rest of the portfolio."""
+        result = var_contributions(scenarios, {'equity': 60.0, 'credit': 40.0})
+        rest = -np.percentile(60.0 * scenarios['equity'], 5)
+        assert by_name(result)['credit']['incremental_var'] == pytest.approx(result['var'] - rest)
+
+
+class TestValidation:
+    """Test input validation."""
+
+    def test_missing_scenarios(self, scenarios):
+        with pytest.raises(ValueError, match="No scenarios"):
+            var_contributions(scenarios, {'equity': 1.0, 'rates': 1.0})
+
+    def test_misaligned_scenarios(self, scenarios):
+        with pytest.raises(ValueError, match="same number"):
+            var_contributions({'equity': scenarios['equity'], 'credit': scenarios['credit'][:-1]},
+                              {'equity': 1.0, 'credit': 1.0})
+
+    def test_too_few_scenarios(self):
+        with pytest.raises(ValueError, match="Too few"):
+            var_contributions({'a': np.linspace(-1, 1, 100)}, {'a': 1.0}, confidence_level=0.95)
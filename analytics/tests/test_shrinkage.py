@@ -0,0 +1,110 @@
+"""
+Tests for hierarchical shrinkage of fund estimates.
+
+Tests include:
+- Shrinkage factors and estimates against the normal-normal closed form
+- The strength scales the shrinkage factor
+- Between-fund variance is floored at zero (full shrinkage to the mean)
+- Groups below min_group_size fall back to the whole portfolio
+- Input validation
+"""
+
+import pytest
+import numpy as np
+from analytics import shrink_fund_estimates
+
+
+def fund(name, irr, sector='Technology', vintage=2018, volatility=0.2, n_observations=20):
+    return {'name': name, 'sector': sector, 'vintage': vintage, 'irr': irr,
+            'volatility': volatility, 'n_observations': n_observations}
+
+
+# v = 0.2² · 4 / 20 = 0.008, var(x) = 0.01, so τ² = 0.002 and B = 0.8
+SECTOR = [fund('A', 0.10), fund('B', 0.20), fund('C', 0.30)]
+
+
+def irr_of(result, key):
+    return [f['irr'][key] for f in result['funds']]
+
+
+class TestClosedForm:
+    """Test the empirical-Bayes estimates."""
+
+    def test_full_shrinkage(self):
+        """θ̂ = μ + (1 - B)(x - μ) with B = v / (v + τ²) = 0.8."""
+        result = shrink_fund_estimates(SECTOR, frequency=4)
+        assert irr_of(result, 'shrinkage') == pytest.approx([0.8, 0.8, 0.8])
+        assert irr_of(result, 'shrunk') == pytest.approx([0.18, 0.20, 0.22])
+        group = result['groups']['Technology']['irr']
+        assert group['mean'] == pytest.approx(0.2)
+        assert group['between_std'] == pytest.approx(np.sqrt(0.002))
+
+    def test_strength_scales_factor(self):
+        """Half strength halves B."""
+        result = shrink_fund_estimates(SECTOR, strength=0.5, frequency=4)
+        assert irr_of(result, 'shrinkage') == pytest.approx([0.4, 0.4, 0.4])
+        assert irr_of(result, 'shrunk') == pytest.approx([0.14, 0.20, 0.26])
+
+    def test_zero_strength_is_raw(self):
+        """λ = 0 leaves the raw estimates."""
+        result = shrink_fund_estimates(SECTOR, strength=0.0, frequency=4)
+        assert irr_of(result, 'shrunk') == pytest.approx(irr_of(result, 'raw'))
+
+    def test_between_variance_floored(self):
+        """Dispersion below the sampling noise gives τ² = 0, so every fund shrinks to the mean."""
+        close = [fund('A', 0.19), fund('B', 0.20), fund('C', 0.21)]
+        result = shrink_fund_estimates(close, frequency=4)
+        assert irr_of(result, 'shrinkage') == pytest.approx([1.0, 1.0, 1.0])
+        assert irr_of(result, 'shrunk') == pytest.approx([0.2, 0.2, 0.2])
+        assert result['groups']['Technology']['irr']['between_std'] == 0.0
+
+    def test_volatility_sampling_variance(self):
+        """Volatility uses v = σ² / (2 (n - 1))."""
+        funds = [fund('A', 0.1, volatility=0.1), fund('B', 0.2, volatility=0.2), fund('C', 0.3, volatility=0.3)]
+        result = shrink_fund_estimates(funds, frequency=4)
+        v = np.array([0.01, 0.04, 0.09]) / 38
+        tau2 = max(0.0, np.var([0.1, 0.2, 0.3], ddof=1) - v.mean())
+        expected = v / (v + tau2)
+        assert [f['volatility']['shrinkage'] for f in result['funds']] == pytest.approx(expected.tolist())
+
+
+class TestGroups:
+    """Test peer group assignment."""
+
+    def test_small_group_uses_portfolio(self):
+        """Two Healthcare funds fall back to the pooled group of all five."""
+        funds = SECTOR + [fund('D', 0.5, sector='Healthcare'), fund('E', 0.6, sector='Healthcare')]
+        result = shrink_fund_estimates(funds, frequency=4)
+        groups = [f['group'] for f in result['funds']]
+        assert groups == ['Technology'] * 3 + ['All'] * 2
+
+        pooled = result['groups']['All']
+        assert pooled['n_funds'] == 5
+        assert pooled['irr']['mean'] == pytest.approx(0.34)
+        b = 0.008 / np.var([0.1, 0.2, 0.3, 0.5, 0.6], ddof=1)
+        assert result['funds'][3]['irr']['shrunk'] == pytest.approx(0.34 + (1 - b) * 0.16)
+        # Technology keeps its own moments
+        assert irr_of(result, 'shrunk')[:3] == pytest.approx([0.18, 0.20, 0.22])
+
+    def test_sector_vintage_labels(self):
+        """sector_vintage groups by both attributes."""
+        funds = [fund(name, irr, vintage=2019) for name, irr in (('A', 0.1), ('B', 0.2), ('C', 0.3))]
+        result = shrink_fund_estimates(funds, group_by='sector_vintage', frequency=4)
+        assert {f['group'] for f in result['funds']} == {'Technology 2019'}
+
+
+class TestValidation:
+    """Test input validation."""
+
+    def test_unknown_grouping(self):
+        with pytest.raises(ValueError):
+            shrink_fund_estimates(SECTOR, group_by='manager')
+
+    def test_strength_range(self):
+        with pytest.raises(ValueError):
+            shrink_fund_estimates(SECTOR, strength=1.5)
+
+    def test_too_few_funds(self):
+        """Funds without IRR or volatility are dropped before counting."""
+        with pytest.raises(ValueError):
+            shrink_fund_estimates([fund('A', 0.1), fund('B', None)])
@@ -0,0 +1,79 @@
+"""
+Tests for reverse stress testing.
+
+Tests include:
+- A linear book recovers the closed-form shock s* = L Σb / (b'Σb)
+- Distance and plausibility follow from b'Σb for two factors
+- Single-factor breaches, losses by factor and infeasible bounds
+"""
+
+import pytest
+import numpy as np
+from analytics import reverse_stress_test
+
+HOLDINGS = [{'name': 'Book', 'value': 100.0, 'exposures': {'equity': 1.0, 'rates': -0.5}}]
+FACTORS = [{'name': 'equity', 'volatility': 0.2}, {'name': 'rates', 'volatility': 0.1}]
+
+
+class TestLinearBook:
+    """Test a book with no convexity against the closed form."""
+
+    def test_closed_form_shock(self):
+        """With b = (-1, 0.5) and Σ = diag(0.04, 0.01), b'Σb = 0.0425."""
+        result = reverse_stress_test(HOLDINGS, FACTORS, loss_threshold=0.2)
+
+        assert result['shock']['equity'] == pytest.approx(0.2 * -0.04 / 0.0425, rel=1e-5)
+        assert result['shock']['rates'] == pytest.approx(0.2 * 0.005 / 0.0425, rel=1e-5)
+        assert result['portfolio_loss'] == pytest.approx(0.2, rel=1e-6)
+
+    def test_distance_and_plausibility(self):
+        """d = L / √(b'Σb) and P(χ²₂ ≥ d²) = exp(-d²/2)."""
+        result = reverse_stress_test(HOLDINGS, FACTORS, loss_threshold=0.2)
+        distance = 0.2 / np.sqrt(0.0425)
+
+        assert result['mahalanobis_distance'] == pytest.approx(distance, rel=1e-5)
+        assert result['plausibility'] == pytest.approx(np.exp(-distance ** 2 / 2), rel=1e-4)
+
+    def test_losses_add_up(self):
+        """Factor losses sum to the portfolio loss; holding losses to loss × value."""
+        result = reverse_stress_test(HOLDINGS, FACTORS, loss_threshold=0.2)
+        assert sum(result['loss_by_factor'].values()) == pytest.approx(result['portfolio_loss'])
+        assert result['loss_by_holding']['Book'] == pytest.approx(100.0 * result['portfolio_loss'])
+
+    def test_single_factor_breach(self):
+        """Alone, equity must fall 20% and rates must rise 0.4."""
+        breach = reverse_stress_test(HOLDINGS, FACTORS, loss_threshold=0.2)['single_factor_breach']
+        assert breach['equity']['shock'] == pytest.approx(-0.2)
+        assert breach['equity']['shock_in_vols'] == pytest.approx(-1.0)
+        assert breach['rates']['shock'] == pytest.approx(0.4)
+
+
+class TestConvexity:
+    """Test the quadratic single-factor solution."""
+
+    def test_quadratic_root_nearest_zero(self):
+        """Loss -(s + ½ s²) = 0.1 has roots -1 ± √0.8; the nearer is -1 + √0.8."""
+        holdings = [{'name': 'Option', 'value': 1.0, 'exposures': {'equity': 1.0}, 'convexity': {'equity': 1.0}}]
+        result = reverse_stress_test(holdings, FACTORS[:1], loss_threshold=0.1)
+        assert result['single_factor_breach']['equity']['shock'] == pytest.approx(-1 + np.sqrt(0.8))
+
+
+class TestValidation:
+    """Test infeasible and invalid inputs."""
+
+    def test_bounds_too_tight(self):
+        """At most 10.5% can be lost within these bounds."""
+        factors = [{**FACTORS[0], 'lower': -0.1}, {**FACTORS[1], 'upper': 0.01}]
+        with pytest.raises(ValueError, match="No shock"):
+            reverse_stress_test(HOLDINGS, factors, loss_threshold=0.2)
+
+    def test_unknown_factor(self):
+        """Exposures must name known factors."""
+        holdings = [{'name': 'Book', 'value': 100.0, 'exposures': {'credit': 1.0}}]
+        with pytest.raises(ValueError, match="unknown factor"):
+            reverse_stress_test(holdings, FACTORS, loss_threshold=0.2)
+
+    def test_threshold_is_a_fraction(self):
+        """The loss threshold lies in (0, 1)."""
+        with pytest.raises(ValueError):
+            reverse_stress_test(HOLDINGS, FACTORS, loss_threshold=1.5)
@@ -0,0 +1,5 @@
+"""Strategy backtesting module."""
+from .engine import BacktestEngine, CommitmentPacing, RebalancingStrategy
+from .walk_forward import WalkForwardValidator, forecast_error_metrics
+
+__all__ = ['BacktestEngine', 'CommitmentPacing', 'RebalancingStrategy', 'WalkForwardValidator', 'forecast_error_metrics']
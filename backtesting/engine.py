@@ -0,0 +1,445 @@
+"""
+Allocation Strategy Backtesting Engine
+
+Replays a rebalancing strategy over a historical returns matrix and reports
+the resulting equity curve, drawdowns and turnover.
+
+Mechanics:
+---------
+Each period the portfolio earns r_p = w^T r_t and weights drift to
+w_i (1 + r_i) / (1 + r_p). At rebalance points weights are reset to target:
+- Calendar rebalancing every `rebalance_frequency` periods
+- Threshold rebalancing when max |w_i - target_i| exceeds `drift_threshold`
+
+Turnover of a rebalance is 0.5 × Σ|w_target - w_drifted| (one-way), and
+transaction costs are charged on Σ|Δw| at `transaction_cost` per unit traded.
+
+Commitment pacing (optional):
+----------------------------
+One asset can be a private (drawdown fund) allocation that is not traded at
+rebalances. Its NAV P moves with its returns and with capital calls C and
+distributions D against the unfunded commitment U (f periods per year):
+
+    C = min(U c / f, L),   D = P d / f,   P' = P + C - D,   U' = U - C
+
+where L is the liquid value (the cash moves to or from the liquid assets).
+Every `commitment_interval` periods new commitments bring NAV plus unfunded
+up to the overcommitment ratio k times the private target weight:
+
+    commitment = max(0, k w_private V - P - U)
+
+Rebalances then reset the liquid assets to their target mix within the
+liquid share 1 - w_private, and drift is measured against that mix.
+
+Pacing under uncertainty:
+------------------------
+The timing of calls and distributions is rarely on plan. With call and
+distribution volatilities σ_c, σ_d the per-period rates are scaled by
+independent lognormal shocks of mean one (as in analytics.liquidity):
+
+    C = min(U min(1, c/f ε_C), L),   D = P min(1, d/f ε_D),
+    ε = exp(σ Z - σ²/2)
+
+pacing_bands replays the same returns over many draws of these shocks and
+reports percentile bands of private NAV, unfunded commitments and their sum
+(exposure) around the point estimate of run.
+"""
+
+import numpy as np
+from dataclasses import dataclass
+from typing import Dict, List, Optional, Tuple
+
+PERCENTILES = (5, 50, 95)
+
+
+@dataclass
+class CommitmentPacing:
+    """
+    Commitment pacing rule for a private allocation.
+
+    Attributes:
+        private_asset: Column of the private asset in the returns matrix
+        call_rate: Annual fraction of unfunded commitments called
+        distribution_rate: Annual fraction of private NAV distributed
+        overcommitment: Ratio of NAV plus unfunded to the private target
+        commitment_interval: Periods between commitments (None = once a year)
+        call_volatility: Lognormal volatility of the per-period call rate
+        distribution_volatility: Lognormal volatility of the per-period distribution rate
+    """
+    private_asset: int
+    call_rate: float = 0.25
+    distribution_rate: float = 0.15
+    overcommitment: float = 1.3
+    commitment_interval: Optional[int] = None
+    call_volatility: float = 0.0
+    distribution_volatility: float = 0.0
+
+
+@dataclass
+class RebalancingStrategy:
+    """
+    Target-weight rebalancing strategy.
+
+    Attributes:
+        target_weights: Target allocation (must sum to 1)
+        rebalance_frequency: Periods between calendar rebalances (0 = never)
+        drift_threshold: Rebalance when any weight drifts further than this
+        transaction_cost: Cost per unit of weight traded (e.g. 0.001 = 10bps)
+        pacing: Commitment pacing rule for a private asset (None = all liquid)
+    """
+    target_weights: np.ndarray
+    rebalance_frequency: int = 3
+    drift_threshold: Optional[float] = None
+    transaction_cost: float = 0.0
+    pacing: Optional[CommitmentPacing] = None
+
+
+class BacktestEngine:
+    """
+    Backtester for static-target allocation strategies.
+
+    Attributes:
+        returns (np.ndarray): Periodic returns (n_periods × n_assets)
+        frequency (int): Periods per year for annualization
+        risk_free_rate (float): Annual risk-free rate for the Sharpe ratio
+        n_periods (int): Number of periods
+        n_assets (int): Number of assets
+
+    Example:
+        >>> returns = np.random.randn(120, 4) * 0.03 + 0.006  # 10 years monthly
+        >>> engine = BacktestEngine(returns, frequency=12)
+        >>> strategy = RebalancingStrategy(np.array([0.4, 0.3, 0.2, 0.1]), rebalance_frequency=3)
+        >>> result = engine.run(strategy)
+        >>> print(f"Max drawdown: {result['max_drawdown']:.2%}")
+    """
+
+    def __init__(self, returns: np.ndarray, frequency: int = 12, risk_free_rate: float = 0.0):
+        """
+        Initialize backtest engine.
+
+        Parameters:
+            returns: Historical returns matrix (n_periods × n_assets)
+            frequency: Periods per year (default 12 for monthly)
+            risk_free_rate: Annual risk-free rate; the Sharpe ratio is
+                (annualized return - risk_free_rate) / annualized volatility
+        """
+        self.returns = np.asarray(returns, dtype=float)
+        if self.returns.ndim != 2:
+            raise ValueError("returns must be a 2D array (n_periods × n_assets)")
+        if not np.all(np.isfinite(self.returns)):
+            raise ValueError("returns must be finite")
+        if np.any(self.returns <= -1):
+            raise ValueError("returns must be greater than -100%")
+
+        self.frequency = frequency
+        self.risk_free_rate = risk_free_rate
+        self.n_periods, self.n_assets = self.returns.shape
+
+    def run(
+        self,
+        strategy: RebalancingStrategy,
+        initial_value: float = 1.0
+    ) -> Dict[str, any]:
+        """
+        Run a backtest of the given strategy.
+
+        Parameters:
+            strategy: Rebalancing strategy to replay
+            initial_value: Starting portfolio value
+
+        Returns:
+            Dictionary with equity curve, drawdowns, turnover and summary
+            statistics, plus commitments, calls, distributions and the
+            unfunded balance when the strategy has a pacing rule (at their
+            planned rates: call and distribution volatilities are ignored)
+        """
+        return self._replay(strategy, initial_value, None)
+
+    def pacing_bands(
+        self,
+        strategy: RebalancingStrategy,
+        n_paths: int = 1000,
+        seed: Optional[int] = None,
+        initial_value: float = 1.0
+    ) -> Dict[str, any]:
+        """
+        Monte Carlo of the commitment plan under stochastic call and distribution timing.
+
+        Parameters:
+            strategy: Rebalancing strategy with a pacing rule
+            n_paths: Number of simulated call and distribution paths
+            seed: Random seed for reproducibility
+            initial_value: Starting portfolio value
+
+        Returns:
+            Dictionary with per-period percentile bands (keyed '5', '50',
+            '95') of private NAV, unfunded commitments, exposure (NAV plus
+            unfunded), calls and distributions, percentiles of total
+            commitments, calls and distributions, and the point estimate of
+            NAV, unfunded and exposure at the planned rates
+        """
+        if strategy.pacing is None:
+            raise ValueError("pacing_bands needs a strategy with a pacing rule")
+        if n_paths < 1:
+            raise ValueError("n_paths must be at least 1")
+        rng = np.random.default_rng(seed)
+
+        plan = self._replay(strategy, initial_value, None)
+        paths = [self._replay(strategy, initial_value, rng) for _ in range(n_paths)]
+
+        def private_nav(result):
+            return result['equity_curve'][1:] * result['private_weight']
+
+        nav = np.array([private_nav(r) for r in paths])
+        unfunded = np.array([r['unfunded'] for r in paths])
+        plan_nav = private_nav(plan)
+
+        return {
+            'n_paths': n_paths,
+            'private_nav': _bands(nav),
+            'unfunded': _bands(unfunded),
+            'exposure': _bands(nav + unfunded),
+            'calls': _bands(np.array([r['calls'] for r in paths])),
+            'distributions': _bands(np.array([r['distributions'] for r in paths])),
+            'total_commitments': _bands(np.array([r['total_commitments'] for r in paths])),
+            'total_calls': _bands(np.array([r['total_calls'] for r in paths])),
+            'total_distributions': _bands(np.array([r['total_distributions'] for r in paths])),
+            'point_estimate': {
+                'private_nav': plan_nav,
+                'unfunded': plan['unfunded'],
+                'exposure': plan_nav + plan['unfunded'],
+            },
+        }
+
+    def _replay(
+        self,
+        strategy: RebalancingStrategy,
+        initial_value: float,
+        rng: Optional[np.random.Generator]
+    ) -> Dict[str, any]:
+        """Replay the strategy; rng draws call and distribution shocks (None = planned rates)."""
+        target = self._validate_strategy(strategy)
+        pacing = strategy.pacing
+
+        weights = target.copy()
+        value = initial_value
+        unfunded = 0.0
+        unfunded_history = np.zeros(self.n_periods)
+        call_history = np.zeros(self.n_periods)
+        distribution_history = np.zeros(self.n_periods)
+        commitments: List[Dict[str, float]] = []
+        total_calls = total_distributions = 0.0
+
+        equity = np.empty(self.n_periods + 1)
+        equity[0] = initial_value
+        period_returns = np.empty(self.n_periods)
+        weight_history = np.empty((self.n_periods, self.n_assets))
+        rebalances: List[Dict[str, float]] = []
+
+        for t in range(self.n_periods):
+            r_t = self.returns[t]
+            port_ret = float(np.dot(weights, r_t))
+
+            value *= 1 + port_ret
+            weights = weights * (1 + r_t) / (1 + port_ret)
+
+            if pacing is not None:
+                shocks = self._pacing_shocks(pacing, rng)
+                weights, unfunded, calls, distributions = self._apply_pacing(weights, value, unfunded, pacing, *shocks)
+                total_calls += calls
+                total_distributions += distributions
+                call_history[t] = calls
+                distribution_history[t] = distributions
+
+                interval = pacing.commitment_interval or self.frequency
+                if t % interval == 0:
+                    private = value * weights[pacing.private_asset]
+                    amount = max(0.0, pacing.overcommitment * target[pacing.private_asset] * value - private - unfunded)
+                    if amount > 0:
+                        unfunded += amount
+                        commitments.append({'period': t, 'amount': amount})
+                unfunded_history[t] = unfunded
+
+            goal = self._rebalance_target(weights, target, pacing)
+            if self._should_rebalance(t, weights, goal, strategy):
+                traded = float(np.sum(np.abs(goal - weights)))
+                cost = value * traded * strategy.transaction_cost
+                value -= cost
+                port_ret = (1 + port_ret) * (1 - traded * strategy.transaction_cost) - 1
+                rebalances.append({
+                    'period': t,
+                    'turnover': 0.5 * traded,
+                    'cost': cost,
+                })
+                weights = goal
+
+            equity[t + 1] = value
+            period_returns[t] = port_ret
+            weight_history[t] = weights
+
+        running_max = np.maximum.accumulate(equity)
+        drawdowns = equity / running_max - 1
+
+        total_turnover = float(sum(r['turnover'] for r in rebalances))
+        years = self.n_periods / self.frequency
+        total_return = equity[-1] / initial_value - 1
+        ann_return = (equity[-1] / initial_value) ** (1 / years) - 1 if years > 0 else 0.0
+        ann_vol = float(np.std(period_returns, ddof=1) * np.sqrt(self.frequency)) if self.n_periods > 1 else 0.0
+
+        result = {
+            'equity_curve': equity,
+            'returns': period_returns,
+            'weights': weight_history,
+            'drawdowns': drawdowns,
+            'max_drawdown': float(np.min(drawdowns)),
+            'total_return': float(total_return),
+            'annualized_return': float(ann_return),
+            'annualized_volatility': ann_vol,
+            'sharpe_ratio': float((ann_return - self.risk_free_rate) / ann_vol) if ann_vol > 0 else 0.0,
+            'rebalances': rebalances,
+            'n_rebalances': len(rebalances),
+            'total_turnover': total_turnover,
+            'annualized_turnover': total_turnover / years if years > 0 else 0.0,
+            'total_costs': float(sum(r['cost'] for r in rebalances)),
+        }
+
+        if pacing is not None:
+            result.update({
+                'private_weight': weight_history[:, pacing.private_asset],
+                'unfunded': unfunded_history,
+                'calls': call_history,
+                'distributions': distribution_history,
+                'commitments': commitments,
+                'total_commitments': float(sum(c['amount'] for c in commitments)),
+                'total_calls': total_calls,
+                'total_distributions': total_distributions,
+            })
+
+        return result
+
+    def compare(
+        self,
+        strategies: Dict[str, RebalancingStrategy],
+        initial_value: float = 1.0
+    ) -> Dict[str, Dict[str, float]]:
+        """
+        Run several strategies and tabulate their summary statistics.
+
+        Parameters:
+            strategies: Mapping of strategy name to strategy
+            initial_value: Starting portfolio value
+
+        Returns:
+            Dictionary of summary statistics per strategy
+        """
+        summary_keys = [
+            'total_return', 'annualized_return', 'annualized_volatility',
+            'sharpe_ratio', 'max_drawdown', 'n_rebalances', 'annualized_turnover'
+        ]
+
+        results = {}
+        for name, strategy in strategies.items():
+            result = self.run(strategy, initial_value=initial_value)
+            results[name] = {k: result[k] for k in summary_keys}
+
+        return results
+
+    def _validate_strategy(self, strategy: RebalancingStrategy) -> np.ndarray:
+        """Validate strategy parameters and return target weights."""
+        target = np.asarray(strategy.target_weights, dtype=float)
+
+        if target.shape != (self.n_assets,):
+            raise ValueError(f"target_weights must have {self.n_assets} entries")
+        if not np.isclose(np.sum(target), 1.0, atol=1e-6):
+            raise ValueError("target_weights must sum to 1")
+        if strategy.rebalance_frequency < 0:
+            raise ValueError("rebalance_frequency must be non-negative")
+        if strategy.drift_threshold is not None and strategy.drift_threshold <= 0:
+            raise ValueError("drift_threshold must be positive")
+        if strategy.transaction_cost < 0:
+            raise ValueError("transaction_cost must be non-negative")
+
+        pacing = strategy.pacing
+        if pacing is not None:
+            if not 0 <= pacing.private_asset < self.n_assets:
+                raise ValueError(f"private_asset must be between 0 and {self.n_assets - 1}")
+            if not 0 < target[pacing.private_asset] < 1:
+                raise ValueError("The private asset's target weight must be between 0 and 1")
+            if pacing.call_rate < 0 or pacing.distribution_rate < 0:
+                raise ValueError("call_rate and distribution_rate must be non-negative")
+            if pacing.overcommitment < 1:
+                raise ValueError("overcommitment must be at least 1")
+            if pacing.commitment_interval is not None and pacing.commitment_interval < 1:
+                raise ValueError("commitment_interval must be a positive number of periods")
+            if pacing.call_volatility < 0 or pacing.distribution_volatility < 0:
+                raise ValueError("call_volatility and distribution_volatility must be non-negative")
+
+        return target
+
+    @staticmethod
+    def _pacing_shocks(pacing: CommitmentPacing, rng: Optional[np.random.Generator]) -> Tuple[float, float]:
+        """Mean-one lognormal multipliers of this period's call and distribution rates."""
+        if rng is None:
+            return 1.0, 1.0
+        z_call, z_distribution = rng.standard_normal(2)
+        sc, sd = pacing.call_volatility, pacing.distribution_volatility
+        return float(np.exp(sc * z_call - 0.5 * sc ** 2)), float(np.exp(sd * z_distribution - 0.5 * sd ** 2))
+
+    def _apply_pacing(
+        self,
+        weights: np.ndarray,
+        value: float,
+        unfunded: float,
+        pacing: CommitmentPacing,
+        call_shock: float = 1.0,
+        distribution_shock: float = 1.0
+    ) -> Tuple[np.ndarray, float, float, float]:
+        """Move one period of calls and distributions between the private and liquid assets."""
+        p = pacing.private_asset
+        private = value * weights[p]
+        liquid = value - private
+
+        calls = min(unfunded * min(1.0, pacing.call_rate / self.frequency * call_shock), liquid)
+        distributions = private * min(1.0, pacing.distribution_rate / self.frequency * distribution_shock)
+        new_liquid = liquid - calls + distributions
+
+        weights = weights.copy()
+        if liquid > 0:
+            weights *= new_liquid / liquid
+        weights[p] = (private + calls - distributions) / value
+        return weights, unfunded - calls, calls, distributions
+
+    @staticmethod
+    def _rebalance_target(
+        weights: np.ndarray,
+        target: np.ndarray,
+        pacing: Optional[CommitmentPacing]
+    ) -> np.ndarray:
+        """Weights a rebalance trades to: the target, or the liquid target mix around the private weight."""
+        if pacing is None:
+            return target.copy()
+        p = pacing.private_asset
+        liquid_target = target.copy()
+        liquid_target[p] = 0.0
+        goal = liquid_target * (1 - weights[p]) / liquid_target.sum()
+        goal[p] = weights[p]
+        return goal
+
+    @staticmethod
+    def _should_rebalance(
+        t: int,
+        weights: np.ndarray,
+        target: np.ndarray,
+        strategy: RebalancingStrategy
+    ) -> bool:
+        """Check calendar and drift rebalancing triggers at the end of period t."""
+        if strategy.rebalance_frequency > 0 and (t + 1) % strategy.rebalance_frequency == 0:
+            return True
+        if strategy.drift_threshold is not None:
+            return bool(np.max(np.abs(weights - target)) > strategy.drift_threshold)
+        return False
+
+
+def _bands(x: np.ndarray) -> Dict[str, any]:
+    """Selected percentiles across paths (axis 0), keyed as strings."""
+    return {str(p): v for p, v in zip(PERCENTILES, np.percentile(x, PERCENTILES, axis=0))}
@@ -0,0 +1,197 @@
+"""
+Tests for the allocation backtesting engine.
+
+Tests include:
+- Buy-and-hold and per-period rebalancing against compounded closed forms
+- Turnover and transaction costs of a single rebalance
+- Drift-threshold triggers and maximum drawdown
+- Sharpe ratio in excess of the risk-free rate
+- Commitment pacing: commitments, calls, distributions and unfunded balance
+- Pacing bands under stochastic call and distribution timing
+- Strategy validation
+"""
+
+import pytest
+import numpy as np
+from backtesting import BacktestEngine, CommitmentPacing, RebalancingStrategy
+
+HALF_HALF = np.array([0.5, 0.5])
+
+
+def constant_returns(n_periods, *returns):
+    return np.tile(np.array(returns, dtype=float), (n_periods, 1))
+
+
+class TestRebalancing:
+    """Test equity curves against closed forms."""
+
+    def test_buy_and_hold(self):
+        """Without rebalancing each sleeve compounds on its own."""
+        engine = BacktestEngine(constant_returns(12, 0.01, 0.0))
+        result = engine.run(RebalancingStrategy(HALF_HALF, rebalance_frequency=0))
+
+        assert result['equity_curve'][-1] == pytest.approx(0.5 * 1.01 ** 12 + 0.5)
+        assert result['n_rebalances'] == 0
+
+    def test_rebalance_every_period(self):
+        """Resetting to 50/50 each period compounds the average return."""
+        engine = BacktestEngine(constant_returns(12, 0.01, 0.0))
+        result = engine.run(RebalancingStrategy(HALF_HALF, rebalance_frequency=1))
+
+        assert result['equity_curve'][-1] == pytest.approx(1.005 ** 12)
+        assert result['n_rebalances'] == 12
+
+    def test_turnover_and_cost(self):
+        """One-way turnover is the drift of one sleeve; cost is charged on both legs."""
+        engine = BacktestEngine(constant_returns(1, 0.01, 0.0))
+        result = engine.run(RebalancingStrategy(HALF_HALF, rebalance_frequency=1, transaction_cost=0.001))
+
+        drift = 0.505 / 1.005 - 0.5
+        assert result['total_turnover'] == pytest.approx(drift)
+        assert result['total_costs'] == pytest.approx(1.005 * 2 * drift * 0.001)
+        assert result['equity_curve'][-1] == pytest.approx(1.005 * (1 - 2 * drift * 0.001))
+
+    def test_drift_threshold(self):
+        """A threshold above the drift does not trigger; one below does."""
+        drift = 0.505 / 1.005 - 0.5
+        engine = BacktestEngine(constant_returns(1, 0.01, 0.0))
+
+        loose = engine.run(RebalancingStrategy(HALF_HALF, rebalance_frequency=0, drift_threshold=drift * 1.01))
+        tight = engine.run(RebalancingStrategy(HALF_HALF, rebalance_frequency=0, drift_threshold=drift * 0.99))
+        assert loose['n_rebalances'] == 0
+        assert tight['n_rebalances'] == 1
+
+    def test_max_drawdown(self):
+        """1 → 1.1 → 0.55 → 0.66 has a 50% maximum drawdown."""
+        engine = BacktestEngine(np.array([[0.1], [-0.5], [0.2]]))
+        result = engine.run(RebalancingStrategy(np.array([1.0]), rebalance_frequency=0))
+
+        assert result['max_drawdown'] == pytest.approx(-0.5)
+        assert result['equity_curve'] == pytest.approx([1.0, 1.1, 0.55, 0.66])
+
+    def test_sharpe_excess_of_risk_free(self):
+        """Returns of 3%, -1% a quarter: Sharpe is (annualized return - r_f) / annualized vol."""
+        returns = np.array([[0.03], [-0.01], [0.03], [-0.01]])
+        strategy = RebalancingStrategy(np.array([1.0]), rebalance_frequency=0)
+        result = BacktestEngine(returns, frequency=4, risk_free_rate=0.02).run(strategy)
+
+        ann_return = (1.03 * 0.99) ** 2 - 1
+        ann_vol = np.std([0.03, -0.01, 0.03, -0.01], ddof=1) * 2
+        assert result['annualized_return'] == pytest.approx(ann_return)
+        assert result['sharpe_ratio'] == pytest.approx((ann_return - 0.02) / ann_vol)
+
+
+class TestPacing:
+    """Test commitment pacing with zero market returns."""
+
+    def test_distributions_and_recommitment(self):
+        """Distributions shrink P by d/f a period; the first period commits back to target."""
+        engine = BacktestEngine(np.zeros((4, 2)), frequency=4)
+        pacing = CommitmentPacing(private_asset=0, call_rate=0.0, distribution_rate=0.4, overcommitment=1.0)
+        result = engine.run(RebalancingStrategy(np.array([0.3, 0.7]), rebalance_frequency=0, pacing=pacing))
+
+        assert result['total_distributions'] == pytest.approx(0.3 * (1 - 0.9 ** 4))
+        assert result['private_weight'] == pytest.approx(0.3 * 0.9 ** np.arange(1, 5))
+        assert [c['period'] for c in result['commitments']] == [0]
+        assert result['total_commitments'] == pytest.approx(0.03)
+        assert result['unfunded'] == pytest.approx([0.03] * 4)
+        assert result['equity_curve'][-1] == pytest.approx(1.0)
+
+    def test_calls_draw_unfunded(self):
+        """With c = f the whole unfunded balance is called the next period."""
+        engine = BacktestEngine(np.zeros((2, 2)), frequency=4)
+        pacing = CommitmentPacing(private_asset=0, call_rate=4.0, distribution_rate=0.4,
+                                  overcommitment=1.0, commitment_interval=4)
+        result = engine.run(RebalancingStrategy(np.array([0.3, 0.7]), rebalance_frequency=0, pacing=pacing))
+
+        assert result['total_calls'] == pytest.approx(0.03)
+        assert result['unfunded'] == pytest.approx([0.03, 0.0])
+        assert result['private_weight'] == pytest.approx([0.27, 0.27 + 0.03 - 0.027])
+
+    def test_rebalance_keeps_private_weight(self):
+        """Rebalances reset the liquid mix only."""
+        engine = BacktestEngine(constant_returns(3, 0.0, 0.02, -0.01), frequency=4)
+        pacing = CommitmentPacing(private_asset=0, call_rate=0.0, distribution_rate=0.4)
+        result = engine.run(RebalancingStrategy(np.array([0.2, 0.4, 0.4]), rebalance_frequency=1, pacing=pacing))
+
+        weights = result['weights']
+        assert weights[:, 1] == pytest.approx(weights[:, 2])
+        assert weights[:, 0] == pytest.approx(result['private_weight'])
+
+
+class TestPacingBands:
+    """Test the commitment plan under stochastic call and distribution timing."""
+
+    def test_no_volatility_matches_plan(self):
+        """Without timing noise every path is the point estimate."""
+        engine = BacktestEngine(constant_returns(8, 0.02, 0.01), frequency=4)
+        pacing = CommitmentPacing(private_asset=0, call_rate=1.0, distribution_rate=0.3)
+        bands = engine.pacing_bands(RebalancingStrategy(np.array([0.3, 0.7]), pacing=pacing), n_paths=5, seed=1)
+
+        for key in ('private_nav', 'unfunded', 'exposure'):
+            for band in bands[key].values():
+                assert band == pytest.approx(bands['point_estimate'][key])
+
+    def test_call_timing_moves_unfunded_not_exposure(self):
+        """With flat returns and no distributions, calls only move unfunded into NAV."""
+        engine = BacktestEngine(np.zeros((8, 2)), frequency=4)
+        pacing = CommitmentPacing(private_asset=0, call_rate=1.0, distribution_rate=0.0,
+                                  commitment_interval=100, call_volatility=0.8)
+        bands = engine.pacing_bands(RebalancingStrategy(np.array([0.3, 0.7]), rebalance_frequency=0, pacing=pacing),
+                                    n_paths=200, seed=7)
+
+        for band in bands['exposure'].values():
+            assert band == pytest.approx([0.39] * 8)
+        assert np.all(bands['unfunded']['95'][1:] > bands['unfunded']['5'][1:])
+        assert np.all(bands['unfunded']['5'] >= 0)
+        assert bands['total_commitments']['50'] == pytest.approx(0.09)
+
+    def test_bands_are_ordered_and_reproducible(self):
+        """Percentiles are ordered per period and a seed fixes the draws."""
+        engine = BacktestEngine(constant_returns(12, 0.02, 0.01), frequency=4)
+        pacing = CommitmentPacing(private_asset=0, call_volatility=0.5, distribution_volatility=0.5)
+        strategy = RebalancingStrategy(np.array([0.3, 0.7]), pacing=pacing)
+        bands = engine.pacing_bands(strategy, n_paths=100, seed=3)
+
+        for key in ('private_nav', 'unfunded', 'exposure', 'calls', 'distributions'):
+            assert np.all(bands[key]['5'] <= bands[key]['50'] + 1e-12)
+            assert np.all(bands[key]['50'] <= bands[key]['95'] + 1e-12)
+        again = engine.pacing_bands(strategy, n_paths=100, seed=3)
+        assert again['private_nav']['50'] == pytest.approx(bands['private_nav']['50'])
+
+    def test_needs_pacing(self):
+        """Bands describe a commitment plan."""
+        with pytest.raises(ValueError, match="pacing"):
+            BacktestEngine(np.zeros((3, 2))).pacing_bands(RebalancingStrategy(HALF_HALF))
+
+    def test_volatility_non_negative(self):
+        """Timing volatilities are lognormal scales."""
+        pacing = CommitmentPacing(private_asset=0, call_volatility=-0.1)
+        with pytest.raises(ValueError, match="volatility"):
+            BacktestEngine(np.zeros((3, 2))).pacing_bands(RebalancingStrategy(np.array([0.3, 0.7]), pacing=pacing))
+
+
+class TestValidation:
+    """Test rejected strategies."""
+
+    def test_weights_must_sum_to_one(self):
+        """Targets are a full allocation."""
+        with pytest.raises(ValueError):
+            BacktestEngine(np.zeros((3, 2))).run(RebalancingStrategy(np.array([0.5, 0.4])))
+
+    def test_private_target_inside_unit_interval(self):
+        """An all-private target leaves nothing to fund calls."""
+        pacing = CommitmentPacing(private_asset=0)
+        with pytest.raises(ValueError, match="private asset"):
+            BacktestEngine(np.zeros((3, 2))).run(RebalancingStrategy(np.array([1.0, 0.0]), pacing=pacing))
+
+    def test_overcommitment_at_least_one(self):
+        """Overcommitment below one would under-commit by design."""
+        pacing = CommitmentPacing(private_asset=0, overcommitment=0.9)
+        with pytest.raises(ValueError, match="overcommitment"):
+            BacktestEngine(np.zeros((3, 2))).run(RebalancingStrategy(np.array([0.3, 0.7]), pacing=pacing))
+
+    def test_returns_below_minus_one(self):
+        """A return of -100% or worse is rejected."""
+        with pytest.raises(ValueError):
+            BacktestEngine(np.array([[-1.0]]))
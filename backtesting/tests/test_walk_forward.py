@@ -0,0 +1,124 @@
+"""
+Tests for walk-forward validation.
+
+Tests include:
+- Rolling and expanding split boundaries
+- The step defaults to the test window (non-overlapping tests)
+- Error metrics against hand-computed values
+- Per-window and pooled metrics of a mean forecaster
+"""
+
+import pytest
+import numpy as np
+from backtesting import WalkForwardValidator, forecast_error_metrics
+
+
+class MeanModel:
+    """Predicts the training mean (fit/predict interface)."""
+
+    def fit(self, X, y):
+        self.mean = float(np.mean(y))
+
+    def predict(self, X):
+        return np.full(len(X), self.mean)
+
+
+class TrainModelMean:
+    """Mean model with PortfolioMLForecaster's train_model interface."""
+
+    def train_model(self, X, y):
+        self.mean = float(np.mean(y))
+
+    def predict(self, X):
+        return np.full(len(X), self.mean)
+
+
+def bounds(splits):
+    return [(int(tr[0]), int(tr[-1]), int(te[0]), int(te[-1])) for tr, te in splits]
+
+
+class TestSplits:
+    """Test train/test window boundaries."""
+
+    def test_rolling(self):
+        """Ten observations, 4 train and 2 test, roll by 2."""
+        wf = WalkForwardValidator(train_window=4, test_window=2)
+        assert bounds(wf.splits(10)) == [(0, 3, 4, 5), (2, 5, 6, 7), (4, 7, 8, 9)]
+
+    def test_expanding(self):
+        """The training window always starts at 0."""
+        wf = WalkForwardValidator(train_window=4, test_window=2, expanding=True)
+        assert bounds(wf.splits(10)) == [(0, 3, 4, 5), (0, 5, 6, 7), (0, 7, 8, 9)]
+
+    def test_step_defaults_to_test_window(self):
+        """Default step gives back-to-back, non-overlapping test windows."""
+        wf = WalkForwardValidator(train_window=4, test_window=3)
+        assert wf.step == 3
+        tests = [te for _, te in wf.splits(13)]
+        assert np.concatenate(tests).tolist() == list(range(4, 13))
+
+    def test_explicit_step(self):
+        """A step of 1 overlaps test windows; a partial final window is dropped."""
+        wf = WalkForwardValidator(train_window=4, test_window=2, step=1)
+        assert bounds(wf.splits(7)) == [(0, 3, 4, 5), (1, 4, 5, 6)]
+
+    def test_too_few_observations(self):
+        """At least one full train plus test window is required."""
+        with pytest.raises(ValueError):
+            WalkForwardValidator(train_window=4, test_window=2).splits(5)
+
+    def test_invalid_windows(self):
+        """Windows and step must be positive."""
+        with pytest.raises(ValueError):
+            WalkForwardValidator(train_window=0, test_window=2)
+        with pytest.raises(ValueError):
+            WalkForwardValidator(train_window=4, test_window=2, step=0)
+
+
+class TestMetrics:
+    """Test forecast error metrics."""
+
+    def test_hand_computed(self):
+        """Errors (1, 0, -2) on actuals (1, 2, 4)."""
+        m = forecast_error_metrics(np.array([1.0, 2.0, 4.0]), np.array([2.0, 2.0, 2.0]))
+        assert m['n'] == 3
+        assert m['rmse'] == pytest.approx(np.sqrt(5 / 3))
+        assert m['mae'] == pytest.approx(1.0)
+        assert m['bias'] == pytest.approx(-1 / 3)
+        assert m['mape'] == pytest.approx((1 + 0 + 0.5) / 3)
+
+    def test_mape_skips_zero_actuals(self):
+        """MAPE is over non-zero actuals, and None when there are none."""
+        assert forecast_error_metrics(np.array([0.0, 2.0]), np.array([1.0, 3.0]))['mape'] == pytest.approx(0.5)
+        assert forecast_error_metrics(np.zeros(2), np.ones(2))['mape'] is None
+
+
+class TestValidate:
+    """Test walk-forward validation of a mean forecaster."""
+
+    def setup_method(self):
+        self.X = np.arange(8.0).reshape(-1, 1)
+        self.y = np.array([0.0, 0.0, 0.0, 0.0, 1.0, 1.0, 1.0, 1.0])
+
+    def test_windows_and_pooled_metrics(self):
+        """Means 0 then 0.5 against actuals of 1."""
+        report = WalkForwardValidator(train_window=4, test_window=2).validate(self.X, self.y, MeanModel)
+
+        assert report['n_windows'] == 2
+        assert report['predictions'].tolist() == pytest.approx([0.0, 0.0, 0.5, 0.5])
+        assert [w['rmse'] for w in report['windows']] == pytest.approx([1.0, 0.5])
+        assert [w['bias'] for w in report['windows']] == pytest.approx([-1.0, -0.5])
+        assert report['overall']['rmse'] == pytest.approx(np.sqrt(0.625))
+        assert report['overall']['bias'] == pytest.approx(-0.75)
+        assert report['windows'][1]['test_start'] == 6
+
+    def test_train_model_interface(self):
+        """Models without fit are trained with train_model."""
+        fit = WalkForwardValidator(train_window=4, test_window=2).validate(self.X, self.y, MeanModel)
+        trained = WalkForwardValidator(train_window=4, test_window=2).validate(self.X, self.y, TrainModelMean)
+        assert trained['predictions'].tolist() == fit['predictions'].tolist()
+
+    def test_length_mismatch(self):
+        """X and y must align."""
+        with pytest.raises(ValueError):
+            WalkForwardValidator(train_window=4, test_window=2).validate(self.X[:-1], self.y, MeanModel)
@@ -0,0 +1,186 @@
+"""
+Walk-Forward Validation
+
+Repeatedly calibrates a forecasting model on a training window and evaluates
+it on the following out-of-sample window, rolling forward through time.
+
+Window Schemes:
+--------------
+Rolling:    [t, t+train) → [t+train, t+train+test), t += step
+Expanding:  [0, t+train) → [t+train, t+train+test), t += step
+
+Error Metrics (per window and pooled):
+- RMSE:  sqrt(mean((ŷ - y)²))
+- MAE:   mean(|ŷ - y|)
+- Bias:  mean(ŷ - y)
+- MAPE:  mean(|ŷ - y| / |y|) over observations with y ≠ 0
+"""
+
+import numpy as np
+from typing import Callable, Dict, List, Tuple
+
+
+class WalkForwardValidator:
+    """
+    Walk-forward out-of-sample validation for forecasting models.
+
+    Models are created fresh for each window by `model_factory` and must
+    provide `fit(X, y)` (or `train_model(X, y)`, as PortfolioMLForecaster
+    does) and `predict(X)`.
+
+    Attributes:
+        train_window (int): Observations in each training window
+        test_window (int): Observations in each evaluation window
+        step (int): Observations to roll forward between windows
+        expanding (bool): Grow the training window instead of rolling it
+
+    Example:
+        >>> from sklearn.linear_model import LinearRegression
+        >>> wf = WalkForwardValidator(train_window=60, test_window=12)
+        >>> report = wf.validate(X, y, LinearRegression)
+        >>> print(report['overall']['rmse'])
+    """
+
+    def __init__(
+        self,
+        train_window: int,
+        test_window: int,
+        step: int = None,
+        expanding: bool = False
+    ):
+        """
+        Initialize walk-forward validator.
+
+        Parameters:
+            train_window: Training observations per window
+            test_window: Out-of-sample observations per window
+            step: Roll-forward step (default: test_window, i.e. non-overlapping tests)
+            expanding: Use an expanding rather than rolling training window
+        """
+        if train_window < 1 or test_window < 1:
+            raise ValueError("train_window and test_window must be positive")
+
+        self.train_window = train_window
+        self.test_window = test_window
+        self.step = step if step is not None else test_window
+        self.expanding = expanding
+
+        if self.step < 1:
+            raise ValueError("step must be positive")
+
+    def splits(self, n_obs: int) -> List[Tuple[np.ndarray, np.ndarray]]:
+        """
+        Generate train/test index pairs.
+
+        Parameters:
+            n_obs: Total number of observations
+
+        Returns:
+            List of (train_indices, test_indices) tuples in time order
+        """
+        if n_obs < self.train_window + self.test_window:
+            raise ValueError(
+                f"Need at least {self.train_window + self.test_window} observations, got {n_obs}"
+            )
+
+        result = []
+        start = 0
+        while start + self.train_window + self.test_window <= n_obs:
+            train_start = 0 if self.expanding else start
+            train_end = start + self.train_window
+            test_end = train_end + self.test_window
+
+            result.append((np.arange(train_start, train_end), np.arange(train_end, test_end)))
+            start += self.step
+
+        return result
+
+    def validate(
+        self,
+        X: np.ndarray,
+        y: np.ndarray,
+        model_factory: Callable[[], any]
+    ) -> Dict[str, any]:
+        """
+        Run walk-forward validation.
+
+        Parameters:
+            X: Feature matrix (n_obs × n_features), ordered in time
+            y: Target vector (n_obs,)
+            model_factory: Zero-argument callable returning an unfitted model
+
+        Returns:
+            Dictionary with per-window metrics, pooled out-of-sample metrics
+            and the out-of-sample predictions
+        """
+        X = np.asarray(X)
+        y = np.asarray(y, dtype=float)
+
+        if len(X) != len(y):
+            raise ValueError("X and y must have the same number of observations")
+
+        windows = []
+        all_pred = []
+        all_actual = []
+
+        for i, (train_idx, test_idx) in enumerate(self.splits(len(y))):
+            model = model_factory()
+            if hasattr(model, 'fit'):
+                model.fit(X[train_idx], y[train_idx])
+            else:
+                model.train_model(X[train_idx], y[train_idx])
+
+            y_pred = np.asarray(model.predict(X[test_idx]), dtype=float)
+            y_test = y[test_idx]
+
+            metrics = forecast_error_metrics(y_test, y_pred)
+            metrics.update({
+                'window': i,
+                'train_start': int(train_idx[0]),
+                'train_end': int(train_idx[-1]),
+                'test_start': int(test_idx[0]),
+                'test_end': int(test_idx[-1]),
+            })
+            windows.append(metrics)
+
+            all_pred.append(y_pred)
+            all_actual.append(y_test)
+
+        predictions = np.concatenate(all_pred)
+        actuals = np.concatenate(all_actual)
+
+        return {
+            'windows': windows,
+            'n_windows': len(windows),
+            'overall': forecast_error_metrics(actuals, predictions),
+            'rmse_by_window': np.array([w['rmse'] for w in windows]),
+            'predictions': predictions,
+            'actuals': actuals,
+        }
+
+
+def forecast_error_metrics(actual: np.ndarray, predicted: np.ndarray) -> Dict[str, float]:
+    """
+    Calculate forecast error metrics.
+
+    Parameters:
+        actual: Realized values
+        predicted: Forecast values
+
+    Returns:
+        Dictionary with n, rmse, mae, bias and mape (None if all actuals are zero)
+    """
+    actual = np.asarray(actual, dtype=float)
+    predicted = np.asarray(predicted, dtype=float)
+    errors = predicted - actual
+
+    nonzero = actual != 0
+    mape = float(np.mean(np.abs(errors[nonzero]) / np.abs(actual[nonzero]))) if np.any(nonzero) else None
+
+    return {
+        'n': int(len(actual)),
+        'rmse': float(np.sqrt(np.mean(errors ** 2))),
+        'mae': float(np.mean(np.abs(errors))),
+        'bias': float(np.mean(errors)),
+        'mape': mape,
+    }
@@ -0,0 +1,161 @@
+#!/usr/bin/env python3
+"""
+Performance Regression Suite
+
+Micro-benchmarks for the hot paths of a simulation request:
+- Normal sampler (float64 and float32)
+- Summary statistics (mean, std, skew, kurtosis)
+- Percentiles (full sort vs selection)
+- JSON serialization of a result payload
+- End-to-end European option pricing
+
+Each case reports iterations/sec and iterations/sec per core. Results can
+be saved as a baseline and later checked against it, failing (exit code 1)
+when any case slows down by more than the tolerance.
+
+Usage:
+    python benchmarks/regression_suite.py                      # print results
+    python benchmarks/regression_suite.py --save-baseline      # record baseline
+    python benchmarks/regression_suite.py --check --tolerance 0.2
+"""
+
+import sys
+import os
+import argparse
+import json
+import time
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import numpy as np
+from scipy import stats
+from pricing.monte_carlo import MonteCarloEngine, select_percentiles
+
+BASELINE_PATH = os.path.join(project_root, 'benchmarks', 'baseline.json')
+N_SAMPLES = 1_000_000
+PERCENTILES = [5, 25, 50, 75, 95]
+
+
+def _cases():
+    """Benchmark cases as (name, setup, run) with setup returning run's argument."""
+    rng = np.random.default_rng(42)
+
+    def sample_float64(_):
+        return rng.standard_normal(N_SAMPLES)
+
+    def sample_float32(_):
+        return rng.standard_normal(N_SAMPLES, dtype=np.float32)
+
+    def statistics(x):
+        return np.mean(x), np.std(x), stats.skew(x), stats.kurtosis(x)
+
+    def percentiles_sort(x):
+        return np.percentile(x, PERCENTILES)
+
+    def percentiles_select(x):
+        return select_percentiles(x, PERCENTILES)
+
+    def serialize(payload):
+        return json.dumps(payload)
+
+    def price_option(_):
+        mc = MonteCarloEngine(n_paths=N_SAMPLES, n_steps=1, variance_reduction='antithetic', seed=42)
+        return mc.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+
+    samples = lambda: rng.standard_normal(N_SAMPLES)
+    payload = lambda: {
+        'price': 10.45,
+        'convergence': [{'n_paths': n, 'price': 10.4, 'time_ms': 1.2} for n in range(1000)],
+        'paths': rng.standard_normal((100, 252)).tolist(),
+    }
+
+    return [
+        ('sampler_float64_1m', lambda: None, sample_float64),
+        ('sampler_float32_1m', lambda: None, sample_float32),
+        ('statistics_1m', samples, statistics),
+        ('percentiles_sort_1m', samples, percentiles_sort),
+        ('percentiles_select_1m', samples, percentiles_select),
+        ('json_serialize_result', payload, serialize),
+        ('price_european_1m', lambda: None, price_option),
+    ]
+
+
+def run_case(setup, run, min_time: float = 0.5, min_iterations: int = 3):
+    """Time run(setup()) until min_time has elapsed; returns iterations/sec."""
+    arg = setup()
+    run(arg)  # Warm up
+
+    iterations = 0
+    start = time.perf_counter()
+    while True:
+        run(arg)
+        iterations += 1
+        elapsed = time.perf_counter() - start
+        if elapsed >= min_time and iterations >= min_iterations:
+            return iterations / elapsed
+
+
+def run_suite(min_time: float = 0.5):
+    """Run every case and return {name: {'ops_per_sec', 'ops_per_sec_per_core'}}."""
+    cores = os.cpu_count() or 1
+    results = {}
+    for name, setup, run in _cases():
+        ops = run_case(setup, run, min_time=min_time)
+        results[name] = {'ops_per_sec': ops, 'ops_per_sec_per_core': ops / cores}
+    return results
+
+
+def compare(results, baseline, tolerance):
+    """Cases slower than baseline by more than tolerance."""
+    regressions = []
+    for name, result in results.items():
+        if name not in baseline:
+            continue
+        ratio = result['ops_per_sec'] / baseline[name]['ops_per_sec']
+        if ratio < 1 - tolerance:
+            regressions.append((name, ratio))
+    return regressions
+
+
+def main():
+    parser = argparse.ArgumentParser(description='Run the performance regression suite')
+    parser.add_argument('--min-time', type=float, default=0.5, help='Seconds to run each case')
+    parser.add_argument('--save-baseline', action='store_true', help='Save results as the baseline')
+    parser.add_argument('--check', action='store_true', help='Fail if slower than the baseline')
+    parser.add_argument('--tolerance', type=float, default=0.2, help='Allowed slowdown (fraction)')
+    args = parser.parse_args()
+
+    results = run_suite(min_time=args.min_time)
+
+    print('=' * 80)
+    print(f'PERFORMANCE REGRESSION SUITE ({os.cpu_count()} cores)')
+    print('=' * 80)
+    print(f'{"Case":<28} {"ops/sec":>14} {"ops/sec/core":>14}')
+    print('-' * 80)
+    for name, result in results.items():
+        print(f'{name:<28} {result["ops_per_sec"]:>14.2f} {result["ops_per_sec_per_core"]:>14.2f}')
+    print()
+
+    if args.save_baseline:
+        with open(BASELINE_PATH, 'w') as f:
+            json.dump(results, f, indent=2)
+        print(f'Baseline saved to {BASELINE_PATH}')
+
+    if args.check:
+        if not os.path.exists(BASELINE_PATH):
+            print('No baseline found; run with --save-baseline first', file=sys.stderr)
+            sys.exit(1)
+        with open(BASELINE_PATH) as f:
+            baseline = json.load(f)
+
+        regressions = compare(results, baseline, args.tolerance)
+        if regressions:
+            for name, ratio in regressions:
+                print(f'❌ {name}: {ratio:.2f}x baseline throughput')
+            sys.exit(1)
+        print(f'✅ No case slower than baseline by more than {args.tolerance:.0%}')
+
+
+if __name__ == '__main__':
+    main()
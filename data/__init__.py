@@ -0,0 +1,4 @@
+"""Data generation and storage helpers."""
+from .synthetic import SyntheticPortfolioConfig, SyntheticPortfolioGenerator
+
+__all__ = ['SyntheticPortfolioConfig', 'SyntheticPortfolioGenerator']
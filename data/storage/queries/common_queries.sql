@@ -210,3 +210,31 @@ FROM cash_flows cf
 JOIN portfolio_data p ON cf.fund_id = p.fund_id
 ORDER BY cf.flow_date DESC
 LIMIT 50;
+
+-- 16. Manager Concentration
+SELECT
+    manager_name,
+    num_funds,
+    ROUND(total_committed / 1000000.0, 2) as committed_mm,
+    ROUND(total_nav / 1000000.0, 2) as nav_mm,
+    ROUND(commitment_weighted_irr * 100, 2) as weighted_irr_pct,
+    ROUND(avg_tvpi, 2) as avg_tvpi,
+    ROUND(commitment_share * 100, 2) as commitment_share_pct,
+    ROUND(nav_share * 100, 2) as nav_share_pct
+FROM vw_manager_summary
+WHERE num_funds > 0
+ORDER BY commitment_share DESC;
+
+-- 17. Top Value-Creating Portfolio Companies
+SELECT
+    company_name,
+    sector,
+    geography,
+    COUNT(DISTINCT fund_id) as num_funds,
+    ROUND(SUM(invested_amount) / 1000000.0, 2) as invested_mm,
+    ROUND(SUM(value_created) / 1000000.0, 2) as value_created_mm,
+    ROUND(SUM(realized_amount + current_valuation) / NULLIF(SUM(invested_amount), 0), 2) as gross_moic
+FROM vw_company_investments
+GROUP BY company_name, sector, geography
+ORDER BY SUM(value_created) DESC
+LIMIT 20;
@@ -1,12 +1,26 @@
 -- Helios Quant Framework - Database Schema
 -- PostgreSQL schema for portfolio, market, and analytics data
+-- Every statement is idempotent: re-applying the file upgrades an existing database in place.
 
 -- Enable extensions
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
+-- Managers (general partners) table
+CREATE TABLE IF NOT EXISTS managers (
+    manager_id SERIAL PRIMARY KEY,
+    manager_name VARCHAR(255) NOT NULL UNIQUE,
+    headquarters VARCHAR(100),
+    primary_strategy VARCHAR(100),
+    founded_year INT,
+    aum NUMERIC(18, 2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Portfolio data table
 CREATE TABLE IF NOT EXISTS portfolio_data (
     fund_id SERIAL PRIMARY KEY,
+    manager_id INT REFERENCES managers(manager_id) ON DELETE SET NULL,
     fund_name VARCHAR(255) NOT NULL,
     vintage INT NOT NULL,
     sector VARCHAR(100) NOT NULL,
@@ -34,6 +48,55 @@ CREATE TABLE IF NOT EXISTS portfolio_data (
     CONSTRAINT valid_status CHECK (status IN ('Active', 'Realized', 'Written-Off'))
 );
 
+-- Fund tags (free-form key/value classifications beyond sector and vintage)
+CREATE TABLE IF NOT EXISTS fund_tags (
+    fund_id INT NOT NULL REFERENCES portfolio_data(fund_id) ON DELETE CASCADE,
+    tag_key VARCHAR(50) NOT NULL,
+    tag_value VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (fund_id, tag_key)
+);
+
+-- Portfolio groups (a hierarchy of nodes, e.g. Private Equity > Buyout > US Buyout)
+CREATE TABLE IF NOT EXISTS portfolio_groups (
+    group_id SERIAL PRIMARY KEY,
+    group_name VARCHAR(100) NOT NULL,
+    parent_id INT REFERENCES portfolio_groups(group_id) ON DELETE RESTRICT,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_group_parent CHECK (parent_id IS NULL OR parent_id <> group_id)
+);
+
+-- Fund assignments to portfolio group nodes (one node per fund)
+CREATE TABLE IF NOT EXISTS portfolio_group_funds (
+    fund_id INT PRIMARY KEY REFERENCES portfolio_data(fund_id) ON DELETE CASCADE,
+    group_id INT NOT NULL REFERENCES portfolio_groups(group_id) ON DELETE CASCADE,
+    assigned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Portfolios (separate mandates, e.g. a pension plan and an endowment); a fund may sit in several
+CREATE TABLE IF NOT EXISTS portfolios (
+    portfolio_id SERIAL PRIMARY KEY,
+    portfolio_name VARCHAR(100) NOT NULL UNIQUE,
+    mandate VARCHAR(100),
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Fund membership of portfolios (a shared fund has one row per portfolio)
+CREATE TABLE IF NOT EXISTS portfolio_funds (
+    portfolio_id INT NOT NULL REFERENCES portfolios(portfolio_id) ON DELETE CASCADE,
+    fund_id INT NOT NULL REFERENCES portfolio_data(fund_id) ON DELETE CASCADE,
+    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (portfolio_id, fund_id)
+);
+
 -- Cash flows table
 CREATE TABLE IF NOT EXISTS cash_flows (
     cash_flow_id SERIAL PRIMARY KEY,
@@ -47,6 +110,121 @@ CREATE TABLE IF NOT EXISTS cash_flows (
     CONSTRAINT valid_flow_type CHECK (flow_type IN ('Capital Call', 'Distribution', 'Dividend', 'Interest', 'Fee', 'Other'))
 );
 
+-- Portfolio companies table (underlying companies, may be held by several funds)
+CREATE TABLE IF NOT EXISTS portfolio_companies (
+    company_id SERIAL PRIMARY KEY,
+    company_name VARCHAR(255) NOT NULL UNIQUE,
+    sector VARCHAR(100) NOT NULL,
+    geography VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Company investments table (fund positions in portfolio companies)
+CREATE TABLE IF NOT EXISTS company_investments (
+    investment_id SERIAL PRIMARY KEY,
+    fund_id INT NOT NULL REFERENCES portfolio_data(fund_id) ON DELETE CASCADE,
+    company_id INT NOT NULL REFERENCES portfolio_companies(company_id) ON DELETE CASCADE,
+    entry_date DATE NOT NULL,
+    exit_date DATE,
+    invested_amount NUMERIC(15, 2) NOT NULL,
+    realized_amount NUMERIC(15, 2) DEFAULT 0,
+    current_valuation NUMERIC(15, 2) DEFAULT 0,
+    ownership_pct NUMERIC(8, 4),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(fund_id, company_id),
+    CONSTRAINT valid_exit_date CHECK (exit_date IS NULL OR exit_date >= entry_date)
+);
+
+-- Cash reconciliation breaks between bank/custodian activity and recorded cash flows
+CREATE TABLE IF NOT EXISTS reconciliation_breaks (
+    break_id SERIAL PRIMARY KEY,
+    break_type VARCHAR(20) NOT NULL,
+    bank_transaction_id VARCHAR(100),
+    cash_flow_id INT REFERENCES cash_flows(cash_flow_id) ON DELETE SET NULL,
+    break_date DATE NOT NULL,
+    bank_amount NUMERIC(15, 2),
+    record_amount NUMERIC(15, 2),
+    reference TEXT,
+    status VARCHAR(20) DEFAULT 'Open',
+    resolution TEXT,
+    resolved_by VARCHAR(100),
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_break_type CHECK (break_type IN ('amount_mismatch', 'unmatched_bank', 'unmatched_record')),
+    CONSTRAINT valid_break_status CHECK (status IN ('Open', 'Resolved', 'Ignored'))
+);
+
+-- Bank/custodian transactions matched to recorded cash flows by reconciliation
+CREATE TABLE IF NOT EXISTS reconciliation_matches (
+    match_id SERIAL PRIMARY KEY,
+    bank_transaction_id VARCHAR(100) NOT NULL,
+    cash_flow_id INT NOT NULL REFERENCES cash_flows(cash_flow_id) ON DELETE CASCADE,
+    match_date DATE NOT NULL,
+    bank_amount NUMERIC(15, 2) NOT NULL,
+    record_amount NUMERIC(15, 2) NOT NULL,
+    score NUMERIC(6, 4) NOT NULL,
+    reference_similarity NUMERIC(6, 4),
+    matched_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(bank_transaction_id),
+    UNIQUE(cash_flow_id)
+);
+
+-- Closed reporting periods (quarter ends). Cash flows dated on or before the latest
+-- closed period end, and the NAVs reported for closed periods, only change through
+-- an adjustment (scripts/periods_api.py), which is recorded in period_adjustments
+CREATE TABLE IF NOT EXISTS reporting_periods (
+    period_end DATE PRIMARY KEY,
+    closed_by VARCHAR(100) NOT NULL,
+    closed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_period_end CHECK (period_end = (date_trunc('quarter', period_end) + INTERVAL '3 months' - INTERVAL '1 day')::date)
+);
+
+-- Fund NAVs as reported for each closed period
+CREATE TABLE IF NOT EXISTS period_navs (
+    fund_id INT NOT NULL REFERENCES portfolio_data(fund_id),
+    period_end DATE NOT NULL REFERENCES reporting_periods(period_end) ON DELETE CASCADE,
+    nav NUMERIC(15, 2),
+
+    PRIMARY KEY (fund_id, period_end)
+);
+
+-- Audit trail of changes made to closed periods
+CREATE TABLE IF NOT EXISTS period_adjustments (
+    adjustment_id SERIAL PRIMARY KEY,
+    period_end DATE NOT NULL REFERENCES reporting_periods(period_end) ON DELETE CASCADE,
+    target VARCHAR(20) NOT NULL,
+    operation VARCHAR(10) NOT NULL,
+    fund_id INT,
+    cash_flow_id INT,
+    old_values JSONB,
+    new_values JSONB,
+    reason TEXT NOT NULL,
+    adjusted_by VARCHAR(100) NOT NULL,
+    adjusted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_adjustment_target CHECK (target IN ('cash_flow', 'nav')),
+    CONSTRAINT valid_adjustment_operation CHECK (operation IN ('insert', 'update', 'delete'))
+);
+
+-- Change feed: one row per insert, update or delete of a fund, fund tag, cash flow or
+-- reported NAV (and one per TRUNCATE), in commit order, for incremental sync (scripts/changes_api.py)
+CREATE TABLE IF NOT EXISTS data_changes (
+    change_id BIGSERIAL PRIMARY KEY,
+    table_name VARCHAR(50) NOT NULL,
+    operation VARCHAR(10) NOT NULL,
+    row_key JSONB,
+    row_data JSONB,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_change_operation CHECK (operation IN ('insert', 'update', 'delete', 'truncate'))
+);
+
 -- Market data table
 CREATE TABLE IF NOT EXISTS market_data (
     market_data_id SERIAL PRIMARY KEY,
@@ -75,6 +253,201 @@ CREATE TABLE IF NOT EXISTS benchmark_data (
     UNIQUE(benchmark_name, date)
 );
 
+-- Risk-free / discount yield curves (one row per curve, date and tenor)
+CREATE TABLE IF NOT EXISTS yield_curves (
+    curve_point_id SERIAL PRIMARY KEY,
+    curve_name VARCHAR(50) NOT NULL DEFAULT 'UST',
+    curve_date DATE NOT NULL,
+    tenor_years NUMERIC(6, 3) NOT NULL,
+    zero_rate NUMERIC(10, 6) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(curve_name, curve_date, tenor_years),
+    CONSTRAINT positive_tenor CHECK (tenor_years > 0)
+);
+
+-- Price index (CPI) series used to restate results in real terms
+CREATE TABLE IF NOT EXISTS cpi_data (
+    cpi_id SERIAL PRIMARY KEY,
+    series_name VARCHAR(50) NOT NULL DEFAULT 'CPI-U',
+    date DATE NOT NULL,
+    index_level NUMERIC(12, 4) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(series_name, date),
+    CONSTRAINT positive_index_level CHECK (index_level > 0)
+);
+
+-- Benchmark composites (blended benchmarks, materialized into benchmark_data)
+CREATE TABLE IF NOT EXISTS benchmark_composites (
+    composite_id SERIAL PRIMARY KEY,
+    composite_name VARCHAR(100) NOT NULL UNIQUE,
+    components JSONB NOT NULL,
+    spread_bps NUMERIC(8, 2) DEFAULT 0,
+    rebalance_frequency VARCHAR(20) DEFAULT 'Monthly',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_rebalance_frequency CHECK (rebalance_frequency IN ('Monthly'))
+);
+
+-- Peer universe quartile breakpoints (uploaded or synced from a data provider)
+CREATE TABLE IF NOT EXISTS peer_benchmarks (
+    peer_benchmark_id SERIAL PRIMARY KEY,
+    source VARCHAR(100) NOT NULL,
+    vintage INT NOT NULL,
+    strategy VARCHAR(100) NOT NULL DEFAULT 'All',
+    metric VARCHAR(10) NOT NULL,
+    upper_quartile NUMERIC(8, 4) NOT NULL,
+    median NUMERIC(8, 4) NOT NULL,
+    lower_quartile NUMERIC(8, 4) NOT NULL,
+    fund_count INT,
+    as_of_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(source, vintage, strategy, metric, as_of_date),
+    CONSTRAINT valid_peer_metric CHECK (metric IN ('IRR', 'TVPI')),
+    CONSTRAINT ordered_quartiles CHECK (lower_quartile <= median AND median <= upper_quartile)
+);
+
+-- Target allocation weights by dimension (sector, strategy)
+CREATE TABLE IF NOT EXISTS allocation_targets (
+    allocation_target_id SERIAL PRIMARY KEY,
+    dimension VARCHAR(20) NOT NULL,
+    bucket VARCHAR(100) NOT NULL,
+    target_weight NUMERIC(6, 4) NOT NULL,
+    drift_threshold NUMERIC(6, 4),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(dimension, bucket),
+    CONSTRAINT valid_allocation_dimension CHECK (dimension IN ('sector', 'strategy')),
+    CONSTRAINT valid_target_weight CHECK (target_weight BETWEEN 0 AND 1)
+);
+
+-- Allocation drift alerts (one open row per bucket and direction until it is back within threshold)
+CREATE TABLE IF NOT EXISTS allocation_drift_alerts (
+    alert_id SERIAL PRIMARY KEY,
+    dimension VARCHAR(20) NOT NULL,
+    bucket VARCHAR(100) NOT NULL,
+    direction VARCHAR(12) NOT NULL,
+    observed_drift NUMERIC(12, 6) NOT NULL,
+    threshold_value NUMERIC(6, 4) NOT NULL,
+    suggested_trade NUMERIC(20, 2),
+    evaluation_trigger VARCHAR(20) NOT NULL,
+    detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+
+    CONSTRAINT valid_drift_dimension CHECK (dimension IN ('sector', 'strategy')),
+    CONSTRAINT valid_drift_direction CHECK (direction IN ('overweight', 'underweight')),
+    CONSTRAINT valid_drift_trigger CHECK (evaluation_trigger IN ('data_update', 'scheduled', 'manual'))
+);
+
+-- Risk limits table
+CREATE TABLE IF NOT EXISTS risk_limits (
+    risk_limit_id SERIAL PRIMARY KEY,
+    metric VARCHAR(50) NOT NULL UNIQUE,
+    limit_value NUMERIC(10, 6) NOT NULL,
+    warning_value NUMERIC(10, 6),
+    active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_risk_limit_metric CHECK (metric IN ('portfolio_var', 'portfolio_volatility', 'max_fund_weight', 'max_sector_weight', 'hhi')),
+    CONSTRAINT valid_warning_value CHECK (warning_value IS NULL OR warning_value <= limit_value)
+);
+
+-- Risk limit breaches table
+CREATE TABLE IF NOT EXISTS risk_limit_breaches (
+    breach_id SERIAL PRIMARY KEY,
+    risk_limit_id INT NOT NULL REFERENCES risk_limits(risk_limit_id) ON DELETE CASCADE,
+    metric VARCHAR(50) NOT NULL,
+    severity VARCHAR(10) NOT NULL,
+    observed_value NUMERIC(12, 6) NOT NULL,
+    threshold_value NUMERIC(10, 6) NOT NULL,
+    evaluation_trigger VARCHAR(20) NOT NULL,
+    detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_by VARCHAR(100),
+    acknowledged_at TIMESTAMP,
+    acknowledgment_note TEXT,
+    resolved_at TIMESTAMP,
+    superseded_by INT REFERENCES risk_limit_breaches(breach_id) ON DELETE SET NULL,
+
+    CONSTRAINT valid_breach_severity CHECK (severity IN ('warning', 'breach')),
+    CONSTRAINT valid_evaluation_trigger CHECK (evaluation_trigger IN ('data_update', 'scheduled', 'manual'))
+);
+
+-- Compliance rules table (evaluated against active fund positions)
+CREATE TABLE IF NOT EXISTS compliance_rules (
+    compliance_rule_id SERIAL PRIMARY KEY,
+    rule_name VARCHAR(100) NOT NULL UNIQUE,
+    rule_type VARCHAR(30) NOT NULL,
+    limit_value NUMERIC(12, 6) NOT NULL,
+    attribute VARCHAR(50),
+    active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_compliance_rule_type CHECK (rule_type IN ('max_group_weight', 'max_position_weight', 'min_holdings', 'min_groups')),
+    CONSTRAINT valid_compliance_attribute CHECK (attribute IS NULL OR attribute IN ('sector', 'manager', 'vintage', 'currency')),
+    CONSTRAINT valid_compliance_limit CHECK (limit_value >= 0)
+);
+
+-- Compliance violations table (one open row per rule and group until it passes again)
+CREATE TABLE IF NOT EXISTS compliance_violations (
+    violation_id SERIAL PRIMARY KEY,
+    compliance_rule_id INT NOT NULL REFERENCES compliance_rules(compliance_rule_id) ON DELETE CASCADE,
+    rule_name VARCHAR(100) NOT NULL,
+    group_value VARCHAR(255) NOT NULL DEFAULT '',
+    observed_value NUMERIC(12, 6) NOT NULL,
+    limit_value NUMERIC(12, 6) NOT NULL,
+    evaluation_trigger VARCHAR(20) NOT NULL,
+    detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+
+    CONSTRAINT valid_compliance_trigger CHECK (evaluation_trigger IN ('data_update', 'scheduled', 'manual'))
+);
+
+-- Named, versioned assumption sets (versions are immutable; saving a name again adds a version)
+CREATE TABLE IF NOT EXISTS assumption_sets (
+    assumption_set_id SERIAL PRIMARY KEY,
+    set_name VARCHAR(100) NOT NULL,
+    version INT NOT NULL,
+    description TEXT,
+    parameters JSONB NOT NULL,
+    created_by VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(set_name, version),
+    CONSTRAINT positive_assumption_version CHECK (version > 0)
+);
+
+-- Model registry: calibrated model parameters with their data window and provenance
+CREATE TABLE IF NOT EXISTS model_registry (
+    model_id SERIAL PRIMARY KEY,
+    model_name VARCHAR(100) NOT NULL,
+    model_type VARCHAR(30) NOT NULL,
+    version INT NOT NULL,
+    parameters JSONB NOT NULL,
+    fit_statistics JSONB,
+    data_source VARCHAR(100),
+    data_start DATE,
+    data_end DATE,
+    n_observations INT,
+    frequency INT,
+    author VARCHAR(100),
+    notes TEXT,
+    calibrated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE(model_name, version),
+    CONSTRAINT valid_model_type CHECK (model_type IN ('normal', 'lognormal', 'student_t', 'garch', 'regime_switching', 'factor_loadings')),
+    CONSTRAINT valid_model_data_window CHECK (data_start IS NULL OR data_end IS NULL OR data_start <= data_end),
+    CONSTRAINT positive_model_frequency CHECK (frequency IS NULL OR frequency > 0)
+);
+
 -- Risk metrics table
 CREATE TABLE IF NOT EXISTS risk_metrics (
     risk_metric_id SERIAL PRIMARY KEY,
@@ -103,10 +476,21 @@ CREATE TABLE IF NOT EXISTS ml_predictions (
     confidence_lower NUMERIC(8, 4),
     confidence_upper NUMERIC(8, 4),
     model_version VARCHAR(50),
+    target_date DATE,
     features JSONB,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Realized fund IRR history (actuals for scoring forecasts)
+CREATE TABLE IF NOT EXISTS fund_irr_history (
+    fund_id INT NOT NULL REFERENCES portfolio_data(fund_id) ON DELETE CASCADE,
+    as_of_date DATE NOT NULL,
+    irr NUMERIC(8, 4) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (fund_id, as_of_date)
+);
+
 -- Simulation results table
 CREATE TABLE IF NOT EXISTS simulation_results (
     simulation_id SERIAL PRIMARY KEY,
@@ -160,16 +544,43 @@ CREATE TABLE IF NOT EXISTS analytics_jobs (
     CONSTRAINT valid_job_type CHECK (job_type IN ('R-Analysis', 'R-Optimization', 'R-Risk', 'Python-ML', 'Python-QuantLib', 'Go-Simulation'))
 );
 
+-- Upgrade tables created by earlier versions of this schema (no-ops on a new database).
+-- New tables need nothing here: CREATE TABLE IF NOT EXISTS above adds them.
+ALTER TABLE portfolio_data ADD COLUMN IF NOT EXISTS manager_id INT REFERENCES managers(manager_id) ON DELETE SET NULL;
+ALTER TABLE ml_predictions ADD COLUMN IF NOT EXISTS target_date DATE;
+ALTER TABLE risk_limit_breaches ADD COLUMN IF NOT EXISTS resolved_at TIMESTAMP;
+ALTER TABLE risk_limit_breaches ADD COLUMN IF NOT EXISTS superseded_by INT REFERENCES risk_limit_breaches(breach_id) ON DELETE SET NULL;
+
 -- Create indexes for performance
-CREATE INDEX idx_portfolio_vintage ON portfolio_data(vintage);
-CREATE INDEX idx_portfolio_sector ON portfolio_data(sector);
-CREATE INDEX idx_portfolio_status ON portfolio_data(status);
-CREATE INDEX idx_cash_flows_fund_date ON cash_flows(fund_id, flow_date);
-CREATE INDEX idx_market_data_ticker_date ON market_data(ticker, date);
-CREATE INDEX idx_benchmark_name_date ON benchmark_data(benchmark_name, date);
-CREATE INDEX idx_risk_metrics_fund_date ON risk_metrics(fund_id, metric_date);
-CREATE INDEX idx_ml_predictions_fund_date ON ml_predictions(fund_id, prediction_date);
-CREATE INDEX idx_analytics_jobs_status ON analytics_jobs(status);
+CREATE INDEX IF NOT EXISTS idx_portfolio_vintage ON portfolio_data(vintage);
+CREATE INDEX IF NOT EXISTS idx_portfolio_sector ON portfolio_data(sector);
+CREATE INDEX IF NOT EXISTS idx_portfolio_status ON portfolio_data(status);
+CREATE INDEX IF NOT EXISTS idx_portfolio_manager ON portfolio_data(manager_id);
+CREATE INDEX IF NOT EXISTS idx_fund_tags_key_value ON fund_tags(tag_key, tag_value);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_portfolio_groups_parent_name ON portfolio_groups(COALESCE(parent_id, 0), group_name);
+CREATE INDEX IF NOT EXISTS idx_portfolio_group_funds_group ON portfolio_group_funds(group_id);
+CREATE INDEX IF NOT EXISTS idx_portfolio_funds_fund ON portfolio_funds(fund_id);
+CREATE INDEX IF NOT EXISTS idx_cash_flows_fund_date ON cash_flows(fund_id, flow_date);
+CREATE INDEX IF NOT EXISTS idx_period_navs_period ON period_navs(period_end);
+CREATE INDEX IF NOT EXISTS idx_period_adjustments_period ON period_adjustments(period_end, adjusted_at);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_breaks_status ON reconciliation_breaks(status, break_date);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_matches_date ON reconciliation_matches(match_date);
+CREATE INDEX IF NOT EXISTS idx_company_investments_fund ON company_investments(fund_id);
+CREATE INDEX IF NOT EXISTS idx_company_investments_company ON company_investments(company_id);
+CREATE INDEX IF NOT EXISTS idx_portfolio_companies_sector ON portfolio_companies(sector);
+CREATE INDEX IF NOT EXISTS idx_market_data_ticker_date ON market_data(ticker, date);
+CREATE INDEX IF NOT EXISTS idx_benchmark_name_date ON benchmark_data(benchmark_name, date);
+CREATE INDEX IF NOT EXISTS idx_peer_benchmarks_vintage ON peer_benchmarks(vintage, strategy, metric);
+CREATE INDEX IF NOT EXISTS idx_yield_curves_name_date ON yield_curves(curve_name, curve_date);
+CREATE INDEX IF NOT EXISTS idx_cpi_series_date ON cpi_data(series_name, date);
+CREATE INDEX IF NOT EXISTS idx_risk_limit_breaches_limit ON risk_limit_breaches(risk_limit_id, acknowledged_at);
+CREATE INDEX IF NOT EXISTS idx_risk_limit_breaches_active ON risk_limit_breaches(risk_limit_id, resolved_at);
+CREATE INDEX IF NOT EXISTS idx_compliance_violations_rule ON compliance_violations(compliance_rule_id, resolved_at);
+CREATE INDEX IF NOT EXISTS idx_allocation_drift_alerts_bucket ON allocation_drift_alerts(dimension, bucket, resolved_at);
+CREATE INDEX IF NOT EXISTS idx_model_registry_source ON model_registry(data_source, model_type);
+CREATE INDEX IF NOT EXISTS idx_risk_metrics_fund_date ON risk_metrics(fund_id, metric_date);
+CREATE INDEX IF NOT EXISTS idx_ml_predictions_fund_date ON ml_predictions(fund_id, prediction_date);
+CREATE INDEX IF NOT EXISTS idx_analytics_jobs_status ON analytics_jobs(status);
 
 -- Create views for common queries
 
@@ -210,6 +621,45 @@ FROM portfolio_data
 WHERE status = 'Active'
 GROUP BY sector;
 
+-- Manager exposure and performance view
+CREATE OR REPLACE VIEW vw_manager_summary AS
+SELECT
+    m.manager_id,
+    m.manager_name,
+    COUNT(p.fund_id) as num_funds,
+    SUM(p.committed_capital) as total_committed,
+    SUM(p.invested_capital) as total_invested,
+    SUM(p.current_nav) as total_nav,
+    SUM(p.irr * p.committed_capital) / NULLIF(SUM(p.committed_capital), 0) as commitment_weighted_irr,
+    AVG(p.tvpi) as avg_tvpi,
+    SUM(p.committed_capital) / NULLIF(SUM(SUM(p.committed_capital)) OVER (), 0) as commitment_share,
+    SUM(p.current_nav) / NULLIF(SUM(SUM(p.current_nav)) OVER (), 0) as nav_share
+FROM managers m
+LEFT JOIN portfolio_data p ON m.manager_id = p.manager_id
+GROUP BY m.manager_id, m.manager_name;
+
+-- Company-level investment attribution view
+CREATE OR REPLACE VIEW vw_company_investments AS
+SELECT
+    ci.investment_id,
+    p.fund_id,
+    p.fund_name,
+    c.company_id,
+    c.company_name,
+    c.sector,
+    c.geography,
+    ci.entry_date,
+    ci.exit_date,
+    ci.invested_amount,
+    ci.realized_amount,
+    ci.current_valuation,
+    (ci.realized_amount + ci.current_valuation) / NULLIF(ci.invested_amount, 0) as gross_moic,
+    ci.realized_amount + ci.current_valuation - ci.invested_amount as value_created,
+    CASE WHEN ci.exit_date IS NULL THEN 'Unrealized' ELSE 'Realized' END as investment_status
+FROM company_investments ci
+JOIN portfolio_data p ON ci.fund_id = p.fund_id
+JOIN portfolio_companies c ON ci.company_id = c.company_id;
+
 -- Recent analytics jobs view
 CREATE OR REPLACE VIEW vw_recent_analytics_jobs AS
 SELECT
@@ -235,25 +685,264 @@ END;
 $$ language 'plpgsql';
 
 -- Trigger for auto-updating updated_at
+DROP TRIGGER IF EXISTS update_portfolio_data_updated_at ON portfolio_data;
 CREATE TRIGGER update_portfolio_data_updated_at
     BEFORE UPDATE ON portfolio_data
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
--- Insert sample data
+DROP TRIGGER IF EXISTS update_managers_updated_at ON managers;
+CREATE TRIGGER update_managers_updated_at
+    BEFORE UPDATE ON managers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_company_investments_updated_at ON company_investments;
+CREATE TRIGGER update_company_investments_updated_at
+    BEFORE UPDATE ON company_investments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_benchmark_composites_updated_at ON benchmark_composites;
+CREATE TRIGGER update_benchmark_composites_updated_at
+    BEFORE UPDATE ON benchmark_composites
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_allocation_targets_updated_at ON allocation_targets;
+CREATE TRIGGER update_allocation_targets_updated_at
+    BEFORE UPDATE ON allocation_targets
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_risk_limits_updated_at ON risk_limits;
+CREATE TRIGGER update_risk_limits_updated_at
+    BEFORE UPDATE ON risk_limits
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_fund_tags_updated_at ON fund_tags;
+CREATE TRIGGER update_fund_tags_updated_at
+    BEFORE UPDATE ON fund_tags
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_portfolio_groups_updated_at ON portfolio_groups;
+CREATE TRIGGER update_portfolio_groups_updated_at
+    BEFORE UPDATE ON portfolio_groups
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_portfolios_updated_at ON portfolios;
+CREATE TRIGGER update_portfolios_updated_at
+    BEFORE UPDATE ON portfolios
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_compliance_rules_updated_at ON compliance_rules;
+CREATE TRIGGER update_compliance_rules_updated_at
+    BEFORE UPDATE ON compliance_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Closed period lock: writes to locked rows fail unless the transaction has set
+-- helios.closed_period_write (closing a period or recording an adjustment)
+CREATE OR REPLACE FUNCTION closed_period_write_allowed()
+RETURNS BOOLEAN AS $$
+BEGIN
+    RETURN COALESCE(current_setting('helios.closed_period_write', true), '') = 'on';
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION enforce_cash_flow_period_lock()
+RETURNS TRIGGER AS $$
+DECLARE
+    closed_through DATE;
+BEGIN
+    IF closed_period_write_allowed() THEN
+        RETURN NULL;
+    END IF;
+    SELECT MAX(period_end) INTO closed_through FROM reporting_periods;
+    IF closed_through IS NULL THEN
+        RETURN NULL;
+    END IF;
+    IF TG_OP = 'TRUNCATE' THEN
+        RAISE EXCEPTION 'cash_flows cannot be truncated while reporting periods through % are closed', closed_through;
+    END IF;
+    IF (TG_OP IN ('UPDATE', 'DELETE') AND OLD.flow_date <= closed_through) OR
+       (TG_OP IN ('INSERT', 'UPDATE') AND NEW.flow_date <= closed_through) THEN
+        RAISE EXCEPTION 'Cash flows dated on or before % are in a closed reporting period; record an adjustment instead', closed_through;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION enforce_period_nav_lock()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NOT closed_period_write_allowed() AND EXISTS (SELECT 1 FROM reporting_periods) THEN
+        RAISE EXCEPTION 'Reported NAVs of closed periods change only through an adjustment';
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS lock_closed_cash_flows ON cash_flows;
+CREATE TRIGGER lock_closed_cash_flows
+    AFTER INSERT OR UPDATE OR DELETE ON cash_flows
+    FOR EACH ROW
+    EXECUTE FUNCTION enforce_cash_flow_period_lock();
+
+DROP TRIGGER IF EXISTS lock_closed_cash_flows_truncate ON cash_flows;
+CREATE TRIGGER lock_closed_cash_flows_truncate
+    BEFORE TRUNCATE ON cash_flows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION enforce_cash_flow_period_lock();
+
+DROP TRIGGER IF EXISTS lock_period_navs ON period_navs;
+CREATE TRIGGER lock_period_navs
+    BEFORE INSERT OR UPDATE OR DELETE OR TRUNCATE ON period_navs
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION enforce_period_nav_lock();
+
+-- Change feed capture. Writers take a transaction-level advisory lock before logging, so
+-- change_ids commit in order and a reader that has seen change_id N never later finds a
+-- smaller one; trigger arguments name the key columns of the table
+CREATE OR REPLACE FUNCTION record_data_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    row_json JSONB;
+    key_json JSONB := '{}'::jsonb;
+    key_column TEXT;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('data_changes'));
+    IF TG_OP = 'TRUNCATE' THEN
+        INSERT INTO data_changes (table_name, operation) VALUES (TG_TABLE_NAME, 'truncate');
+        RETURN NULL;
+    END IF;
+    IF TG_OP = 'DELETE' THEN
+        row_json := to_jsonb(OLD);
+    ELSE
+        row_json := to_jsonb(NEW);
+    END IF;
+    FOREACH key_column IN ARRAY TG_ARGV LOOP
+        key_json := key_json || jsonb_build_object(key_column, row_json -> key_column);
+    END LOOP;
+    INSERT INTO data_changes (table_name, operation, row_key, row_data)
+    VALUES (TG_TABLE_NAME, lower(TG_OP), key_json, CASE WHEN TG_OP = 'DELETE' THEN NULL ELSE row_json END);
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS record_portfolio_data_changes ON portfolio_data;
+CREATE TRIGGER record_portfolio_data_changes
+    AFTER INSERT OR UPDATE OR DELETE ON portfolio_data
+    FOR EACH ROW
+    EXECUTE FUNCTION record_data_change('fund_id');
+
+DROP TRIGGER IF EXISTS record_fund_tags_changes ON fund_tags;
+CREATE TRIGGER record_fund_tags_changes
+    AFTER INSERT OR UPDATE OR DELETE ON fund_tags
+    FOR EACH ROW
+    EXECUTE FUNCTION record_data_change('fund_id', 'tag_key');
+
+DROP TRIGGER IF EXISTS record_cash_flows_changes ON cash_flows;
+CREATE TRIGGER record_cash_flows_changes
+    AFTER INSERT OR UPDATE OR DELETE ON cash_flows
+    FOR EACH ROW
+    EXECUTE FUNCTION record_data_change('cash_flow_id');
+
+DROP TRIGGER IF EXISTS record_period_navs_changes ON period_navs;
+CREATE TRIGGER record_period_navs_changes
+    AFTER INSERT OR UPDATE OR DELETE ON period_navs
+    FOR EACH ROW
+    EXECUTE FUNCTION record_data_change('fund_id', 'period_end');
+
+DROP TRIGGER IF EXISTS record_truncates ON portfolio_data;
+CREATE TRIGGER record_truncates
+    AFTER TRUNCATE ON portfolio_data
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION record_data_change();
+
+DROP TRIGGER IF EXISTS record_truncates ON fund_tags;
+CREATE TRIGGER record_truncates
+    AFTER TRUNCATE ON fund_tags
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION record_data_change();
+
+DROP TRIGGER IF EXISTS record_truncates ON cash_flows;
+CREATE TRIGGER record_truncates
+    AFTER TRUNCATE ON cash_flows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION record_data_change();
+
+DROP TRIGGER IF EXISTS record_truncates ON period_navs;
+CREATE TRIGGER record_truncates
+    AFTER TRUNCATE ON period_navs
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION record_data_change();
+
+-- Start the feed of a database that predates it with its current rows (only while the feed is empty)
+INSERT INTO data_changes (table_name, operation, row_key, row_data)
+SELECT table_name, 'insert', row_key, row_data FROM (
+    SELECT 1 AS ord, 'portfolio_data' AS table_name, jsonb_build_object('fund_id', fund_id) AS row_key, to_jsonb(p) AS row_data
+    FROM portfolio_data p
+    UNION ALL
+    SELECT 2, 'fund_tags', jsonb_build_object('fund_id', fund_id, 'tag_key', tag_key), to_jsonb(t)
+    FROM fund_tags t
+    UNION ALL
+    SELECT 3, 'cash_flows', jsonb_build_object('cash_flow_id', cash_flow_id), to_jsonb(c)
+    FROM cash_flows c
+    UNION ALL
+    SELECT 4, 'period_navs', jsonb_build_object('fund_id', fund_id, 'period_end', period_end), to_jsonb(n)
+    FROM period_navs n
+) AS existing
+WHERE NOT EXISTS (SELECT 1 FROM data_changes)
+ORDER BY ord, row_key;
+
+-- Insert sample data (only into an empty portfolio)
 INSERT INTO portfolio_data (fund_name, vintage, sector, committed_capital, invested_capital, current_nav, irr, moic, tvpi, dpi, benchmark_return, volatility, status)
-VALUES
+SELECT * FROM (VALUES
     ('Tech Growth Fund I', 2018, 'Technology', 100000000, 95000000, 180000000, 0.2450, 1.89, 1.95, 0.50, 0.1200, 0.2800, 'Active'),
     ('Healthcare Ventures II', 2019, 'Healthcare', 75000000, 72000000, 115000000, 0.1850, 1.60, 1.72, 0.35, 0.0950, 0.2200, 'Active'),
     ('Energy Transition Fund', 2020, 'Energy', 150000000, 130000000, 195000000, 0.1650, 1.50, 1.63, 0.28, 0.0850, 0.3200, 'Active'),
     ('Consumer Brand Partners', 2017, 'Consumer', 50000000, 50000000, 92000000, 0.2150, 1.84, 2.10, 0.68, 0.1100, 0.2500, 'Active'),
-    ('Fintech Innovation Fund', 2021, 'Finance', 200000000, 150000000, 210000000, 0.1250, 1.40, 1.48, 0.18, 0.1000, 0.3500, 'Active');
+    ('Fintech Innovation Fund', 2021, 'Finance', 200000000, 150000000, 210000000, 0.1250, 1.40, 1.48, 0.18, 0.1000, 0.3500, 'Active')
+) AS sample
+WHERE NOT EXISTS (SELECT 1 FROM portfolio_data);
 
+COMMENT ON TABLE managers IS 'General partners managing the funds in the portfolio';
 COMMENT ON TABLE portfolio_data IS 'Core portfolio fund data with performance metrics';
+COMMENT ON TABLE fund_tags IS 'Client-defined key/value tags per fund, used to filter list endpoints and aggregate funds';
+COMMENT ON TABLE portfolio_groups IS 'Hierarchical portfolio grouping nodes; analytics accept a group_id and cover the funds in its subtree';
+COMMENT ON TABLE portfolio_group_funds IS 'Assignment of each fund to at most one portfolio group node';
+COMMENT ON TABLE portfolios IS 'Portfolios (mandates) maintained side by side; analytics accept a portfolio_id and cover its funds';
+COMMENT ON TABLE portfolio_funds IS 'Fund membership of portfolios; a fund shared between portfolios appears once per portfolio';
 COMMENT ON TABLE cash_flows IS 'Cash flow transactions for each fund';
+COMMENT ON TABLE portfolio_companies IS 'Underlying portfolio companies held by one or more funds';
+COMMENT ON TABLE company_investments IS 'Fund-level positions in portfolio companies';
+COMMENT ON TABLE reconciliation_breaks IS 'Open and resolved cash reconciliation breaks';
+COMMENT ON TABLE reconciliation_matches IS 'Bank transactions matched to recorded cash flows by the latest reconciliation';
+COMMENT ON TABLE reporting_periods IS 'Closed quarter ends; cash flows and reported NAVs up to the latest one change only through adjustments';
+COMMENT ON TABLE period_navs IS 'Fund NAVs as reported for each closed period';
+COMMENT ON TABLE period_adjustments IS 'Audit trail of adjustments to closed periods, with old and new values, reason and author';
+COMMENT ON TABLE data_changes IS 'Ordered change feed of funds, fund tags, cash flows and reported NAVs; change_id is the sync cursor';
 COMMENT ON TABLE market_data IS 'Historical market price data for benchmarking';
+COMMENT ON TABLE yield_curves IS 'Dated zero-coupon risk-free curves used for Sharpe, alpha and discounting';
+COMMENT ON TABLE cpi_data IS 'Consumer price index levels for inflation-adjusted reporting';
+COMMENT ON TABLE benchmark_composites IS 'Blended benchmark definitions; series are stored in benchmark_data under composite_name';
+COMMENT ON TABLE peer_benchmarks IS 'Peer universe quartile breakpoints by vintage, strategy and metric';
+COMMENT ON TABLE allocation_targets IS 'Target portfolio weights and drift thresholds per sector or strategy';
+COMMENT ON TABLE allocation_drift_alerts IS 'Allocation drift beyond threshold per bucket, with detection time and resolution once back within threshold';
+COMMENT ON TABLE risk_limits IS 'Portfolio risk limits (VaR, volatility, concentration) with optional warning levels';
+COMMENT ON TABLE risk_limit_breaches IS 'Risk limit warnings and breaches with detection, acknowledgment and resolution times';
+COMMENT ON TABLE compliance_rules IS 'Persisted portfolio construction rules (group and position weight caps, minimum holdings and groups)';
+COMMENT ON TABLE compliance_violations IS 'Compliance rule violations with detection time and resolution once the rule passes again';
+COMMENT ON TABLE assumption_sets IS 'Shared expected return, volatility, correlation and pacing assumptions, referenced by assumption_set_id in simulation and optimization requests';
+COMMENT ON TABLE model_registry IS 'Versioned calibrated model parameters (distribution and GARCH fits, regime transition matrices, factor loadings) read by simulations via model_id';
 COMMENT ON TABLE risk_metrics IS 'Calculated risk metrics per fund';
-COMMENT ON TABLE ml_predictions IS 'Machine learning model predictions';
+COMMENT ON TABLE ml_predictions IS 'Machine learning model predictions (every version is kept; target_date is the date the forecast refers to)';
+COMMENT ON TABLE fund_irr_history IS 'Realized fund IRR by date, used to score forecasts';
 COMMENT ON TABLE simulation_results IS 'Monte Carlo and scenario analysis results';
 COMMENT ON TABLE optimization_results IS 'Portfolio optimization results from R models';
 COMMENT ON TABLE analytics_jobs IS 'Tracking table for cross-language analytics job execution';
@@ -0,0 +1,419 @@
+"""
+Synthetic Portfolio Data Generator
+
+Generates a realistic-looking private markets portfolio (funds, cash flows and
+benchmark series) whose rows match the tables in data/storage/schema.sql.
+Used for demo databases and load testing without touching client data.
+
+Generation Model:
+----------------
+- Funds are assigned to managers (GPs), roughly three funds per manager
+- Vintages drawn uniformly from a configurable range
+- Sectors drawn from a configurable mix
+- Fund IRR ~ N(mean_irr, irr_dispersion), clipped to a plausible range
+- TVPI compounds IRR over an effective holding period that grows with age
+- DPI ramps up from year 3 as funds mature; RVPI = TVPI - DPI
+- Capital calls spread over the investment period, distributions after year 3
+- Each fund holds 8-15 portfolio companies from a shared pool (favouring its
+  own sector), so some companies are held by several funds; company values
+  tie out to fund paid-in, distributions and NAV
+- Benchmarks are monthly lognormal index series
+"""
+
+import numpy as np
+from dataclasses import dataclass, field
+from datetime import date
+from typing import Dict, List, Optional, Tuple
+
+
+DEFAULT_SECTOR_MIX = {
+    'Technology': 0.30,
+    'Healthcare': 0.20,
+    'Consumer': 0.15,
+    'Finance': 0.15,
+    'Energy': 0.10,
+    'Industrials': 0.10,
+}
+
+# Annualized (mean return, volatility) for each synthetic benchmark
+DEFAULT_BENCHMARKS = {
+    'S&P 500': (0.10, 0.16),
+    'Russell 2000': (0.09, 0.22),
+    'MSCI World': (0.08, 0.15),
+}
+
+_NAME_STEMS = [
+    'Summit', 'Harbor', 'Granite', 'Meridian', 'Northstar', 'Cedar',
+    'Atlas', 'Beacon', 'Sterling', 'Pinnacle', 'Crescent', 'Ironwood',
+]
+_MANAGER_SUFFIXES = ['Capital', 'Partners', 'Equity', 'Investors', 'Group']
+_HEADQUARTERS = ['New York', 'San Francisco', 'Boston', 'Chicago', 'London', 'Menlo Park']
+_STRATEGIES = ['Buyout', 'Growth Equity', 'Venture Capital', 'Infrastructure', 'Private Credit']
+_NUMERALS = ['I', 'II', 'III', 'IV', 'V', 'VI', 'VII']
+_COMPANY_PREFIXES = [
+    'Nova', 'Quanta', 'Bluefin', 'Bright', 'Vertex', 'Apex',
+    'Lumen', 'Orbit', 'Terra', 'Vital', 'Helix', 'Zenith',
+]
+_COMPANY_SUFFIXES = ['Labs', 'Systems', 'Health', 'Logistics', 'Foods', 'Energy', 'Pay', 'Works', 'Bio', 'Networks']
+GEOGRAPHY_MIX = {
+    'North America': 0.55,
+    'Europe': 0.25,
+    'Asia': 0.15,
+    'Latin America': 0.05,
+}
+
+
+@dataclass
+class SyntheticPortfolioConfig:
+    """Parameters for synthetic portfolio generation."""
+    n_funds: int = 50
+    n_managers: Optional[int] = None
+    vintage_start: int = 2010
+    vintage_end: int = 2023
+    sector_mix: Dict[str, float] = field(default_factory=lambda: dict(DEFAULT_SECTOR_MIX))
+    mean_irr: float = 0.14
+    irr_dispersion: float = 0.08
+    volatility_range: Tuple[float, float] = (0.15, 0.35)
+    commitment_range: Tuple[float, float] = (25e6, 300e6)
+    benchmarks: Dict[str, Tuple[float, float]] = field(default_factory=lambda: dict(DEFAULT_BENCHMARKS))
+    as_of: Optional[date] = None
+    seed: Optional[int] = None
+
+
+class SyntheticPortfolioGenerator:
+    """
+    Generator for synthetic funds, cash flows and benchmark series.
+
+    Rows are returned as dictionaries keyed by schema column names, so they
+    can be inserted directly into managers, portfolio_data, cash_flows,
+    portfolio_companies, company_investments and benchmark_data. Manager,
+    fund and company IDs are assigned sequentially from 1.
+
+    Example:
+        >>> gen = SyntheticPortfolioGenerator(SyntheticPortfolioConfig(n_funds=50, seed=42))
+        >>> data = gen.generate()
+        >>> len(data['funds'])
+        50
+    """
+
+    def __init__(self, config: Optional[SyntheticPortfolioConfig] = None):
+        """
+        Initialize generator.
+
+        Parameters:
+            config: Generation parameters (default: 50 funds, 2010-2023 vintages)
+        """
+        self.config = config or SyntheticPortfolioConfig()
+        self.as_of = self.config.as_of or date.today()
+
+        if self.config.n_funds < 1:
+            raise ValueError("n_funds must be at least 1")
+        if self.config.n_managers is not None and self.config.n_managers < 1:
+            raise ValueError("n_managers must be at least 1")
+        if self.config.vintage_start > self.config.vintage_end:
+            raise ValueError("vintage_start must not be after vintage_end")
+        if self.config.vintage_start < 1990 or self.config.vintage_end > self.as_of.year:
+            raise ValueError(f"Vintages must lie between 1990 and {self.as_of.year}")
+        if not self.config.sector_mix or any(w < 0 for w in self.config.sector_mix.values()):
+            raise ValueError("sector_mix must contain non-negative weights")
+        if sum(self.config.sector_mix.values()) <= 0:
+            raise ValueError("sector_mix weights must not all be zero")
+
+        self.rng = np.random.default_rng(self.config.seed)
+
+    def generate(self) -> Dict[str, List[Dict]]:
+        """
+        Generate a complete synthetic portfolio.
+
+        Returns:
+            Dictionary with 'managers', 'funds', 'cash_flows', 'companies',
+            'investments' and 'benchmarks' row lists
+        """
+        managers = self._generate_managers()
+        companies = self._generate_companies()
+        funds = []
+        cash_flows = []
+        investments = []
+
+        for fund_id in range(1, self.config.n_funds + 1):
+            manager = managers[int(self.rng.integers(len(managers)))]
+            fund = self._generate_fund(fund_id, manager)
+            funds.append(fund)
+            cash_flows.extend(self._generate_cash_flows(fund))
+            investments.extend(self._generate_investments(fund, companies))
+
+        return {
+            'managers': managers,
+            'funds': funds,
+            'cash_flows': cash_flows,
+            'companies': companies,
+            'investments': investments,
+            'benchmarks': self._generate_benchmarks(),
+        }
+
+    def _generate_managers(self) -> List[Dict]:
+        """Generate manager (GP) rows with unique names."""
+        n_managers = self.config.n_managers or max(1, self.config.n_funds // 3)
+
+        managers = []
+        for manager_id in range(1, n_managers + 1):
+            idx = manager_id - 1
+            stem = _NAME_STEMS[idx % len(_NAME_STEMS)]
+            suffix = _MANAGER_SUFFIXES[(idx // len(_NAME_STEMS)) % len(_MANAGER_SUFFIXES)]
+            name = f"{stem} {suffix}"
+            if idx >= len(_NAME_STEMS) * len(_MANAGER_SUFFIXES):
+                name = f"{name} {idx // (len(_NAME_STEMS) * len(_MANAGER_SUFFIXES)) + 1}"
+
+            managers.append({
+                'manager_id': manager_id,
+                'manager_name': name,
+                'headquarters': self._choice(_HEADQUARTERS),
+                'primary_strategy': self._choice(_STRATEGIES),
+                'founded_year': int(self.rng.integers(1985, 2016)),
+                'aum': round(float(self.rng.uniform(0.5e9, 40e9)), -6),
+            })
+
+        return managers
+
+    def _generate_fund(self, fund_id: int, manager: Dict) -> Dict:
+        """Generate a single fund row with consistent performance metrics."""
+        cfg = self.config
+
+        sectors = list(cfg.sector_mix.keys())
+        weights = np.array([cfg.sector_mix[s] for s in sectors], dtype=float)
+        sector = str(self.rng.choice(sectors, p=weights / weights.sum()))
+
+        vintage = int(self.rng.integers(cfg.vintage_start, cfg.vintage_end + 1))
+        age = max(self.as_of.year - vintage, 0)
+
+        committed = round(float(self.rng.uniform(*cfg.commitment_range)), -5)
+
+        # Paid-in ramps over a ~4 year investment period
+        paid_in_ratio = float(np.clip(0.25 * (age + 1) + self.rng.normal(0, 0.05), 0.10, 1.0))
+        invested = round(committed * paid_in_ratio, 2)
+
+        irr = float(np.clip(self.rng.normal(cfg.mean_irr, cfg.irr_dispersion), -0.40, 0.60))
+        volatility = float(self.rng.uniform(*cfg.volatility_range))
+
+        # Effective holding period grows with age; young funds sit near 1.0x
+        holding_years = max(min(age, 10) * 0.5, 0.25)
+        tvpi = max((1 + irr) ** holding_years, 0.05)
+
+        realized_fraction = float(np.clip((age - 3) / 7, 0.0, 1.0))
+        dpi = tvpi * realized_fraction
+        rvpi = tvpi - dpi
+        nav = rvpi * invested
+
+        if tvpi < 0.3:
+            status = 'Written-Off'
+        elif realized_fraction >= 1.0:
+            status = 'Realized'
+        else:
+            status = 'Active'
+
+        benchmark_return = float(self.rng.normal(0.09, 0.02))
+        beta = float(self.rng.uniform(0.8, 1.3))
+        sharpe = (irr - 0.02) / volatility
+
+        return {
+            'fund_id': fund_id,
+            'manager_id': manager['manager_id'],
+            'fund_name': self._fund_name(manager['manager_name'].split()[0], sector),
+            'vintage': vintage,
+            'sector': sector,
+            'committed_capital': committed,
+            'invested_capital': invested,
+            'current_nav': round(nav, 2),
+            'irr': round(irr, 4),
+            'moic': round(tvpi, 4),
+            'tvpi': round(tvpi, 4),
+            'dpi': round(dpi, 4),
+            'rvpi': round(rvpi, 4),
+            'benchmark_return': round(benchmark_return, 4),
+            'volatility': round(volatility, 4),
+            'beta': round(beta, 4),
+            'alpha': round(irr - beta * benchmark_return, 4),
+            'sharpe_ratio': round(sharpe, 4),
+            'sortino_ratio': round(sharpe * 1.3, 4),
+            'max_drawdown': round(-float(self.rng.uniform(0.5, 1.5)) * volatility, 4),
+            'currency': 'USD',
+            'status': status,
+        }
+
+    def _generate_cash_flows(self, fund: Dict) -> List[Dict]:
+        """Generate capital calls, fees and distributions for a fund."""
+        vintage = fund['vintage']
+        quarters = self._quarters_since(vintage)
+        if not quarters:
+            return []
+
+        flows = []
+
+        # Capital calls over the first (up to) 16 quarters
+        call_quarters = quarters[:16]
+        call_weights = self.rng.dirichlet(np.ones(len(call_quarters)))
+        for flow_date, w in zip(call_quarters, call_weights):
+            flows.append(self._flow(fund, flow_date, 'Capital Call',
+                                    fund['invested_capital'] * w, 'Capital call'))
+
+        # Annual management fee on commitments (fourth quarter of each year)
+        fee_rate = float(self.rng.uniform(0.015, 0.02))
+        for flow_date in quarters[3:40:4]:
+            flows.append(self._flow(fund, flow_date, 'Fee',
+                                    fund['committed_capital'] * fee_rate, 'Management fee'))
+
+        # Distributions from year 3 onwards
+        total_distributions = fund['dpi'] * fund['invested_capital']
+        dist_quarters = quarters[12:]
+        if total_distributions > 0 and dist_quarters:
+            dist_weights = self.rng.dirichlet(np.ones(len(dist_quarters)) * 0.5)
+            for flow_date, w in zip(dist_quarters, dist_weights):
+                if w * total_distributions >= 1.0:
+                    flows.append(self._flow(fund, flow_date, 'Distribution',
+                                            total_distributions * w, 'Distribution'))
+
+        return flows
+
+    def _generate_companies(self) -> List[Dict]:
+        """Generate the shared pool of portfolio companies."""
+        n_companies = max(20, self.config.n_funds * 6)
+        n_names = len(_COMPANY_PREFIXES) * len(_COMPANY_SUFFIXES)
+
+        sectors = list(self.config.sector_mix.keys())
+        sector_p = np.array([self.config.sector_mix[s] for s in sectors], dtype=float)
+        sector_p /= sector_p.sum()
+        geographies = list(GEOGRAPHY_MIX.keys())
+        geography_p = np.array(list(GEOGRAPHY_MIX.values()))
+
+        companies = []
+        for company_id in range(1, n_companies + 1):
+            idx = company_id - 1
+            name = (f"{_COMPANY_PREFIXES[idx % len(_COMPANY_PREFIXES)]} "
+                    f"{_COMPANY_SUFFIXES[(idx // len(_COMPANY_PREFIXES)) % len(_COMPANY_SUFFIXES)]}")
+            if idx >= n_names:
+                name = f"{name} {idx // n_names + 1}"
+
+            companies.append({
+                'company_id': company_id,
+                'company_name': name,
+                'sector': str(self.rng.choice(sectors, p=sector_p)),
+                'geography': str(self.rng.choice(geographies, p=geography_p)),
+            })
+
+        return companies
+
+    def _generate_investments(self, fund: Dict, companies: List[Dict]) -> List[Dict]:
+        """Generate a fund's company positions, tied out to its fund-level metrics."""
+        n_holdings = min(int(self.rng.integers(8, 16)), len(companies))
+
+        # Favour companies in the fund's own sector
+        affinity = np.array([4.0 if c['sector'] == fund['sector'] else 1.0 for c in companies])
+        picks = self.rng.choice(len(companies), size=n_holdings, replace=False, p=affinity / affinity.sum())
+
+        invested = self.rng.dirichlet(np.ones(n_holdings)) * fund['invested_capital']
+        multiples = self.rng.lognormal(np.log(max(fund['tvpi'], 0.05)), 0.5, n_holdings)
+
+        vintage_start = date(fund['vintage'], 1, 1).toordinal()
+        realized_fraction = fund['dpi'] / fund['tvpi'] if fund['tvpi'] > 0 else 0.0
+
+        rows = []
+        for j, company_idx in enumerate(picks):
+            entry = date.fromordinal(min(vintage_start + int(self.rng.integers(0, 4 * 365)),
+                                         self.as_of.toordinal()))
+            exited = self.rng.random() < realized_fraction
+            exit_date = None
+            if exited:
+                exit_date = date.fromordinal(min(entry.toordinal() + int(self.rng.integers(3 * 365, 7 * 365)),
+                                                 self.as_of.toordinal()))
+
+            value = invested[j] * multiples[j]
+            rows.append({
+                'fund_id': fund['fund_id'],
+                'company_id': companies[company_idx]['company_id'],
+                'entry_date': entry,
+                'exit_date': exit_date,
+                'invested_amount': invested[j],
+                'realized_amount': value if exited else 0.0,
+                'current_valuation': 0.0 if exited else value,
+                'ownership_pct': round(float(self.rng.uniform(0.02, 0.40)), 4),
+            })
+
+        # Scale company values so they sum to fund distributions and NAV
+        self._tie_out(rows, 'realized_amount', fund['dpi'] * fund['invested_capital'])
+        self._tie_out(rows, 'current_valuation', fund['current_nav'])
+
+        for row in rows:
+            for key in ('invested_amount', 'realized_amount', 'current_valuation'):
+                row[key] = round(float(row[key]), 2)
+
+        return rows
+
+    @staticmethod
+    def _tie_out(rows: List[Dict], key: str, total: float):
+        """Rescale a column so it sums to the fund-level total (when it has any mass)."""
+        current = sum(r[key] for r in rows)
+        if current > 0:
+            for r in rows:
+                r[key] *= total / current
+
+    def _generate_benchmarks(self) -> List[Dict]:
+        """Generate monthly benchmark return and index level series."""
+        months = []
+        year, month = self.config.vintage_start, 1
+        while (year, month) <= (self.as_of.year, self.as_of.month):
+            months.append(_month_end(year, month))
+            month += 1
+            if month > 12:
+                year, month = year + 1, 1
+
+        rows = []
+        for name, (mu, sigma) in self.config.benchmarks.items():
+            monthly_mu = mu / 12
+            monthly_sigma = sigma / np.sqrt(12)
+            returns = self.rng.normal(monthly_mu, monthly_sigma, len(months))
+            levels = 100.0 * np.cumprod(1 + returns)
+
+            for d, ret, level in zip(months, returns, levels):
+                rows.append({
+                    'benchmark_name': name,
+                    'date': d,
+                    'return_value': round(float(ret), 6),
+                    'index_level': round(float(level), 4),
+                })
+
+        return rows
+
+    def _quarters_since(self, vintage: int) -> List[date]:
+        """Quarter-end dates from the vintage year up to the as-of date."""
+        dates = []
+        for year in range(vintage, self.as_of.year + 1):
+            for q in range(1, 5):
+                d = _month_end(year, 3 * q)
+                if d > self.as_of:
+                    return dates
+                dates.append(d)
+        return dates
+
+    def _fund_name(self, stem: str, sector: str) -> str:
+        numeral = self._choice(_NUMERALS)
+        return f"{stem} {sector} Partners {numeral}"
+
+    def _choice(self, options: List[str]) -> str:
+        return options[int(self.rng.integers(len(options)))]
+
+    @staticmethod
+    def _flow(fund: Dict, flow_date: date, flow_type: str, amount: float, description: str) -> Dict:
+        return {
+            'fund_id': fund['fund_id'],
+            'flow_date': flow_date,
+            'flow_type': flow_type,
+            'amount': round(float(amount), 2),
+            'description': description,
+        }
+
+
+def _month_end(year: int, month: int) -> date:
+    """Last calendar day of the given month."""
+    if month == 12:
+        return date(year, 12, 31)
+    return date.fromordinal(date(year, month + 1, 1).toordinal() - 1)
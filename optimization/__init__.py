@@ -2,11 +2,13 @@
 from .markowitz import MarkowitzOptimizer, generate_sample_returns
 from .risk_parity import RiskParityOptimizer, risk_parity_analytical_2asset
 from .cvar_optimizer import CVaROptimizer, calculate_historical_cvar, parametric_cvar
+from .rebalance import RebalanceRecommender
 
 __all__ = [
     'MarkowitzOptimizer',
     'RiskParityOptimizer',
     'CVaROptimizer',
+    'RebalanceRecommender',
     'generate_sample_returns',
     'risk_parity_analytical_2asset',
     'calculate_historical_cvar',
@@ -96,15 +96,16 @@ class CVaROptimizer:
         # Portfolio returns for each scenario
         portfolio_returns = np.dot(returns, weights)
 
-        # Sort returns (ascending, so worst losses first)
-        sorted_returns = np.sort(portfolio_returns)
+        # Partition around the VaR rank (O(n) instead of a full sort);
+        # everything before var_index is no better than the VaR return
+        var_index = int(np.floor((1 - self.alpha) * len(portfolio_returns)))
+        partitioned = np.partition(portfolio_returns, var_index)
 
         # VaR: α-quantile of loss distribution
-        var_index = int(np.floor((1 - self.alpha) * len(sorted_returns)))
-        var = -sorted_returns[var_index]  # Negative because we want loss
+        var = -partitioned[var_index]  # Negative because we want loss
 
         # CVaR: mean of returns worse than VaR
-        cvar_returns = sorted_returns[:var_index+1]
+        cvar_returns = partitioned[:var_index+1]
         cvar = -np.mean(cvar_returns) if len(cvar_returns) > 0 else 0.0
 
         return var, cvar
@@ -289,12 +290,12 @@ def calculate_historical_cvar(
         Tuple of (VaR, CVaR)
     """
     returns = np.asarray(returns)
-    sorted_returns = np.sort(returns)
 
-    var_index = int(np.floor((1 - alpha) * len(sorted_returns)))
-    var = -sorted_returns[var_index]
+    var_index = int(np.floor((1 - alpha) * len(returns)))
+    partitioned = np.partition(returns, var_index)
+    var = -partitioned[var_index]
 
-    cvar_returns = sorted_returns[:var_index+1]
+    cvar_returns = partitioned[:var_index+1]
     cvar = -np.mean(cvar_returns) if len(cvar_returns) > 0 else 0.0
 
     return var, cvar
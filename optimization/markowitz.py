@@ -32,6 +32,8 @@ class MarkowitzOptimizer:
     - Minimum variance portfolio
     - Target return optimization
     - Custom constraints (sector limits, position limits, etc.)
+    - Liquidity constraints (illiquid sleeve caps, lockups, capital-call coverage)
+    - Resampled (Michaud) efficient frontier
 
     Attributes:
         returns (np.ndarray): Historical returns matrix (n_periods × n_assets)
@@ -312,13 +314,105 @@ class MarkowitzOptimizer:
             np.array(frontier_weights)
         )
 
+    def resampled_frontier(
+        self,
+        n_simulations: int = 100,
+        n_points: int = 20,
+        allow_short: bool = False,
+        seed: Optional[int] = None
+    ) -> Dict[str, any]:
+        """
+        Compute the Michaud resampled efficient frontier.
+
+        Input uncertainty is simulated by drawing return histories of the same
+        length from N(μ, Σ), re-estimating μ and Σ and optimizing each draw.
+        Frontier portfolios are matched by rank (i-th point from minimum
+        variance to maximum return) and their weights averaged. Both frontiers
+        are evaluated with the original point estimates.
+
+        Parameters:
+            n_simulations: Number of resampled input sets
+            n_points: Number of portfolios along each frontier
+            allow_short: Allow short positions
+            seed: Random seed for reproducibility
+
+        Returns:
+            Dictionary with 'point_estimate' and 'resampled' frontiers
+            (returns, volatilities, sharpe_ratios, weights) and the standard
+            deviation of resampled weights at each rank
+        """
+        if n_simulations < 1 or n_points < 2:
+            raise ValueError("Need n_simulations >= 1 and n_points >= 2")
+
+        rng = np.random.default_rng(seed)
+        n_periods = self.returns.shape[0]
+        period_mean = self.mean_returns / self.frequency
+        period_cov = self.cov_matrix / self.frequency
+
+        point_weights = self._frontier_weights_by_rank(self, n_points, allow_short)
+
+        draws = np.empty((n_simulations, n_points, self.n_assets))
+        with warnings.catch_warnings():
+            warnings.simplefilter('ignore')
+            for k in range(n_simulations):
+                sim_returns = rng.multivariate_normal(period_mean, period_cov, size=n_periods)
+                sim_opt = MarkowitzOptimizer(
+                    sim_returns,
+                    risk_free_rate=self.risk_free_rate,
+                    frequency=self.frequency
+                )
+                draws[k] = self._frontier_weights_by_rank(sim_opt, n_points, allow_short)
+
+        resampled_weights = draws.mean(axis=0)
+
+        return {
+            'point_estimate': self._evaluate_frontier(point_weights),
+            'resampled': self._evaluate_frontier(resampled_weights),
+            'weight_std': draws.std(axis=0),
+            'n_simulations': n_simulations
+        }
+
+    @staticmethod
+    def _frontier_weights_by_rank(
+        optimizer: 'MarkowitzOptimizer',
+        n_points: int,
+        allow_short: bool
+    ) -> np.ndarray:
+        """Frontier weights at evenly spaced target returns (n_points × n_assets)."""
+        min_ret = optimizer.min_variance(allow_short=allow_short)['return']
+        if allow_short:
+            max_ret = optimizer.max_sharpe_ratio(allow_short=True)['return'] * 1.5
+        else:
+            max_ret = np.max(optimizer.mean_returns)
+        max_ret = max(max_ret, min_ret)
+
+        return np.array([
+            optimizer.target_return(target, allow_short=allow_short)['weights']
+            for target in np.linspace(min_ret, max_ret, n_points)
+        ])
+
+    def _evaluate_frontier(self, weights: np.ndarray) -> Dict[str, np.ndarray]:
+        """Evaluate frontier portfolios with the point-estimate inputs."""
+        perf = np.array([self.portfolio_performance(w) for w in weights])
+        return {
+            'returns': perf[:, 0],
+            'volatilities': perf[:, 1],
+            'sharpe_ratios': perf[:, 2],
+            'weights': weights
+        }
+
     def optimize_with_constraints(
         self,
         objective: str = 'max_sharpe',
         weight_bounds: Optional[Tuple[float, float]] = None,
         sector_limits: Optional[Dict[str, Tuple[List[int], float, float]]] = None,
         turnover_limit: Optional[float] = None,
-        current_weights: Optional[np.ndarray] = None
+        current_weights: Optional[np.ndarray] = None,
+        illiquid_assets: Optional[List[int]] = None,
+        max_illiquid_weight: Optional[float] = None,
+        locked_assets: Optional[List[int]] = None,
+        unfunded_commitments: Optional[float] = None,
+        min_call_coverage: float = 1.0
     ) -> Dict[str, any]:
         """
         Optimize portfolio with custom constraints.
@@ -329,12 +423,24 @@ class MarkowitzOptimizer:
             sector_limits: Dict of sector constraints
                 Format: {'sector_name': ([asset_indices], min_weight, max_weight)}
             turnover_limit: Maximum portfolio turnover from current_weights
-            current_weights: Current portfolio weights (for turnover constraint)
+            current_weights: Current portfolio weights (for turnover and lockup constraints)
+            illiquid_assets: Indices of illiquid assets (private funds, lockups, etc.)
+            max_illiquid_weight: Maximum combined weight of illiquid assets
+            locked_assets: Indices of assets in lockup, which cannot be reduced
+                below current_weights
+            unfunded_commitments: Projected capital calls as a fraction of portfolio value
+            min_call_coverage: Required ratio of liquid weight to unfunded commitments
 
         Returns:
             Dictionary with weights, return, volatility, and Sharpe ratio
+
+        Raises:
+            ValueError: If the liquidity constraints are infeasible, an index
+                is out of range, or the optimizer returns weights that violate
+                a constraint
         """
         constraints = []
+        labels = []
 
         # Sector constraints
         if sector_limits:
@@ -344,11 +450,13 @@ class MarkowitzOptimizer:
                     'type': 'ineq',
                     'fun': lambda w, idx=indices, m=min_w: np.sum(w[idx]) - m
                 })
+                labels.append(f"sector {sector_name} minimum")
                 # Max constraint
                 constraints.append({
                     'type': 'ineq',
                     'fun': lambda w, idx=indices, m=max_w: m - np.sum(w[idx])
                 })
+                labels.append(f"sector {sector_name} maximum")
 
         # Turnover constraint
         if turnover_limit is not None and current_weights is not None:
@@ -356,17 +464,117 @@ class MarkowitzOptimizer:
                 'type': 'ineq',
                 'fun': lambda w: turnover_limit - np.sum(np.abs(w - current_weights))
             })
+            labels.append("turnover limit")
+
+        # Liquidity constraints
+        illiquid = list(illiquid_assets) if illiquid_assets else []
+        locked = list(locked_assets) if locked_assets else []
+        self._check_liquidity_feasible(
+            illiquid, max_illiquid_weight, locked, current_weights,
+            unfunded_commitments, min_call_coverage
+        )
+        liquid = [i for i in range(self.n_assets) if i not in illiquid]
+
+        if max_illiquid_weight is not None and illiquid:
+            constraints.append({
+                'type': 'ineq',
+                'fun': lambda w: max_illiquid_weight - np.sum(w[illiquid])
+            })
+            labels.append("max illiquid weight")
+
+        for i in locked:
+            constraints.append({
+                'type': 'ineq',
+                'fun': lambda w, i=i: w[i] - current_weights[i]
+            })
+            labels.append(f"lockup on asset {i}")
+
+        # Liquid assets must cover projected capital calls
+        if unfunded_commitments is not None:
+            constraints.append({
+                'type': 'ineq',
+                'fun': lambda w: np.sum(w[liquid]) - min_call_coverage * unfunded_commitments
+            })
+            labels.append("capital call coverage")
 
         # Determine objective
         if objective == 'max_sharpe':
-            return self.max_sharpe_ratio(allow_short=False, constraints=constraints)
+            result = self.max_sharpe_ratio(allow_short=False, constraints=constraints)
         elif objective == 'min_variance':
-            return self.min_variance(allow_short=False, constraints=constraints)
+            result = self.min_variance(allow_short=False, constraints=constraints)
         elif isinstance(objective, (int, float)):
-            return self.target_return(objective, allow_short=False, constraints=constraints)
+            result = self.target_return(objective, allow_short=False, constraints=constraints)
         else:
             raise ValueError(f"Unknown objective: {objective}")
 
+        # SLSQP only warns when it stops early, so check the weights themselves
+        tol = 1e-6
+        weights = result['weights']
+        violated = [label for cons, label in zip(constraints, labels) if cons['fun'](weights) < -tol]
+        if abs(np.sum(weights) - 1) > tol:
+            violated.insert(0, "weights sum to 1")
+        if violated:
+            raise ValueError(f"Optimization violates constraints: {', '.join(violated)}")
+
+        return result
+
+    def _check_liquidity_feasible(
+        self,
+        illiquid: List[int],
+        max_illiquid_weight: Optional[float],
+        locked: List[int],
+        current_weights: Optional[np.ndarray],
+        unfunded_commitments: Optional[float],
+        min_call_coverage: float
+    ) -> None:
+        """
+        Reject liquidity constraints that no long-only portfolio can satisfy.
+
+        With weights summing to 1, the illiquid sleeve is at least the locked
+        illiquid weight and the liquid sleeve at least the call coverage
+        (min_call_coverage × unfunded_commitments), so both floors must fit.
+        """
+        for name, indices in (('illiquid_assets', illiquid), ('locked_assets', locked)):
+            if any(not 0 <= i < self.n_assets for i in indices):
+                raise ValueError(f"{name} must be indices in [0, {self.n_assets})")
+            if len(set(indices)) != len(indices):
+                raise ValueError(f"{name} contains duplicate indices")
+
+        if max_illiquid_weight is not None and not 0 <= max_illiquid_weight <= 1:
+            raise ValueError("max_illiquid_weight must be between 0 and 1")
+        if unfunded_commitments is not None and (unfunded_commitments < 0 or min_call_coverage < 0):
+            raise ValueError("unfunded_commitments and min_call_coverage must be non-negative")
+
+        locked_illiquid = 0.0
+        if locked:
+            if current_weights is None:
+                raise ValueError("current_weights is required for locked_assets")
+            current = np.asarray(current_weights, dtype=float)
+            if current.shape != (self.n_assets,):
+                raise ValueError("current_weights must have one entry per asset")
+            if np.sum(current[locked]) > 1:
+                raise ValueError("Locked weights sum to more than 1")
+            locked_illiquid = float(np.sum(current[[i for i in locked if i in illiquid]]))
+            if max_illiquid_weight is not None and locked_illiquid > max_illiquid_weight:
+                raise ValueError(
+                    f"Locked illiquid weight {locked_illiquid:.4f} exceeds "
+                    f"max_illiquid_weight {max_illiquid_weight:.4f}"
+                )
+
+        n_liquid = self.n_assets - len(illiquid)
+        if max_illiquid_weight is not None and illiquid and n_liquid == 0 and max_illiquid_weight < 1:
+            raise ValueError("Every asset is illiquid, so max_illiquid_weight must be 1")
+
+        if unfunded_commitments is not None:
+            required = min_call_coverage * unfunded_commitments
+            if required > 0 and n_liquid == 0:
+                raise ValueError("Capital call coverage requires at least one liquid asset")
+            if required + locked_illiquid > 1:
+                raise ValueError(
+                    f"Call coverage requires liquid weight {required:.4f}, but at most "
+                    f"{1 - locked_illiquid:.4f} is available"
+                )
+
 
 def generate_sample_returns(
     n_assets: int = 10,
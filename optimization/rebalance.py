@@ -0,0 +1,182 @@
+"""
+Portfolio Rebalancing Recommendations
+
+Turns target weights (typically optimizer output) and current holdings into
+concrete trades that respect minimum lot sizes and a turnover budget.
+
+Procedure:
+---------
+1. Desired trade per asset: d_i = w_i × V - h_i, with V = Σh + cash
+2. Illiquid assets cannot be sold; positive trades become commitments
+3. If one-way turnover 0.5 × Σ|d| / V exceeds the budget, scale all trades
+   proportionally down to the budget
+4. Round trades toward zero to whole lots and drop trades below min_trade
+5. Trim buys (largest first) until they are funded by sales plus cash
+"""
+
+import numpy as np
+from typing import Dict, List, Optional, Union
+
+
+class RebalanceRecommender:
+    """
+    Rebalancing trade generator.
+
+    Attributes:
+        holdings (np.ndarray): Current market value per asset
+        target_weights (np.ndarray): Target allocation (sums to 1)
+        cash (float): Uninvested cash available for purchases
+        n_assets (int): Number of assets
+
+    Example:
+        >>> rec = RebalanceRecommender(
+        ...     holdings=[600_000, 300_000, 100_000],
+        ...     target_weights=[0.5, 0.3, 0.2],
+        ...     lot_size=1_000, max_turnover=0.05
+        ... )
+        >>> result = rec.recommend()
+        >>> for trade in result['trades']:
+        ...     print(trade['asset'], trade['action'], trade['amount'])
+    """
+
+    def __init__(
+        self,
+        holdings: Union[List[float], np.ndarray],
+        target_weights: Union[List[float], np.ndarray],
+        cash: float = 0.0,
+        lot_size: Union[float, List[float], np.ndarray] = 0.0,
+        min_trade: float = 0.0,
+        max_turnover: Optional[float] = None,
+        illiquid: Optional[List[bool]] = None,
+        asset_names: Optional[List[str]] = None
+    ):
+        """
+        Initialize rebalance recommender.
+
+        Parameters:
+            holdings: Current market value of each position
+            target_weights: Target weights (must sum to 1)
+            cash: Cash available in addition to holdings
+            lot_size: Trade size granularity (scalar or per asset, 0 = no rounding)
+            min_trade: Smallest trade worth executing
+            max_turnover: One-way turnover budget as a fraction of portfolio value
+            illiquid: Per-asset flags for positions that cannot be sold (e.g. LP interests)
+            asset_names: Asset labels for the trade list
+        """
+        self.holdings = np.asarray(holdings, dtype=float)
+        self.target_weights = np.asarray(target_weights, dtype=float)
+        self.cash = float(cash)
+        self.n_assets = len(self.holdings)
+
+        self.lot_size = np.broadcast_to(np.asarray(lot_size, dtype=float), (self.n_assets,))
+        self.min_trade = min_trade
+        self.max_turnover = max_turnover
+        self.illiquid = np.asarray(illiquid if illiquid is not None else [False] * self.n_assets, dtype=bool)
+        self.asset_names = asset_names or [f'Asset {i + 1}' for i in range(self.n_assets)]
+
+        if self.target_weights.shape != self.holdings.shape:
+            raise ValueError("holdings and target_weights must have the same length")
+        if not np.isclose(np.sum(self.target_weights), 1.0, atol=1e-6):
+            raise ValueError("target_weights must sum to 1")
+        if np.any(self.holdings < 0) or self.cash < 0:
+            raise ValueError("holdings and cash must be non-negative")
+        if np.any(self.lot_size < 0):
+            raise ValueError("lot_size must be non-negative")
+        if max_turnover is not None and max_turnover < 0:
+            raise ValueError("max_turnover must be non-negative")
+        if len(self.illiquid) != self.n_assets or len(self.asset_names) != self.n_assets:
+            raise ValueError("illiquid and asset_names must match the number of assets")
+
+    @property
+    def total_value(self) -> float:
+        """Portfolio value including cash."""
+        return float(np.sum(self.holdings) + self.cash)
+
+    def recommend(self) -> Dict[str, any]:
+        """
+        Generate rebalancing trades.
+
+        Returns:
+            Dictionary with trade list, turnover, post-trade weights and residual cash
+        """
+        total = self.total_value
+        if total <= 0:
+            raise ValueError("Portfolio has no value to rebalance")
+
+        desired = self.target_weights * total - self.holdings
+
+        # Illiquid positions can only be added to (via commitments)
+        desired[self.illiquid & (desired < 0)] = 0.0
+
+        trades = desired.copy()
+        if self.max_turnover is not None:
+            turnover = 0.5 * np.sum(np.abs(trades)) / total
+            if turnover > self.max_turnover:
+                trades *= self.max_turnover / turnover
+
+        trades = self._round_to_lots(trades)
+        trades[np.abs(trades) < self.min_trade] = 0.0
+        trades = self._fund_purchases(trades)
+        trades[(trades > 0) & (trades < self.min_trade)] = 0.0
+
+        post_holdings = self.holdings + trades
+        residual_cash = self.cash - float(np.sum(trades))
+
+        trade_list = []
+        for i in range(self.n_assets):
+            if trades[i] > 0:
+                action = 'commit' if self.illiquid[i] else 'buy'
+            elif trades[i] < 0:
+                action = 'sell'
+            else:
+                action = 'hold'
+
+            trade_list.append({
+                'asset': self.asset_names[i],
+                'action': action,
+                'amount': float(abs(trades[i])),
+                'current_value': float(self.holdings[i]),
+                'target_value': float(self.target_weights[i] * total),
+                'post_trade_value': float(post_holdings[i]),
+            })
+
+        current_weights = self.holdings / total
+        post_weights = post_holdings / total
+
+        return {
+            'trades': trade_list,
+            'turnover': float(0.5 * np.sum(np.abs(trades)) / total),
+            'unconstrained_turnover': float(0.5 * np.sum(np.abs(desired)) / total),
+            'current_weights': current_weights,
+            'post_trade_weights': post_weights,
+            'target_weights': self.target_weights,
+            'tracking_error_before': float(np.sum(np.abs(current_weights - self.target_weights))),
+            'tracking_error_after': float(np.sum(np.abs(post_weights - self.target_weights))),
+            'residual_cash': residual_cash,
+        }
+
+    def _round_to_lots(self, trades: np.ndarray) -> np.ndarray:
+        """Round each trade toward zero to a whole number of lots."""
+        rounded = trades.copy()
+        has_lot = self.lot_size > 0
+        rounded[has_lot] = np.trunc(trades[has_lot] / self.lot_size[has_lot]) * self.lot_size[has_lot]
+        return rounded
+
+    def _fund_purchases(self, trades: np.ndarray) -> np.ndarray:
+        """Trim buys, largest first, until they are covered by sales plus cash."""
+        trades = trades.copy()
+        shortfall = float(np.sum(trades)) - self.cash
+
+        for i in np.argsort(-trades):
+            if shortfall <= 1e-9 or trades[i] <= 0:
+                break
+
+            cut = min(trades[i], shortfall)
+            if self.lot_size[i] > 0:
+                cut = np.ceil(cut / self.lot_size[i]) * self.lot_size[i]
+                cut = min(cut, trades[i])
+
+            trades[i] -= cut
+            shortfall -= cut
+
+        return trades
@@ -0,0 +1,102 @@
+"""
+Tests for Markowitz liquidity constraints.
+
+Tests include:
+- Call coverage above 1 is rejected before optimizing
+- Locked illiquid weight above the illiquid cap is rejected
+- Call coverage plus locked illiquid weight above 1 is rejected
+- Out-of-range and duplicate indices are rejected
+- Feasible constraints hold on the returned weights
+- Weights that violate a constraint raise instead of warning
+"""
+
+import pytest
+import numpy as np
+from optimization import MarkowitzOptimizer, generate_sample_returns
+
+
+@pytest.fixture
+def optimizer():
+    """Four assets of seeded sample returns."""
+    return MarkowitzOptimizer(generate_sample_returns(n_assets=4, n_periods=252, seed=42), risk_free_rate=0.02)
+
+
+CURRENT = np.array([0.3, 0.3, 0.2, 0.2])
+
+
+class TestInfeasible:
+    """Test feasibility checks made before optimizing."""
+
+    def test_coverage_above_one(self, optimizer):
+        """1.5 × 0.8 unfunded needs 120% in liquid assets."""
+        with pytest.raises(ValueError, match="Call coverage"):
+            optimizer.optimize_with_constraints(
+                illiquid_assets=[0], unfunded_commitments=0.8, min_call_coverage=1.5
+            )
+
+    def test_locked_illiquid_above_cap(self, optimizer):
+        """Locked illiquid weight 0.6 cannot fit under a 0.5 cap."""
+        with pytest.raises(ValueError, match="exceeds max_illiquid_weight"):
+            optimizer.optimize_with_constraints(
+                illiquid_assets=[0, 1], max_illiquid_weight=0.5,
+                locked_assets=[0, 1], current_weights=CURRENT
+            )
+
+    def test_coverage_plus_locked_illiquid(self, optimizer):
+        """0.3 locked illiquid leaves 0.7 for a 0.75 coverage requirement."""
+        with pytest.raises(ValueError, match="at most 0.7000"):
+            optimizer.optimize_with_constraints(
+                illiquid_assets=[0], locked_assets=[0], current_weights=CURRENT,
+                unfunded_commitments=0.5, min_call_coverage=1.5
+            )
+
+    def test_no_liquid_assets(self, optimizer):
+        """Coverage needs at least one liquid asset."""
+        with pytest.raises(ValueError, match="liquid asset"):
+            optimizer.optimize_with_constraints(illiquid_assets=[0, 1, 2, 3], unfunded_commitments=0.1)
+
+    @pytest.mark.parametrize('kwargs', [
+        {'illiquid_assets': [4]},
+        {'illiquid_assets': [-1]},
+        {'illiquid_assets': [1, 1]},
+        {'locked_assets': [7], 'current_weights': CURRENT},
+    ])
+    def test_bad_indices(self, optimizer, kwargs):
+        """Indices must be unique and within the asset count."""
+        with pytest.raises(ValueError, match="indices"):
+            optimizer.optimize_with_constraints(**kwargs)
+
+    def test_locked_without_current_weights(self, optimizer):
+        """Lockups are relative to current weights."""
+        with pytest.raises(ValueError, match="current_weights is required"):
+            optimizer.optimize_with_constraints(locked_assets=[0])
+
+
+class TestFeasible:
+    """Test that constraints hold on the optimized weights."""
+
+    def test_liquidity_constraints_hold(self, optimizer):
+        """Illiquid sleeve ≤ 0.2 and liquid sleeve ≥ 1.5 × 0.5."""
+        result = optimizer.optimize_with_constraints(
+            illiquid_assets=[0, 1], max_illiquid_weight=0.2,
+            unfunded_commitments=0.5, min_call_coverage=1.5
+        )
+        w = result['weights']
+        assert np.sum(w) == pytest.approx(1.0, abs=1e-6)
+        assert np.sum(w[[0, 1]]) <= 0.2 + 1e-6
+        assert np.sum(w[[2, 3]]) >= 0.75 - 1e-6
+
+    def test_lockup_holds(self, optimizer):
+        """A locked asset stays at or above its current weight."""
+        result = optimizer.optimize_with_constraints(
+            objective='min_variance', locked_assets=[2], current_weights=CURRENT
+        )
+        assert result['weights'][2] >= CURRENT[2] - 1e-6
+
+    def test_violated_constraints_raise(self, optimizer):
+        """Sector minimums summing to 1.2 cannot be met, so the result is rejected."""
+        with pytest.raises(ValueError, match="violates constraints"):
+            optimizer.optimize_with_constraints(sector_limits={
+                'a': ([0, 1], 0.6, 1.0),
+                'b': ([2, 3], 0.6, 1.0),
+            })
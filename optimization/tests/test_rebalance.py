@@ -0,0 +1,93 @@
+"""
+Tests for rebalancing recommendations.
+
+Tests include:
+- Trades round toward zero to whole lots, on both buys and sells
+- Buys that exceed sales plus cash are trimmed largest first, in whole lots
+- Trades scale down proportionally to the turnover budget
+- Illiquid positions are never sold
+"""
+
+import pytest
+import numpy as np
+from optimization import RebalanceRecommender
+
+
+def amounts(result):
+    """Signed trade amounts (sells negative)."""
+    return [-t['amount'] if t['action'] == 'sell' else t['amount'] for t in result['trades']]
+
+
+class TestLotRounding:
+    """Test rounding trades to whole lots."""
+
+    def test_rounds_toward_zero(self):
+        """±100k desired with 30k lots trades ±90k."""
+        rec = RebalanceRecommender(holdings=[600_000, 400_000], target_weights=[0.5, 0.5], lot_size=30_000)
+        result = rec.recommend()
+        assert amounts(result) == pytest.approx([-90_000, 90_000])
+        assert result['residual_cash'] == pytest.approx(0.0)
+
+    def test_below_one_lot_holds(self):
+        """A trade smaller than one lot rounds to no trade."""
+        rec = RebalanceRecommender(holdings=[510_000, 490_000], target_weights=[0.5, 0.5], lot_size=25_000)
+        result = rec.recommend()
+        assert [t['action'] for t in result['trades']] == ['hold', 'hold']
+
+
+class TestFundPurchases:
+    """Test trimming buys to the cash available."""
+
+    def test_largest_buy_trimmed_first(self):
+        """V = 1.05M: desired buys 20k and 220k against 50k cash trim the 220k buy by 190k."""
+        rec = RebalanceRecommender(
+            holdings=[400_000, 400_000, 200_000], target_weights=[0.4, 0.2, 0.4],
+            cash=50_000, illiquid=[False, True, False]
+        )
+        result = rec.recommend()
+        assert amounts(result) == pytest.approx([20_000, 0, 30_000])
+        assert result['trades'][1]['action'] == 'hold'
+        assert result['residual_cash'] == pytest.approx(0.0, abs=1e-6)
+
+    def test_trim_in_whole_lots(self):
+        """A 160k shortfall on a 200k buy with 25k lots cuts 175k, leaving cash unspent."""
+        rec = RebalanceRecommender(
+            holdings=[400_000, 400_000, 200_000], target_weights=[0.4, 0.2, 0.4],
+            cash=40_000, lot_size=25_000, illiquid=[False, True, False]
+        )
+        result = rec.recommend()
+        assert amounts(result) == pytest.approx([0, 0, 25_000])
+        assert result['residual_cash'] == pytest.approx(15_000)
+
+    def test_illiquid_buy_is_a_commitment(self):
+        """Positive trades on illiquid positions are commitments."""
+        rec = RebalanceRecommender(
+            holdings=[700_000, 300_000], target_weights=[0.6, 0.4], illiquid=[False, True]
+        )
+        result = rec.recommend()
+        assert [t['action'] for t in result['trades']] == ['sell', 'commit']
+        assert amounts(result) == pytest.approx([-100_000, 100_000])
+
+
+class TestTurnover:
+    """Test the turnover budget."""
+
+    def test_trades_scale_to_budget(self):
+        """20% desired turnover against a 10% budget halves every trade."""
+        rec = RebalanceRecommender(holdings=[600_000, 400_000], target_weights=[0.4, 0.6], max_turnover=0.1)
+        result = rec.recommend()
+        assert result['unconstrained_turnover'] == pytest.approx(0.2)
+        assert result['turnover'] == pytest.approx(0.1)
+        assert amounts(result) == pytest.approx([-100_000, 100_000])
+        np.testing.assert_allclose(result['post_trade_weights'], [0.5, 0.5])
+
+    def test_within_budget_unchanged(self):
+        """Trades inside the budget are not scaled."""
+        rec = RebalanceRecommender(holdings=[600_000, 400_000], target_weights=[0.4, 0.6], max_turnover=0.5)
+        result = rec.recommend()
+        assert result['turnover'] == pytest.approx(result['unconstrained_turnover'])
+
+    def test_weights_must_sum_to_one(self):
+        """Targets that do not sum to 1 are rejected."""
+        with pytest.raises(ValueError):
+            RebalanceRecommender(holdings=[600_000, 400_000], target_weights=[0.5, 0.6])
@@ -0,0 +1,75 @@
+"""
+Tests for the Michaud resampled frontier.
+
+Tests include:
+- The point-estimate frontier runs from the closed-form minimum variance
+  portfolio to the highest-return asset
+- Resampled weights are long-only and fully invested
+- A single simulation has no weight dispersion
+- Resampled portfolios never beat the point-estimate frontier
+- Seeded runs are reproducible
+"""
+
+import pytest
+import numpy as np
+from optimization import MarkowitzOptimizer
+
+
+@pytest.fixture
+def optimizer():
+    """Two near-uncorrelated assets: 16% and 32% volatility, 10% and 20% mean return."""
+    rng = np.random.default_rng(7)
+    noise = rng.standard_normal((500, 2)) * np.array([0.01, 0.02])
+    returns = noise - noise.mean(axis=0) + np.array([0.10, 0.20]) / 252
+    return MarkowitzOptimizer(returns, risk_free_rate=0.02)
+
+
+class TestPointEstimate:
+    """Test the frontier of the original inputs."""
+
+    def test_endpoints(self, optimizer):
+        """First point is Σ⁻¹1 / 1'Σ⁻¹1; last is all in the highest-return asset."""
+        result = optimizer.resampled_frontier(n_simulations=1, n_points=5, seed=0)
+        weights = result['point_estimate']['weights']
+
+        inv_ones = np.linalg.solve(optimizer.cov_matrix, np.ones(2))
+        assert weights[0] == pytest.approx(inv_ones / inv_ones.sum(), abs=1e-3)
+        assert weights[-1] == pytest.approx([0.0, 1.0], abs=1e-3)
+
+    def test_evenly_spaced_returns(self, optimizer):
+        """Points sit at evenly spaced target returns."""
+        returns = optimizer.resampled_frontier(n_simulations=1, n_points=5, seed=0)['point_estimate']['returns']
+        assert returns == pytest.approx(np.linspace(returns[0], 0.20, 5), abs=1e-4)
+
+
+class TestResampled:
+    """Test the averaged frontier."""
+
+    def test_long_only_and_invested(self, optimizer):
+        result = optimizer.resampled_frontier(n_simulations=10, n_points=5, seed=1)
+        weights = result['resampled']['weights']
+        assert weights.shape == (5, 2)
+        assert np.all(weights >= -1e-8)
+        assert weights.sum(axis=1) == pytest.approx(np.ones(5))
+        assert result['weight_std'].shape == (5, 2)
+        assert result['n_simulations'] == 10
+
+    def test_single_simulation_has_no_dispersion(self, optimizer):
+        result = optimizer.resampled_frontier(n_simulations=1, n_points=4, seed=2)
+        assert result['weight_std'] == pytest.approx(np.zeros((4, 2)))
+
+    def test_dominated_by_point_estimate(self, optimizer):
+        """At its own return, each resampled portfolio is no less volatile than the efficient one."""
+        resampled = optimizer.resampled_frontier(n_simulations=10, n_points=5, seed=3)['resampled']
+        for ret, vol in zip(resampled['returns'], resampled['volatilities']):
+            assert vol >= optimizer.target_return(ret)['volatility'] - 1e-5
+
+    def test_reproducible(self, optimizer):
+        first = optimizer.resampled_frontier(n_simulations=5, n_points=4, seed=4)
+        second = optimizer.resampled_frontier(n_simulations=5, n_points=4, seed=4)
+        assert first['resampled']['weights'] == pytest.approx(second['resampled']['weights'])
+
+    @pytest.mark.parametrize('kwargs', [{'n_simulations': 0}, {'n_points': 1}])
+    def test_invalid_arguments(self, optimizer, kwargs):
+        with pytest.raises(ValueError):
+            optimizer.resampled_frontier(**kwargs)
@@ -1,4 +1,12 @@
 """Monte Carlo pricing module."""
 from .engine import MonteCarloEngine, VarianceReduction, compare_variance_reduction
+from .batch import ParameterSweep
+from .statistics import (RunningStatistics, ThresholdQueries, evaluate_threshold_queries, normality_tests,
+                         select_percentiles, weighted_percentiles)
+from .low_discrepancy import SobolSequence
+from .stratified import ScenarioBucket, StratifiedSimulator
 
-__all__ = ['MonteCarloEngine', 'VarianceReduction', 'compare_variance_reduction']
+__all__ = ['MonteCarloEngine', 'VarianceReduction', 'compare_variance_reduction', 'ParameterSweep',
+           'RunningStatistics', 'ThresholdQueries', 'evaluate_threshold_queries', 'normality_tests',
+           'select_percentiles', 'weighted_percentiles', 'SobolSequence', 'ScenarioBucket',
+           'StratifiedSimulator']
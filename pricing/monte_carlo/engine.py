@@ -12,13 +12,18 @@ Performance Targets:
 Variance Reduction Techniques:
 - Antithetic variates: 2x variance reduction
 - Control variates: 2-5x additional reduction
-- Quasi-Monte Carlo (Sobol sequences): faster convergence
+- Quasi-Monte Carlo (Owen-scrambled Sobol sequences): faster convergence
 - Importance sampling
+
+Precision:
+- float64 (default) for full accuracy
+- float32 halves memory traffic and doubles SIMD width for very large runs;
+  the price is still accumulated in float64
 """
 
 import numpy as np
 from typing import Callable, Optional, Literal, Tuple, Dict
-from scipy.stats import qmc
+from .low_discrepancy import SobolSequence
 import time
 
 
@@ -34,6 +39,7 @@ class MonteCarloEngine:
         n_steps (int): Number of time steps
         variance_reduction (str): Variance reduction method
         seed (int): Random seed for reproducibility
+        precision (str): 'float64' or 'float32' compute mode
 
     Example:
         >>> mc = MonteCarloEngine(n_paths=100000, n_steps=252)
@@ -49,7 +55,8 @@ class MonteCarloEngine:
         n_paths: int = 100000,
         n_steps: int = 252,
         variance_reduction: Literal['none', 'antithetic', 'control', 'sobol'] = 'antithetic',
-        seed: Optional[int] = None
+        seed: Optional[int] = None,
+        precision: Literal['float64', 'float32'] = 'float64'
     ):
         """
         Initialize Monte Carlo engine.
@@ -59,15 +66,38 @@ class MonteCarloEngine:
             n_steps: Number of time steps per path
             variance_reduction: Variance reduction technique
             seed: Random seed for reproducibility
+            precision: 'float64' (default) or 'float32' for faster, lower
+                precision runs
         """
+        if precision not in ('float64', 'float32'):
+            raise ValueError(f"Unknown precision: {precision}")
+        if variance_reduction not in ('none', 'antithetic', 'control', 'sobol'):
+            raise ValueError(f"Unknown variance reduction: {variance_reduction}")
+        if int(n_paths) != n_paths or n_paths < 2:
+            raise ValueError("n_paths must be an integer of at least 2")
+        if int(n_steps) != n_steps or n_steps < 1:
+            raise ValueError("n_steps must be a positive integer")
+
         self.n_paths = n_paths
         self.n_steps = n_steps
         self.variance_reduction = variance_reduction
         self.seed = seed
+        self.precision = precision
+        self.dtype = np.dtype(precision)
 
         if seed is not None:
             np.random.seed(seed)
 
+        # The legacy global sampler only produces float64; float32 mode
+        # samples directly in single precision
+        self._rng = np.random.default_rng(seed) if precision == 'float32' else None
+
+        # Each simulation restarts the Sobol sequence at index 0, so repeated
+        # prices and bumped (S0 ± dS) runs share the same points; only the
+        # chunks of one price_with_statistics run continue the sequence
+        self._sobol: Optional[SobolSequence] = None
+        self._sobol_continue = False
+
     def simulate_gbm(
         self,
         S0: float,
@@ -93,23 +123,24 @@ class MonteCarloEngine:
 
         # Generate random numbers based on variance reduction method
         if self.variance_reduction == 'sobol':
-            Z = self._generate_sobol_normals()
+            Z = self._generate_sobol_normals().astype(self.dtype, copy=False)
         elif self.variance_reduction == 'antithetic':
-            # Generate half paths, then use antithetic variates
+            # Generate half paths, then use antithetic variates (plus one
+            # independent path when n_paths is odd)
             n_half = self.n_paths // 2
-            Z_half = np.random.standard_normal((n_half, self.n_steps))
-            Z = np.vstack([Z_half, -Z_half])
+            Z_half = self._standard_normal((n_half, self.n_steps))
+            Z = np.vstack([Z_half, -Z_half, self._standard_normal((self.n_paths % 2, self.n_steps))])
         else:
-            Z = np.random.standard_normal((self.n_paths, self.n_steps))
+            Z = self._standard_normal((self.n_paths, self.n_steps))
 
         # Initialize paths array
-        S = np.zeros((self.n_paths, self.n_steps + 1))
+        S = np.zeros((self.n_paths, self.n_steps + 1), dtype=self.dtype)
         S[:, 0] = S0
 
         # Vectorized path simulation
         # Using exact solution: S(t+dt) = S(t) * exp((mu - 0.5*sigma^2)*dt + sigma*sqrt(dt)*Z)
-        drift = (mu - 0.5 * sigma**2) * dt
-        diffusion = sigma * np.sqrt(dt)
+        drift = self.dtype.type((mu - 0.5 * sigma**2) * dt)
+        diffusion = self.dtype.type(sigma * np.sqrt(dt))
 
         for t in range(self.n_steps):
             S[:, t+1] = S[:, t] * np.exp(drift + diffusion * Z[:, t])
@@ -143,26 +174,30 @@ class MonteCarloEngine:
         # Generate random numbers based on variance reduction method
         if self.variance_reduction == 'sobol':
             # For terminal values, we only need 1D Sobol
-            sampler = qmc.Sobol(d=1, scramble=True, seed=self.seed)
-            sobol_uniform = sampler.random(n=self.n_paths)
-            from scipy.stats import norm
-            Z = norm.ppf(sobol_uniform).flatten()
+            Z = self._sobol_normals(dimension=1).ravel().astype(self.dtype, copy=False)
         elif self.variance_reduction == 'antithetic':
-            # Generate half paths, then use antithetic variates
+            # Generate half paths, then use antithetic variates (plus one
+            # independent path when n_paths is odd)
             n_half = self.n_paths // 2
-            Z_half = np.random.standard_normal(n_half)
-            Z = np.concatenate([Z_half, -Z_half])
+            Z_half = self._standard_normal(n_half)
+            Z = np.concatenate([Z_half, -Z_half, self._standard_normal(self.n_paths % 2)])
         else:
-            Z = np.random.standard_normal(self.n_paths)
+            Z = self._standard_normal(self.n_paths)
 
         # Exact terminal solution (fully vectorized, no loops!)
-        drift = (mu - 0.5 * sigma**2) * T
-        diffusion = sigma * np.sqrt(T)
+        drift = self.dtype.type((mu - 0.5 * sigma**2) * T)
+        diffusion = self.dtype.type(sigma * np.sqrt(T))
 
-        S_T = S0 * np.exp(drift + diffusion * Z)
+        S_T = self.dtype.type(S0) * np.exp(drift + diffusion * Z)
 
         return S_T
 
+    def _standard_normal(self, shape) -> np.ndarray:
+        """Standard normal draws in the configured precision."""
+        if self._rng is not None:
+            return self._rng.standard_normal(shape, dtype=np.float32)
+        return np.random.standard_normal(shape)
+
     def _generate_sobol_normals(self) -> np.ndarray:
         """
         Generate quasi-random normal variates using Sobol sequences.
@@ -173,17 +208,15 @@ class MonteCarloEngine:
         Returns:
             Array of shape (n_paths, n_steps) with quasi-random normals
         """
-        # Create Sobol sequence generator
-        sampler = qmc.Sobol(d=self.n_steps, scramble=True, seed=self.seed)
-
-        # Generate uniform Sobol samples
-        sobol_uniform = sampler.random(n=self.n_paths)
+        return self._sobol_normals(dimension=self.n_steps)
 
-        # Transform to standard normal using inverse CDF
-        from scipy.stats import norm
-        sobol_normal = norm.ppf(sobol_uniform)
-
-        return sobol_normal
+    def _sobol_normals(self, dimension: int) -> np.ndarray:
+        """n_paths points of the engine's Owen-scrambled Sobol sequence as normals."""
+        if self._sobol is None or self._sobol.dimension != dimension:
+            self._sobol = SobolSequence(dimension=dimension, seed=self.seed)
+        elif not self._sobol_continue:
+            self._sobol.reset()
+        return self._sobol.normal(self.n_paths)
 
     def price_european_option(
         self,
@@ -222,6 +255,9 @@ class MonteCarloEngine:
         else:  # put
             payoffs = np.maximum(K - S_T, 0)
 
+        # Accumulate in float64 so float32 mode loses precision only per path
+        payoffs = payoffs.astype(np.float64, copy=False)
+
         # Control variates adjustment (if selected)
         if self.variance_reduction == 'control':
             price_mc_raw = np.exp(-r * T) * np.mean(payoffs)
@@ -240,6 +276,109 @@ class MonteCarloEngine:
 
         return float(price)
 
+    def price_with_statistics(
+        self,
+        S0: float,
+        K: float,
+        T: float,
+        r: float,
+        sigma: float,
+        option_type: Literal['call', 'put'] = 'call',
+        q: float = 0.0,
+        chunk_size: int = 1_000_000,
+        prior: Optional['RunningStatistics'] = None,
+        prior_pairs: Optional['RunningStatistics'] = None
+    ) -> Dict[str, float]:
+        """
+        Price European option and summarize the discounted payoff distribution.
+
+        Paths are simulated in chunks and summarized with one-pass
+        (Welford/Chan) moments, so memory stays bounded for very large runs.
+        With n_paths ≤ chunk_size the price equals price_european_option.
+
+        Passing the statistics of an earlier run of the same option (warm
+        start) adds n_paths new paths to it. The engine's seed must differ
+        from the earlier run's so the new paths are independent.
+
+        The standard error matches the sampling scheme. Antithetic paths are
+        not independent, so the error comes from the pair means
+        (f(Z) + f(-Z)) / 2, which are: SE = std(pair means) / √(n_paths / 2).
+        Sobol points are not random draws at all, and a single scrambled
+        sequence gives no error estimate, so std_error is None.
+
+        Parameters:
+            S0, K, T, r, sigma, option_type, q: Option parameters
+            chunk_size: Paths per chunk
+            prior: Statistics of an earlier run to extend
+            prior_pairs: Pair-mean statistics of that run (antithetic only;
+                without them the pair variance comes from the new paths)
+
+        Returns:
+            Dictionary with 'price', 'std_error', 'std', 'skewness',
+            'kurtosis' (excess), 'jarque_bera' normality test, 'n_paths'
+            (including prior paths), 'state' (see RunningStatistics.to_dict)
+            and, for antithetic sampling, 'pair_state'
+        """
+        from .statistics import RunningStatistics
+
+        if prior is not None and self.variance_reduction == 'sobol':
+            raise ValueError("Warm start is not supported with Sobol sampling")
+
+        discount = np.exp(-r * T)
+        antithetic = self.variance_reduction == 'antithetic'
+        stats = RunningStatistics()
+        pairs = RunningStatistics()
+        if prior is not None:
+            stats.merge(prior)
+        if prior_pairs is not None and antithetic:
+            pairs.merge(prior_pairs)
+        total_paths = self.n_paths
+        if self._sobol is not None:
+            self._sobol.reset()
+
+        try:
+            self._sobol_continue = True
+            remaining = total_paths
+            while remaining > 0:
+                self.n_paths = min(chunk_size, remaining)
+                S_T = self.simulate_terminal_gbm(S0=S0, mu=r - q, sigma=sigma, T=T)
+                if option_type == 'call':
+                    payoffs = np.maximum(S_T - K, 0)
+                else:
+                    payoffs = np.maximum(K - S_T, 0)
+                discounted = discount * payoffs.astype(np.float64, copy=False)
+                stats.update(discounted)
+                if antithetic:
+                    # Paths [0, h) and [h, 2h) are mirror images (see simulate_terminal_gbm)
+                    n_half = self.n_paths // 2
+                    pairs.update(0.5 * (discounted[:n_half] + discounted[n_half:2 * n_half]))
+                remaining -= self.n_paths
+        finally:
+            self.n_paths = total_paths
+            self._sobol_continue = False
+
+        summary = stats.summary()
+        if self.variance_reduction == 'sobol':
+            std_error = None
+        elif antithetic and pairs.n > 1:
+            std_error = float(np.sqrt(pairs.variance / (summary['n'] / 2)))
+        else:
+            std_error = summary['std_error']
+
+        result = {
+            'price': summary['mean'],
+            'std_error': std_error,
+            'std': summary['std'],
+            'skewness': summary['skewness'],
+            'kurtosis': summary['kurtosis'],
+            'jarque_bera': stats.jarque_bera(),
+            'n_paths': summary['n'],
+            'state': stats.to_dict(),
+        }
+        if antithetic:
+            result['pair_state'] = pairs.to_dict()
+        return result
+
     def price_with_greeks(
         self,
         S0: float,
@@ -331,7 +470,8 @@ class MonteCarloEngine:
             'max_ms': float(np.max(times)),
             'n_paths': self.n_paths,
             'n_steps': self.n_steps,
-            'variance_reduction': self.variance_reduction
+            'variance_reduction': self.variance_reduction,
+            'precision': self.precision
         }
 
 
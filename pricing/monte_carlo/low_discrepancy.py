@@ -0,0 +1,138 @@
+"""
+Low-Discrepancy Sequences
+
+Sobol sequences with Owen (nested uniform) scrambling for quasi-Monte Carlo.
+
+Construction:
+------------
+Points come from scipy's unscrambled Sobol generator (Joe-Kuo direction
+numbers, up to 21201 dimensions) at 32-bit resolution. Each coordinate is
+then Owen-scrambled with the hash-based permutation of Laine & Karras as
+used by Burley (2020):
+
+    x' = reverse(LK(reverse(x), seed_d))
+
+LK flips each bit depending only on the bits above it, so it is a random
+nested uniform scramble. Each dimension d gets an independent seed_d from
+the sequence seed.
+
+Compared with scipy's linear matrix scramble, Owen scrambling keeps the
+(t, m, s)-net structure and gives unbiased estimates. For smooth integrands
+the error rate is close to O(n^{-3/2}). Points are centred in their 2^-32
+cell, so they never reach 0 or 1 and can be mapped to normals safely.
+"""
+
+import numpy as np
+from scipy.stats import norm, qmc
+from typing import Optional
+
+MAX_DIMENSION = 21201
+_BITS = 32
+_SCALE = float(2 ** _BITS)
+
+
+class SobolSequence:
+    """
+    Owen-scrambled Sobol sequence.
+
+    Attributes:
+        dimension (int): Number of coordinates per point
+        scramble (bool): Apply Owen scrambling
+        seed (int): Seed for the scrambling permutations
+        position (int): Index of the next point
+
+    Example:
+        >>> sobol = SobolSequence(dimension=252, seed=42)
+        >>> u = sobol.random(1024)       # (1024, 252) uniforms in (0, 1)
+        >>> z = sobol.normal(1024)       # next 1024 points as standard normals
+    """
+
+    def __init__(self, dimension: int, scramble: bool = True, seed: Optional[int] = None):
+        """
+        Initialize sequence.
+
+        Parameters:
+            dimension: Coordinates per point (1 to MAX_DIMENSION)
+            scramble: Apply Owen scrambling (False gives the raw sequence)
+            seed: Seed for the scrambling; None draws fresh entropy
+        """
+        if int(dimension) != dimension or not 1 <= dimension <= MAX_DIMENSION:
+            raise ValueError(f"dimension must be an integer between 1 and {MAX_DIMENSION}")
+
+        self.dimension = int(dimension)
+        self.scramble = scramble
+        self.seed = seed
+        self.position = 0
+
+        self._sobol = qmc.Sobol(d=self.dimension, scramble=False, bits=_BITS)
+        self._seeds = np.random.SeedSequence(seed).generate_state(self.dimension, dtype=np.uint32)
+
+    def random(self, n: int) -> np.ndarray:
+        """
+        Next n points as uniforms.
+
+        Parameters:
+            n: Number of points (powers of 2 keep the net balanced)
+
+        Returns:
+            Array of shape (n, dimension) in (0, 1)
+        """
+        raw = self._sobol.random(n)
+        self.position += n
+
+        x = (raw * _SCALE).astype(np.uint64).astype(np.uint32)
+        if self.scramble:
+            x = _reverse_bits(_laine_karras(_reverse_bits(x), self._seeds))
+
+        return (x.astype(np.float64) + 0.5) / _SCALE
+
+    def normal(self, n: int) -> np.ndarray:
+        """
+        Next n points mapped to standard normals by the inverse CDF.
+
+        Parameters:
+            n: Number of points
+
+        Returns:
+            Array of shape (n, dimension)
+        """
+        return norm.ppf(self.random(n))
+
+    def fast_forward(self, n: int) -> 'SobolSequence':
+        """
+        Skip n points (e.g. to give each worker its own block of the sequence).
+
+        Parameters:
+            n: Points to skip
+
+        Returns:
+            self, for chaining
+        """
+        self._sobol.fast_forward(n)
+        self.position += n
+        return self
+
+    def reset(self) -> 'SobolSequence':
+        """Restart from the first point with the same scrambling."""
+        self._sobol.reset()
+        self.position = 0
+        return self
+
+
+def _reverse_bits(x: np.ndarray) -> np.ndarray:
+    """Reverse the bits of each uint32."""
+    x = ((x >> 1) & np.uint32(0x55555555)) | ((x & np.uint32(0x55555555)) << 1)
+    x = ((x >> 2) & np.uint32(0x33333333)) | ((x & np.uint32(0x33333333)) << 2)
+    x = ((x >> 4) & np.uint32(0x0F0F0F0F)) | ((x & np.uint32(0x0F0F0F0F)) << 4)
+    x = ((x >> 8) & np.uint32(0x00FF00FF)) | ((x & np.uint32(0x00FF00FF)) << 8)
+    return (x >> 16) | (x << 16)
+
+
+def _laine_karras(x: np.ndarray, seed: np.ndarray) -> np.ndarray:
+    """Laine-Karras permutation: each bit is flipped based on lower bits only (wrapping uint32)."""
+    x = x + seed
+    x ^= x * np.uint32(0x6C50B47C)
+    x ^= x * np.uint32(0xB82F1E52)
+    x ^= x * np.uint32(0xC7AFE638)
+    x ^= x * np.uint32(0x8D22F6E6)
+    return x
@@ -0,0 +1,486 @@
+"""
+Simulation Output Statistics
+
+Summary statistics that scale to very large simulation outputs.
+
+One-Pass Moments:
+----------------
+RunningStatistics accumulates the count, mean and central moment sums
+M2, M3, M4 batch by batch and merges partial results with the pairwise
+update of Chan, Golub & LeVeque (Welford's update for single values). With
+δ = mean_b - mean_a and n = n_a + n_b:
+
+    M2 = M2_a + M2_b + δ² n_a n_b / n
+    M3 = M3_a + M3_b + δ³ n_a n_b (n_a - n_b) / n² + 3δ (n_a M2_b - n_b M2_a) / n
+    M4 = M4_a + M4_b + δ⁴ n_a n_b (n_a² - n_a n_b + n_b²) / n³
+         + 6δ² (n_a² M2_b + n_b² M2_a) / n² + 4δ (n_a M3_b - n_b M3_a) / n
+
+This avoids the catastrophic cancellation of E[x²] - E[x]² when the mean is
+large relative to the spread, and lets workers summarize their own chunks.
+The state (n, mean, M2, M3, M4) is small and serializable, so a finished
+run can later be extended with more samples (warm start).
+
+Normality Tests:
+---------------
+- Jarque-Bera:       JB = n/6 (S² + K²/4) ~ χ²(2) under normality, from the
+                     one-pass skewness S and excess kurtosis K
+- Anderson-Darling:  A² weighted towards the tails; needs the sorted sample,
+                     so large outputs are tested on a random subsample
+
+With millions of paths both tests reject trivial departures, so results
+also flag material non-normality by effect size (|S| > 0.5 or |K| > 1).
+
+Percentiles by Selection:
+------------------------
+np.percentile sorts the whole array (O(n log n)) to read a handful of
+order statistics. select_percentiles instead partitions around just the
+ranks it needs (introselect, O(n) per rank) and applies the same linear
+interpolation as np.percentile:
+
+    h = (n - 1) p / 100,   q_p = x_(⌊h⌋) + (h - ⌊h⌋) (x_(⌈h⌉) - x_(⌊h⌋))
+
+Threshold Queries:
+-----------------
+ThresholdQueries accumulates weighted counts and sums on each side of
+user thresholds as batches arrive, so tail probabilities and conditional
+means come back without returning raw samples:
+
+    P(X < t) = Σ w 1[x < t] / Σ w,    E[X | X < t] = Σ w x 1[x < t] / Σ w 1[x < t]
+
+(and likewise above t). Probability of loss, expected loss given loss and
+probability of beating a benchmark are all of this form.
+
+Weighted samples (e.g. post-stratified) place each sorted point at the
+midpoint of its cumulative weight, F_i = (Σ_{j<i} w_j + w_i / 2) / Σ w, and
+interpolate linearly between points.
+"""
+
+import numpy as np
+from scipy import stats as sp_stats
+from typing import Dict, List, Optional, Sequence
+
+MATERIAL_SKEWNESS = 0.5
+MATERIAL_KURTOSIS = 1.0
+
+
+class RunningStatistics:
+    """
+    Numerically stable one-pass mean, variance, skewness and kurtosis.
+
+    Attributes:
+        n (int): Number of observations
+        mean (float): Running mean
+
+    Example:
+        >>> stats = RunningStatistics()
+        >>> for chunk in chunks:
+        ...     stats.update(chunk)
+        >>> stats.summary()['skewness']
+    """
+
+    def __init__(self):
+        """Initialize empty accumulator."""
+        self.n = 0
+        self.mean = 0.0
+        self._m2 = 0.0
+        self._m3 = 0.0
+        self._m4 = 0.0
+
+    def update(self, values: np.ndarray) -> 'RunningStatistics':
+        """
+        Add a batch of observations.
+
+        Parameters:
+            values: Array of observations
+
+        Returns:
+            self, for chaining
+        """
+        x = np.asarray(values, dtype=np.float64).ravel()
+        if len(x) == 0:
+            return self
+
+        batch = RunningStatistics()
+        batch.n = len(x)
+        batch.mean = float(np.mean(x))
+        d = x - batch.mean
+        d2 = d * d
+        batch._m2 = float(np.sum(d2))
+        batch._m3 = float(np.sum(d2 * d))
+        batch._m4 = float(np.sum(d2 * d2))
+
+        return self.merge(batch)
+
+    def merge(self, other: 'RunningStatistics') -> 'RunningStatistics':
+        """
+        Merge another accumulator into this one (Chan et al. pairwise update).
+
+        Parameters:
+            other: Accumulator over a disjoint set of observations
+
+        Returns:
+            self, for chaining
+        """
+        if other.n == 0:
+            return self
+        if self.n == 0:
+            self.n, self.mean = other.n, other.mean
+            self._m2, self._m3, self._m4 = other._m2, other._m3, other._m4
+            return self
+
+        na, nb = float(self.n), float(other.n)
+        n = na + nb
+        delta = other.mean - self.mean
+        delta2 = delta * delta
+
+        m2 = self._m2 + other._m2 + delta2 * na * nb / n
+        m3 = (
+            self._m3 + other._m3
+            + delta * delta2 * na * nb * (na - nb) / (n * n)
+            + 3.0 * delta * (na * other._m2 - nb * self._m2) / n
+        )
+        m4 = (
+            self._m4 + other._m4
+            + delta2 * delta2 * na * nb * (na * na - na * nb + nb * nb) / (n ** 3)
+            + 6.0 * delta2 * (na * na * other._m2 + nb * nb * self._m2) / (n * n)
+            + 4.0 * delta * (na * other._m3 - nb * self._m3) / n
+        )
+
+        self.n = self.n + other.n
+        self.mean = self.mean + delta * nb / n
+        self._m2, self._m3, self._m4 = m2, m3, m4
+        return self
+
+    def to_dict(self) -> Dict[str, float]:
+        """Accumulator state for storage (see from_dict)."""
+        return {'n': self.n, 'mean': self.mean, 'm2': self._m2, 'm3': self._m3, 'm4': self._m4}
+
+    @classmethod
+    def from_dict(cls, state: Dict[str, float]) -> 'RunningStatistics':
+        """
+        Restore an accumulator saved with to_dict.
+
+        Parameters:
+            state: Dictionary with 'n', 'mean', 'm2', 'm3' and 'm4'
+
+        Returns:
+            RunningStatistics with that state
+        """
+        rs = cls()
+        rs.n = int(state['n'])
+        rs.mean = float(state['mean'])
+        rs._m2, rs._m3, rs._m4 = float(state['m2']), float(state['m3']), float(state['m4'])
+        return rs
+
+    @property
+    def variance(self) -> float:
+        """Sample variance (ddof=1)."""
+        return self._m2 / (self.n - 1) if self.n > 1 else 0.0
+
+    @property
+    def std(self) -> float:
+        """Sample standard deviation (ddof=1)."""
+        return float(np.sqrt(self.variance))
+
+    @property
+    def skewness(self) -> float:
+        """Population skewness g1 = √n M3 / M2^1.5 (matches scipy.stats.skew)."""
+        if self.n < 2 or self._m2 == 0:
+            return 0.0
+        return float(np.sqrt(self.n) * self._m3 / self._m2 ** 1.5)
+
+    @property
+    def kurtosis(self) -> float:
+        """Excess kurtosis g2 = n M4 / M2² - 3 (matches scipy.stats.kurtosis)."""
+        if self.n < 2 or self._m2 == 0:
+            return 0.0
+        return float(self.n * self._m4 / (self._m2 * self._m2) - 3.0)
+
+    def jarque_bera(self) -> Dict[str, any]:
+        """
+        Jarque-Bera normality test from the accumulated moments.
+
+        Returns:
+            Dictionary with statistic, p-value and normality flags
+        """
+        jb = self.n / 6.0 * (self.skewness ** 2 + self.kurtosis ** 2 / 4.0)
+        p_value = float(sp_stats.chi2.sf(jb, df=2))
+        return {
+            'statistic': float(jb),
+            'p_value': p_value,
+            'reject_normality_5pct': p_value < 0.05,
+            'materially_non_normal': _material(self.skewness, self.kurtosis),
+        }
+
+    def summary(self) -> Dict[str, float]:
+        """All statistics as a dictionary."""
+        return {
+            'n': self.n,
+            'mean': self.mean,
+            'std': self.std,
+            'std_error': self.std / np.sqrt(self.n) if self.n > 0 else 0.0,
+            'skewness': self.skewness,
+            'kurtosis': self.kurtosis,
+        }
+
+
+QUERY_TYPES = ('prob_below', 'prob_above', 'mean_below', 'mean_above')
+
+
+class ThresholdQueries:
+    """
+    Tail probabilities and conditional means accumulated batch by batch.
+
+    Attributes:
+        queries (List[dict]): Queries with 'type' (one of QUERY_TYPES),
+            'threshold' and an optional 'name'
+
+    Example:
+        >>> tq = ThresholdQueries([
+        ...     {'name': 'prob_loss', 'type': 'prob_below', 'threshold': 0.0},
+        ...     {'name': 'expected_loss_given_loss', 'type': 'mean_below', 'threshold': 0.0},
+        ... ])
+        >>> tq.update(returns).results()
+    """
+
+    def __init__(self, queries: List[Dict]):
+        """
+        Initialize accumulator.
+
+        Parameters:
+            queries: Threshold queries
+        """
+        for q in queries:
+            if q.get('type') not in QUERY_TYPES:
+                raise ValueError(f"Unknown query type: {q.get('type')}; choose from {QUERY_TYPES}")
+            if not isinstance(q.get('threshold'), (int, float)) or not np.isfinite(q['threshold']):
+                raise ValueError("Every query needs a finite numeric threshold")
+
+        self.queries = [
+            dict(q, name=q.get('name') or f"{q['type']}_{q['threshold']:g}") for q in queries
+        ]
+        if len({q['name'] for q in self.queries}) != len(self.queries):
+            raise ValueError("Query names must be unique")
+
+        self._total = 0.0
+        self._weight = np.zeros(len(self.queries))
+        self._sum = np.zeros(len(self.queries))
+
+    def update(self, values: np.ndarray, weights: Optional[np.ndarray] = None) -> 'ThresholdQueries':
+        """
+        Add a batch of outcomes.
+
+        Parameters:
+            values: Outcomes
+            weights: Weight per outcome (default 1)
+
+        Returns:
+            self, for chaining
+        """
+        x = np.asarray(values, dtype=np.float64).ravel()
+        w = np.ones_like(x) if weights is None else np.asarray(weights, dtype=np.float64).ravel()
+        if len(w) != len(x):
+            raise ValueError("weights must match values")
+
+        self._total += float(w.sum())
+        for i, q in enumerate(self.queries):
+            mask = x < q['threshold'] if q['type'].endswith('below') else x > q['threshold']
+            self._weight[i] += float(w[mask].sum())
+            self._sum[i] += float((w[mask] * x[mask]).sum())
+        return self
+
+    def results(self) -> Dict[str, Optional[float]]:
+        """
+        Query answers by name (None for a conditional mean with no outcomes on that side).
+
+        Returns:
+            Dictionary query name -> value
+        """
+        if self._total <= 0:
+            raise ValueError("No outcomes accumulated")
+
+        out = {}
+        for i, q in enumerate(self.queries):
+            if q['type'].startswith('prob'):
+                out[q['name']] = float(self._weight[i] / self._total)
+            else:
+                out[q['name']] = float(self._sum[i] / self._weight[i]) if self._weight[i] > 0 else None
+        return out
+
+
+def evaluate_threshold_queries(
+    queries: List[Dict],
+    outcomes: Dict[str, np.ndarray],
+    weights: Optional[np.ndarray] = None,
+    default_metric: Optional[str] = None
+) -> Dict[str, Optional[float]]:
+    """
+    Answer threshold queries against one of several outcome metrics each.
+
+    Parameters:
+        queries: Threshold queries, each with an optional 'metric' naming
+            the outcome array it applies to
+        outcomes: Metric name -> outcomes (e.g. 'total_return',
+            'annualized_return')
+        weights: Weight per outcome (default 1)
+        default_metric: Metric for queries without one (default: first key)
+
+    Returns:
+        Dictionary query name -> value
+    """
+    default_metric = default_metric or next(iter(outcomes))
+    by_metric: Dict[str, List[Dict]] = {}
+    for q in queries:
+        metric = q.get('metric', default_metric)
+        if metric not in outcomes:
+            raise ValueError(f"Unknown query metric: {metric}; choose from {sorted(outcomes)}")
+        by_metric.setdefault(metric, []).append(q)
+
+    results = {}
+    for metric, metric_queries in by_metric.items():
+        answered = ThresholdQueries(metric_queries).update(outcomes[metric], weights).results()
+        duplicate = set(answered) & set(results)
+        if duplicate:
+            raise ValueError(f"Query names must be unique: {sorted(duplicate)}")
+        results.update(answered)
+    return results
+
+
+def normality_tests(
+    samples: np.ndarray,
+    max_ad_samples: int = 100_000,
+    seed: Optional[int] = 0
+) -> Dict[str, any]:
+    """
+    Jarque-Bera and Anderson-Darling normality tests on a sample.
+
+    Parameters:
+        samples: 1D array of simulation outputs
+        max_ad_samples: Subsample size for Anderson-Darling (requires a sort)
+        seed: Seed for the subsample
+
+    Returns:
+        Dictionary with skewness, kurtosis, both test results and a
+        'materially_non_normal' flag
+    """
+    x = np.asarray(samples, dtype=np.float64).ravel()
+    if len(x) < 8:
+        raise ValueError("Need at least 8 samples for normality tests")
+
+    rs = RunningStatistics().update(x)
+    if rs.variance == 0:
+        raise ValueError("Normality tests need a sample with non-zero variance")
+
+    ad_sample = x
+    if len(x) > max_ad_samples:
+        ad_sample = np.random.default_rng(seed).choice(x, size=max_ad_samples, replace=False)
+    ad = sp_stats.anderson(ad_sample, dist='norm')
+    critical_5pct = float(ad.critical_values[list(ad.significance_level).index(5.0)])
+
+    return {
+        'n': rs.n,
+        'skewness': rs.skewness,
+        'kurtosis': rs.kurtosis,
+        'jarque_bera': rs.jarque_bera(),
+        'anderson_darling': {
+            'statistic': float(ad.statistic),
+            'critical_value_5pct': critical_5pct,
+            'reject_normality_5pct': bool(ad.statistic > critical_5pct),
+            'n_tested': len(ad_sample),
+        },
+        'materially_non_normal': _material(rs.skewness, rs.kurtosis),
+    }
+
+
+def _material(skewness: float, kurtosis: float) -> bool:
+    """Departure from normality large enough to make mean/std summaries misleading."""
+    return bool(abs(skewness) > MATERIAL_SKEWNESS or abs(kurtosis) > MATERIAL_KURTOSIS)
+
+
+def select_percentiles(samples: np.ndarray, percentiles: Sequence[float]) -> np.ndarray:
+    """
+    Percentiles of a 1D sample without a full sort.
+
+    Matches np.percentile(samples, percentiles) with linear interpolation.
+
+    Parameters:
+        samples: 1D array of samples
+        percentiles: Percentiles in [0, 100]
+
+    Returns:
+        Array of percentile values in the order requested
+    """
+    x = np.asarray(samples).ravel()
+    n = len(x)
+    if n == 0:
+        raise ValueError("Cannot compute percentiles of an empty sample")
+
+    p = np.asarray(percentiles, dtype=float)
+    if np.any((p < 0) | (p > 100)):
+        raise ValueError("Percentiles must be in [0, 100]")
+
+    h = (n - 1) * p / 100.0
+    lo = np.floor(h).astype(int)
+    hi = np.ceil(h).astype(int)
+
+    kth: List[int] = sorted(set(lo.tolist()) | set(hi.tolist()))
+    partitioned = np.partition(x, kth)
+
+    x_lo = partitioned[lo].astype(float)
+    x_hi = partitioned[hi].astype(float)
+    return x_lo + (h - lo) * (x_hi - x_lo)
+
+
+def weighted_percentiles(
+    samples: np.ndarray,
+    weights: np.ndarray,
+    percentiles: Sequence[float]
+) -> np.ndarray:
+    """
+    Percentiles of a weighted 1D sample.
+
+    With equal weights this approaches np.percentile as n grows.
+
+    Parameters:
+        samples: 1D array of samples
+        weights: Non-negative weight per sample
+        percentiles: Percentiles in [0, 100]
+
+    Returns:
+        Array of percentile values in the order requested
+    """
+    x = np.asarray(samples, dtype=np.float64).ravel()
+    w = np.asarray(weights, dtype=np.float64).ravel()
+    if len(x) == 0 or len(x) != len(w):
+        raise ValueError("Need a non-empty sample with one weight per value")
+    if np.any(w < 0) or w.sum() <= 0:
+        raise ValueError("Weights must be non-negative with a positive sum")
+
+    p = np.asarray(percentiles, dtype=float)
+    if np.any((p < 0) | (p > 100)):
+        raise ValueError("Percentiles must be in [0, 100]")
+
+    order = np.argsort(x, kind='stable')
+    x, w = x[order], w[order]
+    cdf = (np.cumsum(w) - 0.5 * w) / w.sum()
+    return np.interp(p / 100.0, cdf, x)
+
+
+def horizon_index(times: np.ndarray, horizon: float) -> int:
+    """
+    Column of a simulated path grid at a horizon.
+
+    Parameters:
+        times: Path time grid in years (starting at 0)
+        horizon: Horizon in years; must lie on the grid
+
+    Returns:
+        Index into the time axis
+    """
+    times = np.asarray(times, dtype=float)
+    if not 0 < horizon <= times[-1] + 1e-9:
+        raise ValueError(f"Horizon {horizon:g} is outside the simulated 0-{times[-1]:g} years")
+    index = int(np.argmin(np.abs(times - horizon)))
+    if abs(times[index] - horizon) > 1e-6:
+        raise ValueError(f"Horizon {horizon:g} is not on the simulation grid (step {times[1] - times[0]:g} years)")
+    return index
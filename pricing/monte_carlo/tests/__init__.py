@@ -0,0 +1 @@
+"""Tests for Monte Carlo simulation."""
@@ -0,0 +1,78 @@
+"""
+Tests for the Monte Carlo engine's payoff statistics.
+
+Tests include:
+- Antithetic standard error comes from the pair means, across chunks
+- Antithetic error is below the iid error for a monotone payoff
+- Sobol pricing reports no standard error
+- Each Sobol simulation restarts the sequence, so repeated prices match and
+  finite-difference gamma matches Black-Scholes
+"""
+
+import pytest
+import numpy as np
+from pricing.monte_carlo import MonteCarloEngine, RunningStatistics
+from pricing.options.black_scholes import BlackScholes
+
+
+class TestPriceWithStatistics:
+    """Test the standard error reported for each sampling scheme."""
+
+    def test_antithetic_error_from_pair_means(self):
+        """The error is the std of the pair means over √(n/2)."""
+        n = 2 ** 12
+        mc = MonteCarloEngine(n_paths=n, n_steps=1, variance_reduction='antithetic', seed=42)
+        priced = mc.price_with_statistics(S0=100, K=100, T=1.0, r=0.05, sigma=0.2, chunk_size=n // 4)
+
+        pairs = RunningStatistics.from_dict(priced['pair_state'])
+        assert pairs.n == n // 2
+        assert pairs.mean == pytest.approx(priced['price'], rel=1e-12)
+        assert priced['std_error'] == pytest.approx(pairs.std / np.sqrt(n / 2), rel=1e-12)
+
+    def test_antithetic_error_below_iid_error(self):
+        """A call payoff is monotone in Z, so mirrored paths are negatively correlated."""
+        mc = MonteCarloEngine(n_paths=2 ** 14, n_steps=1, variance_reduction='antithetic', seed=42)
+        priced = mc.price_with_statistics(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+        assert priced['std_error'] < priced['std'] / np.sqrt(priced['n_paths'])
+
+    def test_sobol_has_no_standard_error(self):
+        """One scrambled sequence gives no error estimate."""
+        mc = MonteCarloEngine(n_paths=2 ** 12, n_steps=1, variance_reduction='sobol', seed=42)
+        priced = mc.price_with_statistics(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+        assert priced['std_error'] is None
+        assert 'pair_state' not in priced
+
+
+class TestSobolRestart:
+    """Test that each Sobol simulation starts from the first point."""
+
+    def test_repeated_prices_match(self):
+        """Two prices from one engine use the same points."""
+        mc = MonteCarloEngine(n_paths=2 ** 12, n_steps=1, variance_reduction='sobol', seed=42)
+        first = mc.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+        second = mc.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+        assert second == first
+
+    def test_same_seed_engines_match(self):
+        """Engines with the same seed price identically regardless of history."""
+        used = MonteCarloEngine(n_paths=2 ** 12, n_steps=1, variance_reduction='sobol', seed=7)
+        used.price_european_option(S0=90, K=100, T=0.5, r=0.03, sigma=0.3)
+        fresh = MonteCarloEngine(n_paths=2 ** 12, n_steps=1, variance_reduction='sobol', seed=7)
+        assert used.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2) == \
+            fresh.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+
+    def test_chunks_continue_the_sequence(self):
+        """Chunked statistics price equals the single-chunk price."""
+        n = 2 ** 12
+        mc = MonteCarloEngine(n_paths=n, n_steps=1, variance_reduction='sobol', seed=42)
+        whole = mc.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+        chunked = mc.price_with_statistics(S0=100, K=100, T=1.0, r=0.05, sigma=0.2, chunk_size=n // 4)
+        assert chunked['price'] == pytest.approx(whole, rel=1e-12)
+
+    def test_gamma_matches_black_scholes(self):
+        """Bumped runs share common points, so the finite difference is stable."""
+        mc = MonteCarloEngine(n_paths=2 ** 16, n_steps=1, variance_reduction='sobol', seed=42)
+        greeks = mc.price_with_greeks(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+        bs = BlackScholes(S=100, K=100, T=1.0, r=0.05, sigma=0.2, option_type='call')
+        assert greeks['delta'] == pytest.approx(bs.delta(), rel=0.01)
+        assert greeks['gamma'] == pytest.approx(bs.gamma(), rel=0.05)
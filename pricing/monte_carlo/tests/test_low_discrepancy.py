@@ -0,0 +1,78 @@
+"""
+Tests for Owen-scrambled Sobol sequences.
+
+Tests include:
+- Points stay strictly inside (0, 1)
+- Scrambling preserves the net property (one point per elementary interval)
+- Reproducibility from the seed, and fast_forward matches sequential draws
+- Quasi-random normals price a European call close to Black-Scholes
+"""
+
+import pytest
+import numpy as np
+from pricing.monte_carlo import MonteCarloEngine
+from pricing.monte_carlo.low_discrepancy import SobolSequence, MAX_DIMENSION
+from pricing.options.black_scholes import BlackScholes
+
+
+class TestSobolSequence:
+    """Test sequence construction and scrambling."""
+
+    def test_open_unit_interval(self):
+        """Uniforms never hit 0 or 1."""
+        u = SobolSequence(dimension=5, seed=1).random(1024)
+        assert u.shape == (1024, 5)
+        assert np.all(u > 0) and np.all(u < 1)
+
+    @pytest.mark.parametrize('scramble', [True, False])
+    def test_stratification_preserved(self, scramble):
+        """2^m points put exactly one point in each 1/2^m interval per dimension."""
+        u = SobolSequence(dimension=8, scramble=scramble, seed=3).random(256)
+        for d in range(8):
+            counts = np.bincount((u[:, d] * 256).astype(int), minlength=256)
+            assert np.all(counts == 1)
+
+    def test_two_dimensional_net(self):
+        """Scrambled points keep the (0, m, 2)-net property in the first two dimensions."""
+        u = SobolSequence(dimension=2, seed=11).random(64)
+        cells = (u[:, 0] * 8).astype(int) * 8 + (u[:, 1] * 8).astype(int)
+        assert len(np.unique(cells)) == 64
+
+    def test_seed_reproducible(self):
+        """Same seed gives the same points; different seeds differ."""
+        a = SobolSequence(dimension=4, seed=7).random(128)
+        b = SobolSequence(dimension=4, seed=7).random(128)
+        c = SobolSequence(dimension=4, seed=8).random(128)
+        np.testing.assert_array_equal(a, b)
+        assert not np.allclose(a, c)
+
+    def test_fast_forward(self):
+        """Skipping points matches drawing them."""
+        sequential = SobolSequence(dimension=3, seed=5)
+        sequential.random(512)
+        skipped = SobolSequence(dimension=3, seed=5).fast_forward(512)
+        np.testing.assert_array_equal(sequential.random(64), skipped.random(64))
+        assert skipped.position == 576
+
+    def test_invalid_dimension(self):
+        with pytest.raises(ValueError):
+            SobolSequence(dimension=0)
+        with pytest.raises(ValueError):
+            SobolSequence(dimension=MAX_DIMENSION + 1)
+
+
+class TestQuasiMonteCarloPricing:
+    """Test the engine's Sobol mode."""
+
+    def test_european_call_near_black_scholes(self):
+        mc = MonteCarloEngine(n_paths=2 ** 16, n_steps=1, variance_reduction='sobol', seed=42)
+        price = mc.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2)
+        bs = BlackScholes(S=100, K=100, T=1.0, r=0.05, sigma=0.2).price()
+        assert price == pytest.approx(bs, abs=0.02)
+
+    def test_chunks_continue_the_sequence(self):
+        """Chunked pricing draws new points per chunk instead of repeating them."""
+        mc = MonteCarloEngine(n_paths=2 ** 14, n_steps=1, variance_reduction='sobol', seed=42)
+        chunked = mc.price_with_statistics(S0=100, K=100, T=1.0, r=0.05, sigma=0.2, chunk_size=2 ** 12)
+        whole = MonteCarloEngine(n_paths=2 ** 14, n_steps=1, variance_reduction='sobol', seed=42)
+        assert chunked['price'] == pytest.approx(whole.price_european_option(S0=100, K=100, T=1.0, r=0.05, sigma=0.2), rel=1e-12)
@@ -0,0 +1,174 @@
+"""
+Tests for one-pass simulation statistics.
+
+Tests include:
+- Agreement with NumPy/SciPy on a single batch
+- Chunked updates and parallel merges match a single pass
+- Saved state restores an accumulator that can keep growing
+- Stability for large means (no catastrophic cancellation)
+- Selection-based percentiles match np.percentile
+- Normality tests accept normal and reject skewed samples
+- Threshold queries match direct computation, in batches and with weights
+"""
+
+import pytest
+import numpy as np
+from scipy import stats
+from pricing.monte_carlo.statistics import (
+    RunningStatistics, ThresholdQueries, evaluate_threshold_queries, normality_tests, select_percentiles
+)
+
+
+@pytest.fixture
+def samples():
+    return np.random.default_rng(7).lognormal(mean=0.0, sigma=0.8, size=50_000)
+
+
+class TestRunningStatistics:
+    """Test one-pass moments against reference implementations."""
+
+    def test_single_batch_matches_reference(self, samples):
+        """One update matches numpy/scipy."""
+        rs = RunningStatistics().update(samples)
+        assert rs.n == len(samples)
+        assert rs.mean == pytest.approx(np.mean(samples), rel=1e-12)
+        assert rs.std == pytest.approx(np.std(samples, ddof=1), rel=1e-10)
+        assert rs.skewness == pytest.approx(stats.skew(samples), rel=1e-8)
+        assert rs.kurtosis == pytest.approx(stats.kurtosis(samples), rel=1e-8)
+
+    def test_chunked_updates_match_single_pass(self, samples):
+        """Uneven chunks give the same moments as one batch."""
+        chunked = RunningStatistics()
+        for chunk in np.array_split(samples, [7, 1000, 1001, 30_000]):
+            chunked.update(chunk)
+
+        single = RunningStatistics().update(samples)
+        assert chunked.mean == pytest.approx(single.mean, rel=1e-12)
+        assert chunked.variance == pytest.approx(single.variance, rel=1e-10)
+        assert chunked.skewness == pytest.approx(single.skewness, rel=1e-8)
+        assert chunked.kurtosis == pytest.approx(single.kurtosis, rel=1e-8)
+
+    def test_merge_of_worker_results(self, samples):
+        """Merging per-worker accumulators matches a single pass."""
+        workers = [RunningStatistics().update(part) for part in np.array_split(samples, 4)]
+        merged = RunningStatistics()
+        for worker in workers:
+            merged.merge(worker)
+
+        assert merged.n == len(samples)
+        assert merged.skewness == pytest.approx(stats.skew(samples), rel=1e-8)
+        assert merged.kurtosis == pytest.approx(stats.kurtosis(samples), rel=1e-8)
+
+    def test_state_round_trip_resumes(self, samples):
+        """A restored accumulator extended with new data matches a single pass."""
+        first, second = samples[:20_000], samples[20_000:]
+        restored = RunningStatistics.from_dict(RunningStatistics().update(first).to_dict())
+        restored.update(second)
+
+        full = RunningStatistics().update(samples)
+        assert restored.n == full.n
+        assert restored.mean == pytest.approx(full.mean, rel=1e-12)
+        assert restored.variance == pytest.approx(full.variance, rel=1e-10)
+        assert restored.kurtosis == pytest.approx(full.kurtosis, rel=1e-8)
+
+    def test_large_mean_is_stable(self):
+        """Variance survives a mean far larger than the spread."""
+        x = 1e9 + np.random.default_rng(1).standard_normal(100_000)
+        rs = RunningStatistics()
+        for chunk in np.array_split(x, 10):
+            rs.update(chunk)
+        assert rs.std == pytest.approx(np.std(x - 1e9, ddof=1), rel=1e-6)
+
+    def test_empty(self):
+        """Empty accumulator reports zeros."""
+        rs = RunningStatistics().update(np.array([]))
+        assert rs.n == 0
+        assert rs.variance == 0.0
+        assert rs.skewness == 0.0
+
+
+class TestSelectPercentiles:
+    """Test selection-based percentiles."""
+
+    def test_matches_numpy(self, samples):
+        """Linear interpolation matches np.percentile."""
+        p = [0, 1, 5, 25, 50, 75, 95, 99, 100]
+        np.testing.assert_allclose(select_percentiles(samples, p), np.percentile(samples, p))
+
+    def test_small_sample(self):
+        """Interpolates between neighbouring order statistics."""
+        assert select_percentiles([4.0, 1.0, 3.0, 2.0], [50])[0] == pytest.approx(2.5)
+
+    def test_invalid_percentile(self):
+        """Percentiles outside [0, 100] are rejected."""
+        with pytest.raises(ValueError):
+            select_percentiles([1.0, 2.0], [101])
+
+
+class TestNormality:
+    """Test Jarque-Bera and Anderson-Darling reporting."""
+
+    def test_normal_sample_not_material(self):
+        """A normal sample is not flagged as materially non-normal."""
+        x = np.random.default_rng(3).standard_normal(20_000)
+        result = normality_tests(x)
+        assert not result['materially_non_normal']
+        assert result['jarque_bera']['p_value'] > 0.001
+
+    def test_lognormal_sample_rejected(self, samples):
+        """A skewed sample is rejected by both tests and flagged."""
+        result = normality_tests(samples)
+        assert result['jarque_bera']['reject_normality_5pct']
+        assert result['anderson_darling']['reject_normality_5pct']
+        assert result['materially_non_normal']
+
+    def test_jarque_bera_matches_scipy(self, samples):
+        """One-pass JB statistic matches scipy."""
+        rs = RunningStatistics().update(samples)
+        assert rs.jarque_bera()['statistic'] == pytest.approx(stats.jarque_bera(samples).statistic, rel=1e-8)
+
+    def test_anderson_darling_subsample(self, samples):
+        """Large samples are subsampled for Anderson-Darling."""
+        result = normality_tests(samples, max_ad_samples=1000)
+        assert result['anderson_darling']['n_tested'] == 1000
+
+
+class TestThresholdQueries:
+    """Test tail probabilities and conditional means."""
+
+    def test_batches_match_direct(self, samples):
+        returns = samples - 1.0
+        tq = ThresholdQueries([
+            {'name': 'prob_loss', 'type': 'prob_below', 'threshold': 0.0},
+            {'name': 'expected_loss_given_loss', 'type': 'mean_below', 'threshold': 0.0},
+            {'name': 'prob_beat', 'type': 'prob_above', 'threshold': 0.5},
+        ])
+        for chunk in np.array_split(returns, 7):
+            tq.update(chunk)
+        result = tq.results()
+
+        assert result['prob_loss'] == pytest.approx(np.mean(returns < 0))
+        assert result['expected_loss_given_loss'] == pytest.approx(np.mean(returns[returns < 0]))
+        assert result['prob_beat'] == pytest.approx(np.mean(returns > 0.5))
+
+    def test_weights(self):
+        result = ThresholdQueries([{'name': 'p', 'type': 'prob_below', 'threshold': 0.0}]) \
+            .update([-1.0, 1.0], weights=[3.0, 1.0]).results()
+        assert result['p'] == pytest.approx(0.75)
+
+    def test_empty_side_is_none(self):
+        result = ThresholdQueries([{'name': 'm', 'type': 'mean_below', 'threshold': -10.0}]).update([0.0, 1.0]).results()
+        assert result['m'] is None
+
+    def test_metric_routing(self):
+        outcomes = {'a': np.array([-1.0, 1.0]), 'b': np.array([1.0, 2.0])}
+        result = evaluate_threshold_queries(
+            [{'name': 'on_a', 'type': 'prob_below', 'threshold': 0.0},
+             {'name': 'on_b', 'type': 'prob_below', 'threshold': 0.0, 'metric': 'b'}],
+            outcomes
+        )
+        assert result == {'on_a': 0.5, 'on_b': 0.0}
+
+    def test_invalid_type(self):
+        with pytest.raises(ValueError):
+            ThresholdQueries([{'type': 'prob_between', 'threshold': 0.0}])
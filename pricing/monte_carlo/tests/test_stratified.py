@@ -0,0 +1,85 @@
+"""
+Tests for stratified scenario simulation.
+
+Tests include:
+- Allocation methods respect the total and the per-bucket minimum
+- Post-stratification weights recover the mixture mean for any allocation
+- Neyman allocation does not increase the standard error
+- One run summarizes several horizons
+- Weighted percentiles agree with np.percentile for equal weights
+"""
+
+import pytest
+import numpy as np
+from pricing.monte_carlo import ScenarioBucket, StratifiedSimulator, weighted_percentiles
+
+
+@pytest.fixture
+def buckets():
+    return [
+        ScenarioBucket('recession', 0.2, mu=-0.10, sigma=0.30),
+        ScenarioBucket('base', 0.6, mu=0.07, sigma=0.15),
+        ScenarioBucket('boom', 0.2, mu=0.15, sigma=0.20),
+    ]
+
+
+def mixture_mean(buckets, T):
+    return sum(b.probability * np.exp(b.mu * T) for b in buckets)
+
+
+class TestStratifiedSimulator:
+    """Test allocation and post-stratified estimates."""
+
+    @pytest.mark.parametrize('allocation', ['proportional', 'equal', 'neyman'])
+    def test_allocation_totals(self, buckets, allocation):
+        counts = StratifiedSimulator(buckets, n_paths=10_001, allocation=allocation).allocate(T=5)
+        assert sum(counts.values()) == 10_001
+        assert min(counts.values()) >= 1000
+
+    @pytest.mark.parametrize('allocation', ['proportional', 'equal', 'neyman'])
+    def test_mixture_mean_recovered(self, buckets, allocation):
+        sim = StratifiedSimulator(buckets, n_paths=200_000, allocation=allocation, seed=1)
+        paths = sim.simulate(T=5)
+        assert paths['weights'].sum() == pytest.approx(1.0)
+        result = sim.summarize(paths)
+        assert result['mean'] == pytest.approx(mixture_mean(buckets, 5), abs=4 * result['std_error'])
+
+    def test_neyman_reduces_standard_error(self, buckets):
+        se = {}
+        for allocation in ('proportional', 'neyman'):
+            sim = StratifiedSimulator(buckets, n_paths=100_000, allocation=allocation, seed=3)
+            se[allocation] = sim.summarize(sim.simulate(T=5))['std_error']
+        assert se['neyman'] <= se['proportional'] * 1.02
+
+    def test_horizon_summaries(self, buckets):
+        sim = StratifiedSimulator(buckets, n_paths=100_000, seed=5)
+        paths = sim.simulate(T=5)
+        by_horizon = sim.horizon_summaries(paths, [1, 3, 5])
+
+        assert list(by_horizon) == ['1', '3', '5']
+        assert by_horizon['5']['mean'] == pytest.approx(sim.summarize(paths)['mean'])
+        for h in (1, 3):
+            summary = by_horizon[str(h)]
+            assert summary['mean'] == pytest.approx(mixture_mean(buckets, h), abs=4 * summary['std_error'])
+
+    def test_horizon_off_grid(self, buckets):
+        sim = StratifiedSimulator(buckets, n_paths=10_000, seed=5)
+        with pytest.raises(ValueError):
+            sim.summarize(sim.simulate(T=5), horizon=2.5)
+
+    def test_invalid_probabilities(self, buckets):
+        buckets[0].probability = 0.5
+        with pytest.raises(ValueError):
+            StratifiedSimulator(buckets)
+
+
+class TestWeightedPercentiles:
+    """Test weighted percentiles."""
+
+    def test_equal_weights_match_numpy(self):
+        x = np.random.default_rng(0).standard_normal(100_000)
+        result = weighted_percentiles(x, np.ones_like(x), [5, 50, 95])
+        np.testing.assert_allclose(result, np.percentile(x, [5, 50, 95]), atol=1e-3)
+
+    def test_weights_shift_median(self):
+        assert weighted_percentiles([0.0, 1.0], [1.0, 3.0], [50])[0] > 0.5
@@ -0,0 +1,5 @@
+"""Interest rate models module."""
+from .yield_curve import YieldCurve
+from .short_rate import ShortRateParams, InflationParams, RateInflationSimulator
+
+__all__ = ['YieldCurve', 'ShortRateParams', 'InflationParams', 'RateInflationSimulator']
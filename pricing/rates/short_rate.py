@@ -0,0 +1,374 @@
+"""
+Interest Rate and Inflation Scenario Generator
+
+Simulates correlated short-rate, inflation and (optionally) asset return
+paths so nominal outcomes can be restated in real terms.
+
+Models:
+------
+Short rate (Vasicek or CIR):
+    dr_t = κ_r (θ_r - r_t) dt + σ_r r_t^γ dW_r,     γ = 0 (Vasicek), ½ (CIR)
+
+Inflation rate (Ornstein-Uhlenbeck):
+    dπ_t = κ_π (θ_π - π_t) dt + σ_π dW_π
+
+Asset (GBM, optional):
+    dS_t / S_t = μ dt + σ_S dW_S
+
+with Corr(dW_r, dW_π, dW_S) given by a correlation matrix. The price index
+is CPI_t = exp(∫ π_s ds) and real asset value is S_t / CPI_t. CIR uses
+full-truncation Euler so the rate stays non-negative.
+
+Scenario blending:
+-----------------
+Runs under several configurations (e.g. 60% base, 30% downturn, 10%
+crisis) are combined into one mixture distribution by weighting each path
+of scenario h by p_h / n_h.
+"""
+
+import numpy as np
+from dataclasses import dataclass
+from typing import Dict, List, Optional
+
+from ..monte_carlo.statistics import (
+    evaluate_threshold_queries, horizon_index, normality_tests, select_percentiles, weighted_percentiles
+)
+
+
+@dataclass
+class ShortRateParams:
+    """
+    Mean-reverting short-rate parameters.
+
+    Attributes:
+        r0: Initial rate
+        kappa: Mean reversion speed
+        theta: Long-run mean
+        sigma: Volatility
+        model: 'vasicek' or 'cir'
+    """
+    r0: float = 0.04
+    kappa: float = 0.3
+    theta: float = 0.035
+    sigma: float = 0.01
+    model: str = 'vasicek'
+
+    def __post_init__(self):
+        if self.model not in ('vasicek', 'cir'):
+            raise ValueError(f"Unknown short rate model: {self.model}")
+        if self.kappa <= 0 or self.sigma < 0:
+            raise ValueError("kappa must be positive and sigma non-negative")
+        if self.model == 'cir' and (self.r0 < 0 or self.theta <= 0):
+            raise ValueError("CIR requires r0 ≥ 0 and theta > 0")
+
+
+@dataclass
+class InflationParams:
+    """
+    Mean-reverting inflation rate parameters.
+
+    Attributes:
+        pi0: Initial annual inflation
+        kappa: Mean reversion speed
+        theta: Long-run inflation
+        sigma: Volatility
+    """
+    pi0: float = 0.03
+    kappa: float = 0.5
+    theta: float = 0.025
+    sigma: float = 0.01
+
+    def __post_init__(self):
+        if self.kappa <= 0 or self.sigma < 0:
+            raise ValueError("kappa must be positive and sigma non-negative")
+
+
+class RateInflationSimulator:
+    """
+    Joint simulation of short rates, inflation and asset values.
+
+    Attributes:
+        rate (ShortRateParams): Short rate model
+        inflation (InflationParams): Inflation model
+        correlation (np.ndarray): 3x3 correlation of (rate, inflation, asset) shocks
+        n_paths (int): Number of paths
+        n_steps (int): Time steps per year
+        seed (int): Random seed
+
+    Example:
+        >>> sim = RateInflationSimulator(ShortRateParams(model='cir'), InflationParams())
+        >>> paths = sim.simulate(T=10, asset_mu=0.10, asset_sigma=0.20)
+        >>> summary = sim.real_outcomes(paths)
+    """
+
+    def __init__(
+        self,
+        rate: ShortRateParams,
+        inflation: InflationParams,
+        correlation: Optional[np.ndarray] = None,
+        n_paths: int = 10000,
+        n_steps: int = 12,
+        seed: Optional[int] = None
+    ):
+        """
+        Initialize simulator.
+
+        Parameters:
+            rate: Short rate parameters
+            inflation: Inflation parameters
+            correlation: 3x3 shock correlation matrix (default: identity)
+            n_paths: Number of simulated paths
+            n_steps: Time steps per year
+            seed: Random seed for reproducibility
+        """
+        self.rate = rate
+        self.inflation = inflation
+        self.correlation = np.eye(3) if correlation is None else np.asarray(correlation, dtype=float)
+        self.n_paths = n_paths
+        self.n_steps = n_steps
+        self.seed = seed
+
+        if self.correlation.shape != (3, 3) or not np.allclose(self.correlation, self.correlation.T):
+            raise ValueError("correlation must be a symmetric 3x3 matrix")
+        try:
+            self._cholesky = np.linalg.cholesky(self.correlation)
+        except np.linalg.LinAlgError:
+            raise ValueError("correlation matrix must be positive definite")
+
+    def simulate(
+        self,
+        T: float = 10.0,
+        asset_mu: Optional[float] = None,
+        asset_sigma: Optional[float] = None,
+        initial_value: float = 1.0
+    ) -> Dict[str, np.ndarray]:
+        """
+        Simulate correlated paths.
+
+        Parameters:
+            T: Horizon in years
+            asset_mu: Asset drift (omit to skip asset paths)
+            asset_sigma: Asset volatility
+            initial_value: Initial asset value
+
+        Returns:
+            Dictionary of arrays shaped (n_paths, n_points): 'short_rate',
+            'inflation', 'cpi', and when an asset is simulated 'nominal_value'
+            and 'real_value'; plus 'times'
+        """
+        n_points = int(round(T * self.n_steps))
+        if n_points < 1:
+            raise ValueError("Horizon too short for the step size")
+        dt = 1.0 / self.n_steps
+
+        rng = np.random.default_rng(self.seed)
+        shocks = rng.standard_normal((n_points, self.n_paths, 3)) @ self._cholesky.T * np.sqrt(dt)
+
+        r = np.empty((self.n_paths, n_points + 1))
+        pi = np.empty((self.n_paths, n_points + 1))
+        r[:, 0] = self.rate.r0
+        pi[:, 0] = self.inflation.pi0
+
+        for t in range(n_points):
+            r_prev = r[:, t]
+            if self.rate.model == 'cir':
+                r_pos = np.maximum(r_prev, 0.0)
+                r[:, t + 1] = np.maximum(
+                    r_prev + self.rate.kappa * (self.rate.theta - r_pos) * dt
+                    + self.rate.sigma * np.sqrt(r_pos) * shocks[t, :, 0],
+                    0.0
+                )
+            else:
+                r[:, t + 1] = r_prev + self.rate.kappa * (self.rate.theta - r_prev) * dt + self.rate.sigma * shocks[t, :, 0]
+
+            pi[:, t + 1] = (
+                pi[:, t] + self.inflation.kappa * (self.inflation.theta - pi[:, t]) * dt
+                + self.inflation.sigma * shocks[t, :, 1]
+            )
+
+        cpi = np.exp(np.concatenate([np.zeros((self.n_paths, 1)), np.cumsum(pi[:, :-1] * dt, axis=1)], axis=1))
+
+        paths = {
+            'times': np.arange(n_points + 1) * dt,
+            'short_rate': r,
+            'inflation': pi,
+            'cpi': cpi,
+        }
+
+        if asset_mu is not None:
+            if asset_sigma is None or asset_sigma < 0:
+                raise ValueError("asset_sigma must be given and non-negative")
+            log_increments = (asset_mu - 0.5 * asset_sigma ** 2) * dt + asset_sigma * shocks[:, :, 2].T
+            nominal = initial_value * np.exp(
+                np.concatenate([np.zeros((self.n_paths, 1)), np.cumsum(log_increments, axis=1)], axis=1)
+            )
+            paths['nominal_value'] = nominal
+            paths['real_value'] = nominal / cpi
+
+        return paths
+
+    @staticmethod
+    def real_outcomes(
+        paths: Dict[str, np.ndarray],
+        percentiles=(5, 25, 50, 75, 95),
+        queries: Optional[List[Dict]] = None,
+        horizon: Optional[float] = None
+    ) -> Dict[str, any]:
+        """
+        Summarize nominal vs real outcomes at the end of the run or at a horizon.
+
+        Parameters:
+            paths: Output of simulate()
+            percentiles: Percentiles to report
+            queries: Threshold queries (see ThresholdQueries) on the asset's
+                'nominal_total_return' (default), 'real_total_return',
+                'nominal_annualized_return' or 'real_annualized_return'
+            horizon: Years at which to summarize (default: end of the run)
+
+        Returns:
+            Dictionary with distribution summaries (including normality
+            tests) for rates, inflation, CPI and (if simulated) nominal and
+            real values, plus 'threshold_queries' when queries are given
+        """
+        index = len(paths['times']) - 1 if horizon is None else horizon_index(paths['times'], horizon)
+        horizon = float(paths['times'][index])
+        keys = ['short_rate', 'inflation', 'cpi', 'nominal_value', 'real_value']
+
+        summary = {'horizon': horizon}
+        for key in keys:
+            if key not in paths:
+                continue
+            terminal = paths[key][:, index]
+            summary[key] = {
+                'mean': float(np.mean(terminal)),
+                'std': float(np.std(terminal)),
+                'percentiles': {str(p): float(v) for p, v in zip(percentiles, select_percentiles(terminal, percentiles))},
+            }
+            if len(terminal) >= 8 and np.std(terminal) > 0:
+                summary[key]['normality'] = normality_tests(terminal)
+
+        if 'real_value' in paths and horizon > 0:
+            initial = paths['nominal_value'][:, 0]
+            for key in ('nominal_value', 'real_value'):
+                annualized = (paths[key][:, index] / initial) ** (1.0 / horizon) - 1
+                summary[key]['annualized_return_median'] = float(np.median(annualized))
+                summary[key]['prob_loss'] = float(np.mean(paths[key][:, index] < initial))
+
+        if queries:
+            summary['threshold_queries'] = evaluate_threshold_queries(
+                queries, _return_outcomes(paths, index, horizon), default_metric='nominal_total_return'
+            )
+
+        return summary
+
+    @staticmethod
+    def horizon_outcomes(
+        paths: Dict[str, np.ndarray],
+        horizons: List[float],
+        percentiles=(5, 25, 50, 75, 95),
+        queries: Optional[List[Dict]] = None
+    ) -> Dict[str, Dict]:
+        """
+        Outcome summaries at several horizons from a single run.
+
+        Parameters:
+            paths: Output of simulate()
+            horizons: Horizons in years (each on the simulation grid)
+            percentiles: Percentiles to report
+            queries: Threshold queries evaluated at every horizon
+
+        Returns:
+            Dictionary horizon (as a string, e.g. '5') -> real_outcomes summary
+        """
+        return {
+            f'{h:g}': RateInflationSimulator.real_outcomes(paths, percentiles, queries, horizon=h)
+            for h in sorted(set(horizons))
+        }
+
+    @staticmethod
+    def blended_outcomes(
+        scenarios: List[Dict],
+        percentiles=(5, 25, 50, 75, 95),
+        queries: Optional[List[Dict]] = None,
+        horizon: Optional[float] = None
+    ) -> Dict[str, any]:
+        """
+        Probability-weighted mixture of outcomes from several scenario runs.
+
+        Each path in scenario h gets weight p_h / n_h, so the blend does not
+        depend on how many paths each scenario was run with.
+
+        Parameters:
+            scenarios: Rows with 'name', 'probability' (summing to 1) and
+                'paths' (output of simulate(), on the same time grid)
+            percentiles: Percentiles to report
+            queries: Threshold queries evaluated on the blend (as in real_outcomes)
+            horizon: Years at which to summarize (default: end of the run)
+
+        Returns:
+            Dictionary with the blended mean, std and percentiles per series
+            (and probability of loss for asset values), per-scenario
+            real_outcomes summaries, and 'threshold_queries' when queries are given
+        """
+        if abs(sum(sc['probability'] for sc in scenarios) - 1.0) > 1e-6:
+            raise ValueError("Scenario probabilities must sum to 1")
+        times = scenarios[0]['paths']['times']
+        if any(len(sc['paths']['times']) != len(times) for sc in scenarios):
+            raise ValueError("Scenarios must share the same horizon and step size")
+
+        index = len(times) - 1 if horizon is None else horizon_index(times, horizon)
+        horizon = float(times[index])
+        weights = np.concatenate([
+            np.full(sc['paths']['short_rate'].shape[0], sc['probability'] / sc['paths']['short_rate'].shape[0])
+            for sc in scenarios
+        ])
+        keys = [
+            key for key in ('short_rate', 'inflation', 'cpi', 'nominal_value', 'real_value')
+            if all(key in sc['paths'] for sc in scenarios)
+        ]
+
+        blended = {'horizon': horizon}
+        for key in keys:
+            terminal = np.concatenate([sc['paths'][key][:, index] for sc in scenarios])
+            mean = float(np.sum(weights * terminal))
+            blended[key] = {
+                'mean': mean,
+                'std': float(np.sqrt(np.sum(weights * (terminal - mean) ** 2))),
+                'percentiles': {
+                    str(p): float(v) for p, v in zip(percentiles, weighted_percentiles(terminal, weights, percentiles))
+                },
+            }
+            if key in ('nominal_value', 'real_value'):
+                initial = np.concatenate([sc['paths']['nominal_value'][:, 0] for sc in scenarios])
+                blended[key]['prob_loss'] = float(np.sum(weights * (terminal < initial)))
+
+        if queries:
+            per_scenario = [_return_outcomes(sc['paths'], index, horizon) for sc in scenarios]
+            outcomes = {metric: np.concatenate([o[metric] for o in per_scenario]) for metric in per_scenario[0]}
+            blended['threshold_queries'] = evaluate_threshold_queries(
+                queries, outcomes, weights=weights, default_metric='nominal_total_return'
+            )
+
+        return {
+            'blended': blended,
+            'scenarios': {
+                sc['name']: {
+                    'probability': sc['probability'],
+                    **RateInflationSimulator.real_outcomes(sc['paths'], percentiles, queries, horizon=horizon),
+                }
+                for sc in scenarios
+            },
+        }
+
+
+def _return_outcomes(paths: Dict[str, np.ndarray], index: int, horizon: float) -> Dict[str, np.ndarray]:
+    """Nominal and real total and annualized asset returns at a time index."""
+    if 'real_value' not in paths or horizon <= 0:
+        raise ValueError("Threshold queries need a simulated asset")
+    initial = paths['nominal_value'][:, 0]
+    outcomes = {}
+    for prefix, key in (('nominal', 'nominal_value'), ('real', 'real_value')):
+        growth = paths[key][:, index] / initial
+        outcomes[f'{prefix}_total_return'] = growth - 1
+        outcomes[f'{prefix}_annualized_return'] = growth ** (1.0 / horizon) - 1
+    return outcomes
@@ -0,0 +1,140 @@
+"""
+Risk-Free Yield Curve
+
+Dated zero-coupon curve used for Sharpe ratios, alpha and discounting.
+
+Conventions:
+-----------
+- Rates are annual, continuously compounded zero rates
+- Interpolation is linear in zero rate between tenors, flat beyond the
+  shortest and longest tenors
+- Discount factor:  P(t) = exp(-z(t) t)
+- Forward rate:     f(t1, t2) = (z(t2) t2 - z(t1) t1) / (t2 - t1)
+"""
+
+import numpy as np
+from datetime import date
+from typing import Dict, List, Optional, Union
+
+
+class YieldCurve:
+    """
+    Zero-coupon yield curve with interpolation between tenors.
+
+    Attributes:
+        tenors (np.ndarray): Tenors in years, ascending
+        rates (np.ndarray): Zero rates at each tenor
+        curve_date (date): Date the curve was observed
+        name (str): Curve name (e.g. 'UST')
+
+    Example:
+        >>> curve = YieldCurve([0.25, 1, 5, 10], [0.050, 0.048, 0.042, 0.043])
+        >>> curve.zero_rate(2.0)
+        0.0465
+        >>> curve.discount_factor(2.0)
+    """
+
+    def __init__(
+        self,
+        tenors: List[float],
+        rates: List[float],
+        curve_date: Optional[date] = None,
+        name: str = 'UST'
+    ):
+        """
+        Initialize yield curve.
+
+        Parameters:
+            tenors: Tenors in years
+            rates: Continuously compounded zero rates (decimal)
+            curve_date: Observation date
+            name: Curve name
+        """
+        tenors = np.asarray(tenors, dtype=float).ravel()
+        rates = np.asarray(rates, dtype=float).ravel()
+
+        if len(tenors) == 0 or len(tenors) != len(rates):
+            raise ValueError("tenors and rates must be non-empty and the same length")
+        if np.any(tenors <= 0):
+            raise ValueError("tenors must be positive")
+        if not np.all(np.isfinite(rates)):
+            raise ValueError("rates must be finite")
+
+        order = np.argsort(tenors)
+        self.tenors = tenors[order]
+        self.rates = rates[order]
+        if np.any(np.diff(self.tenors) == 0):
+            raise ValueError("tenors must be unique")
+
+        self.curve_date = curve_date
+        self.name = name
+
+    @classmethod
+    def flat(cls, rate: float, **kwargs) -> 'YieldCurve':
+        """Curve with the same zero rate at every tenor."""
+        return cls([1.0], [rate], **kwargs)
+
+    def zero_rate(self, t: Union[float, np.ndarray]) -> Union[float, np.ndarray]:
+        """
+        Interpolated zero rate.
+
+        Parameters:
+            t: Maturity in years (scalar or array)
+
+        Returns:
+            Zero rate(s) at t
+        """
+        z = np.interp(t, self.tenors, self.rates)
+        return float(z) if np.ndim(z) == 0 else z
+
+    def discount_factor(self, t: Union[float, np.ndarray]) -> Union[float, np.ndarray]:
+        """
+        Discount factor P(t) = exp(-z(t) t).
+
+        Parameters:
+            t: Maturity in years (scalar or array, t ≥ 0)
+
+        Returns:
+            Discount factor(s)
+        """
+        t_arr = np.asarray(t, dtype=float)
+        if np.any(t_arr < 0):
+            raise ValueError("Maturity must be non-negative")
+        df = np.exp(-np.interp(t_arr, self.tenors, self.rates) * t_arr)
+        return float(df) if np.ndim(df) == 0 else df
+
+    def forward_rate(self, t1: float, t2: float) -> float:
+        """
+        Continuously compounded forward rate between t1 and t2.
+
+        Parameters:
+            t1: Start (years)
+            t2: End (years, > t1)
+
+        Returns:
+            Forward rate
+        """
+        if t2 <= t1:
+            raise ValueError("t2 must be greater than t1")
+        return float((self.zero_rate(t2) * t2 - self.zero_rate(t1) * t1) / (t2 - t1))
+
+    def risk_free_rate(self, horizon: float = 1.0) -> float:
+        """
+        Annually compounded risk-free return over a horizon, for Sharpe and alpha.
+
+        Parameters:
+            horizon: Horizon in years
+
+        Returns:
+            Annual effective rate
+        """
+        return float(np.expm1(self.zero_rate(horizon)))
+
+    def to_dict(self) -> Dict[str, any]:
+        """Serializable representation."""
+        return {
+            'name': self.name,
+            'curve_date': self.curve_date.isoformat() if self.curve_date else None,
+            'tenors': self.tenors.tolist(),
+            'rates': self.rates.tolist(),
+        }
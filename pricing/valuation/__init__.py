@@ -0,0 +1,4 @@
+"""Cash flow valuation module."""
+from .dcf import DCFValuation
+
+__all__ = ['DCFValuation']
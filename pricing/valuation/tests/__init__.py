@@ -0,0 +1 @@
+"""Tests for cash flow valuation."""
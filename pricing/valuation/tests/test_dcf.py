@@ -0,0 +1,92 @@
+"""
+Tests for DCF valuation.
+
+Tests include:
+- PV against the closed form Σ CF_i exp(-r t_i) on a flat curve
+- Shifts, spreads and flat-rate scenarios
+- Duration and DV01 of a single zero-coupon flow
+- Dated cash flows and input validation
+"""
+
+import pytest
+import numpy as np
+from datetime import date
+from pricing.rates import YieldCurve
+from pricing.valuation import DCFValuation
+
+FLOWS = [{'t': 1.0, 'amount': -100.0}, {'t': 4.0, 'amount': 150.0}]
+
+
+class TestClosedForm:
+    """Test present values against hand-computed discounting."""
+
+    def test_flat_curve_pv(self):
+        """PV = -100 e^{-0.05} + 150 e^{-0.2}."""
+        result = DCFValuation(YieldCurve.flat(0.05)).value(FLOWS)
+        base = result['scenarios']['base']
+
+        assert base['pv'] == pytest.approx(-100 * np.exp(-0.05) + 150 * np.exp(-0.2))
+        assert base['pv_contributions'] == pytest.approx(-100 * np.exp(-0.05))
+        assert base['pv_distributions'] == pytest.approx(150 * np.exp(-0.2))
+        assert result['undiscounted_total'] == pytest.approx(50.0)
+
+    def test_shift_and_spread_add_to_curve(self):
+        """A 100bp shift plus a 400bp spread discounts at 10%."""
+        result = DCFValuation(YieldCurve.flat(0.05)).value(
+            FLOWS, scenarios={'stress': {'shift_bps': 100, 'spread_bps': 400}}
+        )
+        assert result['scenarios']['stress']['pv'] == pytest.approx(-100 * np.exp(-0.10) + 150 * np.exp(-0.40))
+
+    def test_flat_rate_replaces_curve(self):
+        """A scenario 'rate' ignores the curve and any spread."""
+        curve = YieldCurve([1, 5], [0.02, 0.06])
+        result = DCFValuation(curve).value(FLOWS, scenarios={'flat': {'rate': 0.08, 'spread_bps': 500}})
+        assert result['scenarios']['flat']['discount_rates'] == pytest.approx([0.08, 0.08])
+
+    def test_interpolated_curve(self):
+        """A flow at 3 years is discounted at the interpolated 4% zero rate."""
+        curve = YieldCurve([1, 5], [0.02, 0.06])
+        result = DCFValuation(curve).value([{'t': 3.0, 'amount': 100.0}])
+        assert result['scenarios']['base']['pv'] == pytest.approx(100 * np.exp(-0.04 * 3))
+
+
+class TestSensitivities:
+    """Test duration and DV01."""
+
+    def test_zero_coupon_duration_and_dv01(self):
+        """One flow has duration t and DV01 = PV (e^{-0.0001 t} - 1)."""
+        result = DCFValuation(YieldCurve.flat(0.05)).value([{'t': 7.0, 'amount': 1000.0}])
+        base = result['scenarios']['base']
+
+        assert base['duration'] == pytest.approx(7.0)
+        assert base['dv01'] == pytest.approx(base['pv'] * (np.exp(-0.0001 * 7) - 1))
+
+    def test_zero_pv_has_no_duration(self):
+        """Duration is undefined when flows net to zero PV."""
+        result = DCFValuation(YieldCurve.flat(0.0)).value([{'t': 1.0, 'amount': -50.0}, {'t': 2.0, 'amount': 50.0}])
+        assert result['scenarios']['base']['duration'] is None
+
+
+class TestSchedule:
+    """Test cash flow timing and validation."""
+
+    def test_dated_flows(self):
+        """Dates are converted to years of 365.25 days."""
+        dcf = DCFValuation(YieldCurve.flat(0.05), valuation_date=date(2024, 1, 1))
+        result = dcf.value([{'date': date(2025, 1, 1), 'amount': 100.0}])
+        assert result['times'] == pytest.approx([366 / 365.25])
+
+    def test_dated_flows_need_valuation_date(self):
+        """Without a valuation date there is nothing to measure from."""
+        with pytest.raises(ValueError, match="valuation_date"):
+            DCFValuation(YieldCurve.flat(0.05)).value([{'date': date(2025, 1, 1), 'amount': 100.0}])
+
+    def test_past_flows_rejected(self):
+        """Cash flows before the valuation date are rejected."""
+        with pytest.raises(ValueError):
+            DCFValuation(YieldCurve.flat(0.05)).value([{'t': -0.5, 'amount': 100.0}])
+
+    def test_empty_schedule(self):
+        """At least one cash flow is needed."""
+        with pytest.raises(ValueError):
+            DCFValuation(YieldCurve.flat(0.05)).value([])
@@ -0,0 +1,219 @@
+#!/usr/bin/env python3
+"""
+Target allocation drift API script for web interface.
+
+Actions:
+    set_targets: replace the stored targets for a dimension
+    drift:       compare current NAV weights with the stored targets (all
+                 active funds, or a portfolio group's subtree with group_id
+                 and/or one portfolio's funds with portfolio_id); nothing
+                 is stored
+    evaluate:    check drift for every dimension with targets and record
+                 alerts in allocation_drift_alerts
+    alerts:      list recorded alerts (open, resolved or all)
+
+evaluate is run by seed_demo.py after each data load (trigger 'data_update'),
+alongside the risk limit and compliance checks. A bucket that stays outside
+its threshold in the same direction keeps one open row (last_seen_at and
+observed_drift are refreshed); open rows that are back within threshold, or
+whose targets were removed, are resolved.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor, execute_values
+from analytics import allocation_drift
+from portfolio_groups import group_filter
+
+DIMENSION_COLUMNS = {
+    'sector': 'p.sector',
+    'strategy': "COALESCE(m.primary_strategy, 'Unclassified')",
+}
+TRIGGERS = ('data_update', 'scheduled', 'manual')
+ALERT_FILTERS = {
+    'open': 'WHERE a.resolved_at IS NULL',
+    'resolved': 'WHERE a.resolved_at IS NOT NULL',
+    'all': '',
+}
+
+
+def set_targets(cur, params):
+    dimension = params['dimension']
+    rows = [
+        (dimension, t['bucket'], t['target_weight'], t.get('drift_threshold'))
+        for t in params['targets']
+    ]
+    if abs(sum(r[2] for r in rows) - 1.0) > 1e-6:
+        raise ValueError("Target weights must sum to 1")
+
+    cur.execute("DELETE FROM allocation_targets WHERE dimension = %s", (dimension,))
+    execute_values(
+        cur,
+        "INSERT INTO allocation_targets (dimension, bucket, target_weight, drift_threshold) VALUES %s",
+        rows
+    )
+    return {'dimension': dimension, 'stored': len(rows)}
+
+
+def drift(cur, params):
+    dimension = params.get('dimension', 'sector')
+
+    cur.execute(
+        "SELECT bucket, target_weight, drift_threshold FROM allocation_targets WHERE dimension = %s",
+        (dimension,)
+    )
+    rows = cur.fetchall()
+    if not rows:
+        raise ValueError(f"No allocation targets stored for {dimension}")
+    targets = {row['bucket']: float(row['target_weight']) for row in rows}
+    thresholds = {
+        row['bucket']: float(row['drift_threshold'])
+        for row in rows if row['drift_threshold'] is not None
+    }
+
+    condition, args = group_filter(cur, params.get('group_id'), portfolio_id=params.get('portfolio_id'))
+    cur.execute(
+        f"SELECT {DIMENSION_COLUMNS[dimension]} AS bucket, SUM(COALESCE(p.current_nav, 0)) AS value "
+        "FROM portfolio_data p LEFT JOIN managers m ON m.manager_id = p.manager_id "
+        f"WHERE p.status = 'Active' AND {condition} GROUP BY 1",
+        args
+    )
+    values = {row['bucket']: float(row['value']) for row in cur.fetchall()}
+
+    result = allocation_drift(
+        values,
+        targets,
+        thresholds=thresholds,
+        default_threshold=params.get('default_threshold', 0.05)
+    )
+    result['dimension'] = dimension
+    result['group_id'] = params.get('group_id')
+    result['portfolio_id'] = params.get('portfolio_id')
+    return result
+
+
+def evaluate(cur, params):
+    trigger = params.get('trigger', 'manual')
+    if trigger not in TRIGGERS:
+        raise ValueError(f"Unknown trigger: {trigger}")
+
+    cur.execute("SELECT DISTINCT dimension FROM allocation_targets ORDER BY dimension")
+    dimensions = [row['dimension'] for row in cur.fetchall()]
+
+    results = {}
+    seen = []
+    new_alerts = 0
+    for dimension in dimensions:
+        result = drift(cur, {'dimension': dimension, 'default_threshold': params.get('default_threshold', 0.05)})
+        for alert in result['alerts']:
+            cur.execute(
+                "UPDATE allocation_drift_alerts SET observed_drift = %s, threshold_value = %s, "
+                "suggested_trade = %s, last_seen_at = CURRENT_TIMESTAMP "
+                "WHERE dimension = %s AND bucket = %s AND direction = %s AND resolved_at IS NULL "
+                "RETURNING alert_id",
+                (alert['drift'], alert['threshold'], alert['suggested_trade'],
+                 dimension, alert['bucket'], alert['direction'])
+            )
+            row = cur.fetchone()
+            alert['new_alert'] = row is None
+            if row is None:
+                cur.execute(
+                    "INSERT INTO allocation_drift_alerts (dimension, bucket, direction, observed_drift, "
+                    "threshold_value, suggested_trade, evaluation_trigger) VALUES (%s, %s, %s, %s, %s, %s, %s) "
+                    "RETURNING alert_id",
+                    (dimension, alert['bucket'], alert['direction'], alert['drift'],
+                     alert['threshold'], alert['suggested_trade'], trigger)
+                )
+                row = cur.fetchone()
+                new_alerts += 1
+            alert['alert_id'] = row['alert_id']
+            seen.append(row['alert_id'])
+        results[dimension] = result
+
+    # Anything still open that did not drift this time is back within threshold (or lost its targets)
+    cur.execute(
+        "UPDATE allocation_drift_alerts SET resolved_at = CURRENT_TIMESTAMP "
+        "WHERE resolved_at IS NULL AND alert_id <> ALL(%s::int[]) RETURNING alert_id",
+        (seen,)
+    )
+    return {
+        'dimensions': results,
+        'n_dimensions': len(results),
+        'n_alerts': len(seen),
+        'n_new_alerts': new_alerts,
+        'n_resolved': len(cur.fetchall()),
+        'trigger': trigger,
+    }
+
+
+def list_alerts(cur, params):
+    status = params.get('status', 'open')
+    if status not in ALERT_FILTERS:
+        raise ValueError(f"Unknown alert status: {status}")
+    cur.execute(
+        f"SELECT a.* FROM allocation_drift_alerts a {ALERT_FILTERS[status]} "
+        "ORDER BY a.detected_at DESC, a.alert_id DESC LIMIT %s",
+        (params.get('limit', 100),)
+    )
+    alerts = [dict(row) for row in cur.fetchall()]
+    return {'status': status, 'alerts': alerts, 'n_alerts': len(alerts)}
+
+
+def _serialize(value):
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'set_targets': set_targets,
+    'drift': drift,
+    'evaluate': evaluate,
+    'alerts': list_alerts,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'drift')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+        if params.get('dimension', 'sector') not in DIMENSION_COLUMNS:
+            raise ValueError(f"Unknown dimension: {params['dimension']}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Allocation drift error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
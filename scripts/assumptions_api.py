@@ -0,0 +1,124 @@
+#!/usr/bin/env python3
+"""
+Assumption set API script for web interface.
+
+Actions:
+    save:     validate a parameter set and store it as the next version of
+              its name (versions are never modified)
+    list:     latest version of every named set
+    versions: all versions of one name
+    get:      one set by assumption_set_id, or by set_name and version
+              (latest version when omitted)
+
+Simulation and optimization requests reference a set by assumption_set_id
+(see assumptions_store.resolve_assumption_set).
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import AssumptionSet
+
+SUMMARY_COLUMNS = "assumption_set_id, set_name, version, description, created_by, created_at"
+
+
+def save(cur, params):
+    parameters = AssumptionSet.from_parameters(params['parameters']).to_parameters()
+    cur.execute(
+        "INSERT INTO assumption_sets (set_name, version, description, parameters, created_by) "
+        "SELECT %s, COALESCE(MAX(version), 0) + 1, %s, %s, %s FROM assumption_sets WHERE set_name = %s "
+        "RETURNING *",
+        (params['set_name'], params.get('description'), json.dumps(parameters), params.get('created_by'),
+         params['set_name'])
+    )
+    return dict(cur.fetchone())
+
+
+def list_sets(cur, params):
+    cur.execute(
+        f"SELECT DISTINCT ON (set_name) {SUMMARY_COLUMNS}, "
+        "COUNT(*) OVER (PARTITION BY set_name) AS n_versions "
+        "FROM assumption_sets ORDER BY set_name, version DESC"
+    )
+    return {'assumption_sets': [dict(row) for row in cur.fetchall()]}
+
+
+def versions(cur, params):
+    cur.execute(
+        f"SELECT {SUMMARY_COLUMNS} FROM assumption_sets WHERE set_name = %s ORDER BY version DESC",
+        (params['set_name'],)
+    )
+    rows = [dict(row) for row in cur.fetchall()]
+    if not rows:
+        raise ValueError(f"No assumption set named '{params['set_name']}'")
+    return {'set_name': params['set_name'], 'versions': rows}
+
+
+def get(cur, params):
+    if params.get('assumption_set_id') is not None:
+        cur.execute("SELECT * FROM assumption_sets WHERE assumption_set_id = %s", (params['assumption_set_id'],))
+    else:
+        cur.execute(
+            "SELECT * FROM assumption_sets WHERE set_name = %s AND (%s::int IS NULL OR version = %s::int) "
+            "ORDER BY version DESC LIMIT 1",
+            (params['set_name'], params.get('version'), params.get('version'))
+        )
+    row = cur.fetchone()
+    if row is None:
+        raise ValueError("Assumption set not found")
+    return dict(row)
+
+
+def _serialize(value):
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'save': save,
+    'list': list_sets,
+    'versions': versions,
+    'get': get,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'list')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Assumption set error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
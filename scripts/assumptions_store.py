@@ -0,0 +1,56 @@
+"""
+Stored assumption set access shared by the API scripts.
+
+Sets are saved through scripts/assumptions_api.py into the assumption_sets
+table. Simulation and optimization scripts call resolve_assumption_set with
+their request parameters; requests that carry an assumption_set_id get the
+stored set, others keep their own inputs.
+"""
+
+import os
+from typing import Dict, Optional, Tuple
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import AssumptionSet
+
+
+def load_assumption_set(cur, assumption_set_id: int) -> Tuple[AssumptionSet, Dict]:
+    """Stored set by id, with its name and version."""
+    cur.execute(
+        "SELECT assumption_set_id, set_name, version, parameters FROM assumption_sets "
+        "WHERE assumption_set_id = %s",
+        (assumption_set_id,)
+    )
+    row = cur.fetchone()
+    if row is None:
+        raise ValueError(f"No assumption set with id {assumption_set_id}")
+    reference = {
+        'assumption_set_id': row['assumption_set_id'],
+        'set_name': row['set_name'],
+        'version': row['version'],
+    }
+    return AssumptionSet.from_parameters(row['parameters']), reference
+
+
+def resolve_assumption_set(params: Dict) -> Tuple[Optional[AssumptionSet], Optional[Dict]]:
+    """
+    Assumption set referenced by a request.
+
+    Returns:
+        (assumptions, reference) or (None, None) when the request has no
+        assumption_set_id
+    """
+    if params.get('assumption_set_id') is None:
+        return None, None
+
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        raise ValueError("assumption_set_id requires DATABASE_URL")
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            return load_assumption_set(cur, params['assumption_set_id'])
+    finally:
+        conn.close()
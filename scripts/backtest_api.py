@@ -0,0 +1,139 @@
+#!/usr/bin/env python3
+"""
+Backtesting API script for web interface.
+
+The strategy is replayed over posted returns or over stored benchmark_data
+series (one series name per asset, aligned on their common dates, with an
+optional start_date and end_date). The frequency of stored series is
+inferred from their dates when not given. An optional pacing rule treats
+one asset as a private allocation funded by commitments; with pacing_paths
+the commitment plan is also simulated under stochastic call and distribution
+timing (the pacing rule's call_volatility and distribution_volatility) and
+returned with percentile bands of private NAV and unfunded exposure. The
+Sharpe ratio uses the request's risk_free_rate, else the stored yield curve's.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from backtesting import BacktestEngine, CommitmentPacing, RebalancingStrategy
+from series_store import infer_frequency, load_aligned_series
+from rates_store import resolve_risk_free_rate
+import numpy as np
+
+
+def load_returns(params):
+    """Aligned stored returns for the requested series."""
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        raise ValueError("DATABASE_URL is not set")
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn:
+            with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                return load_aligned_series(cur, params['series'], params.get('start_date'), params.get('end_date'))
+    finally:
+        conn.close()
+
+
+def _serialize(value):
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+def _tolist(bands):
+    """Percentile bands (numpy values) as JSON-ready lists and floats."""
+    return {k: _tolist(v) if isinstance(v, dict) else v.tolist() for k, v in bands.items()}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        target_weights = np.array(params['target_weights'], dtype=float)
+
+        dates = None
+        if params.get('returns') is not None:
+            returns = np.array(params['returns'], dtype=float)
+            frequency = params.get('frequency', 12)
+        elif params.get('series'):
+            if len(params['series']) != len(target_weights):
+                raise ValueError("Provide one series per target weight")
+            dates, returns = load_returns(params)
+            frequency = params.get('frequency') or infer_frequency(dates)
+        else:
+            raise ValueError("Provide returns or stored series names")
+
+        pacing = None
+        if params.get('pacing') is not None:
+            pacing = CommitmentPacing(**{k: v for k, v in params['pacing'].items() if v is not None})
+
+        strategy = RebalancingStrategy(
+            target_weights=target_weights,
+            rebalance_frequency=params.get('rebalance_frequency', 21),
+            drift_threshold=params.get('drift_threshold'),
+            transaction_cost=params.get('transaction_cost', 0.0),
+            pacing=pacing
+        )
+
+        risk_free_rate, risk_free_source = resolve_risk_free_rate(params)
+        engine = BacktestEngine(returns, frequency=frequency, risk_free_rate=risk_free_rate)
+        result = engine.run(strategy)
+
+        output = {
+            'equity_curve': result['equity_curve'].tolist(),
+            'drawdowns': result['drawdowns'].tolist(),
+            'rebalances': result['rebalances'],
+            'max_drawdown': result['max_drawdown'],
+            'total_return': result['total_return'],
+            'annualized_return': result['annualized_return'],
+            'annualized_volatility': result['annualized_volatility'],
+            'sharpe_ratio': result['sharpe_ratio'],
+            'risk_free_rate': {'rate': risk_free_rate, 'source': risk_free_source},
+            'n_rebalances': result['n_rebalances'],
+            'total_turnover': result['total_turnover'],
+            'annualized_turnover': result['annualized_turnover'],
+            'total_costs': result['total_costs'],
+            'frequency': frequency
+        }
+
+        if dates is not None:
+            output['series'] = params['series']
+            output['dates'] = dates
+
+        if pacing is not None:
+            output['pacing'] = {
+                'private_weight': result['private_weight'].tolist(),
+                'unfunded': result['unfunded'].tolist(),
+                'commitments': result['commitments'],
+                'total_commitments': result['total_commitments'],
+                'total_calls': result['total_calls'],
+                'total_distributions': result['total_distributions'],
+            }
+            if params.get('pacing_paths') is not None:
+                bands = engine.pacing_bands(strategy, n_paths=params['pacing_paths'], seed=params.get('seed'))
+                n_paths = bands.pop('n_paths')
+                output['pacing']['bands'] = {'n_paths': n_paths, **_tolist(bands)}
+
+        print(json.dumps(output, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Backtest error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
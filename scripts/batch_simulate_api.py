@@ -0,0 +1,60 @@
+#!/usr/bin/env python3
+"""
+Batch parameter sweep API script for web interface.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+from pricing.monte_carlo import ParameterSweep
+from result_cache import cached
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        base_params = {
+            'S0': params.get('S', 100.0),
+            'K': params.get('K', 100.0),
+            'T': params.get('T', 1.0),
+            'r': params.get('r', 0.05),
+            'sigma': params.get('sigma', 0.2),
+            'q': params.get('q', 0.0),
+            'option_type': params.get('option_type', 'call'),
+        }
+
+        # Accept 'S' in the grid for consistency with the single-run endpoint
+        grid = {('S0' if name == 'S' else name): values for name, values in params['grid'].items()}
+
+        sweep = ParameterSweep(
+            base_params,
+            grid,
+            n_paths=params.get('n_paths', 100000),
+            variance_reduction=params.get('variance_reduction', 'antithetic'),
+            seed=params.get('seed', 42)
+        )
+        # Worker count does not change results, so it is left out of the cache key
+        result = cached(
+            'batch_simulate',
+            {k: v for k, v in params.items() if k != 'max_workers'},
+            lambda: sweep.run(max_workers=params.get('max_workers'))
+        )
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Batch simulation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
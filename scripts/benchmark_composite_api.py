@@ -0,0 +1,137 @@
+#!/usr/bin/env python3
+"""
+Benchmark composite API script for web interface.
+
+Builds a blended benchmark from component series in benchmark_data and
+stores the result in benchmark_data under the composite name, so it can be
+used anywhere a benchmark name is accepted. Stored composites are rebuilt by
+rebuild_composites whenever their component series are reloaded (seed_demo).
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor, execute_values
+from analytics import composite_benchmark
+
+
+def load_components(cur, names):
+    """Return series per component, restricted to dates common to all components."""
+    cur.execute(
+        "SELECT benchmark_name, date, return_value FROM benchmark_data "
+        "WHERE benchmark_name = ANY(%s) AND return_value IS NOT NULL",
+        (names,)
+    )
+    series = {name: {} for name in names}
+    for row in cur.fetchall():
+        series[row['benchmark_name']][row['date']] = float(row['return_value'])
+
+    empty = [name for name, obs in series.items() if not obs]
+    if empty:
+        raise ValueError(f"Unknown benchmarks: {empty}")
+
+    dates = sorted(set.intersection(*(set(obs) for obs in series.values())))
+    return dates, {name: [obs[d] for d in dates] for name, obs in series.items()}
+
+
+def build_composite(cur, name, components, spread_bps):
+    """Compute a composite from its components and replace its series in benchmark_data."""
+    weights = {c['benchmark']: float(c['weight']) for c in components}
+    dates, component_returns = load_components(cur, list(weights))
+    result = composite_benchmark(dates, component_returns, weights, spread_bps=spread_bps)
+
+    cur.execute("DELETE FROM benchmark_data WHERE benchmark_name = %s", (name,))
+    execute_values(
+        cur,
+        "INSERT INTO benchmark_data (benchmark_name, date, return_value, index_level) VALUES %s",
+        [(name, d, r, level) for d, r, level in zip(dates, result['returns'], result['index_levels'])]
+    )
+    return result
+
+
+def rebuild_composites(cur):
+    """
+    Rebuild every stored composite from the current component series.
+
+    Composites are rebuilt in creation order, so one built on an earlier
+    composite sees its refreshed series. A composite whose components are
+    missing keeps no series and is reported as failed.
+    """
+    cur.execute(
+        "SELECT composite_name, components, spread_bps FROM benchmark_composites ORDER BY composite_id"
+    )
+    rebuilt, failed = [], []
+    for row in cur.fetchall():
+        cur.execute("SAVEPOINT rebuild_composite")
+        try:
+            build_composite(cur, row['composite_name'], row['components'], float(row['spread_bps'] or 0))
+            rebuilt.append(row['composite_name'])
+        except ValueError as e:
+            cur.execute("ROLLBACK TO SAVEPOINT rebuild_composite")
+            cur.execute("DELETE FROM benchmark_data WHERE benchmark_name = %s", (row['composite_name'],))
+            failed.append({'name': row['composite_name'], 'error': str(e)})
+        cur.execute("RELEASE SAVEPOINT rebuild_composite")
+    return {'rebuilt': rebuilt, 'failed': failed}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        name = params['name']
+        weights = {c['benchmark']: float(c['weight']) for c in params['components']}
+        spread_bps = float(params.get('spread_bps', 0))
+        persist = params.get('persist', True)
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    if not persist:
+                        dates, component_returns = load_components(cur, list(weights))
+                        result = composite_benchmark(dates, component_returns, weights, spread_bps=spread_bps)
+                    else:
+                        cur.execute(
+                            "SELECT 1 FROM benchmark_data WHERE benchmark_name = %s "
+                            "AND NOT EXISTS (SELECT 1 FROM benchmark_composites WHERE composite_name = %s) LIMIT 1",
+                            (name, name)
+                        )
+                        if cur.fetchone():
+                            raise ValueError(f"'{name}' is an existing non-composite benchmark")
+
+                        cur.execute(
+                            "INSERT INTO benchmark_composites (composite_name, components, spread_bps) "
+                            "VALUES (%s, %s, %s) ON CONFLICT (composite_name) DO UPDATE "
+                            "SET components = EXCLUDED.components, spread_bps = EXCLUDED.spread_bps "
+                            "RETURNING composite_id",
+                            (name, json.dumps(params['components']), spread_bps)
+                        )
+                        composite_id = cur.fetchone()['composite_id']
+                        result = build_composite(cur, name, params['components'], spread_bps)
+                        result['composite_id'] = composite_id
+        finally:
+            conn.close()
+
+        result['name'] = name
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Composite benchmark error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
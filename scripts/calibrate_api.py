@@ -0,0 +1,158 @@
+#!/usr/bin/env python3
+"""
+Distribution calibration API script for web interface.
+
+Actions:
+    fit:          maximum likelihood fits of the requested distributions
+    bayesian:     normal-inverse-gamma update of prior return assumptions
+    calibrations: registered fits of a stored series (latest version per model)
+
+fit and bayesian take either posted returns or a stored series: a
+benchmark_data name with optional start_date and end_date. Fits of a stored
+series are written to the model registry (unless register is false), one
+model per series and distribution, so simulations can use them by model_id.
+The frequency of a stored series is inferred from its dates when not given.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import DistributionCalibrator, bayesian_return_update
+from model_store import register_model
+from series_store import infer_frequency, load_series
+
+
+def fit(params, cur):
+    if not params.get('series'):
+        calibrator = DistributionCalibrator(params['returns'], frequency=params.get('frequency', 252))
+        return calibrator.fit_all(params.get('models'))
+
+    dates, returns = load_series(cur, params['series'], params.get('start_date'), params.get('end_date'))
+    frequency = params.get('frequency') or infer_frequency(dates)
+    result = DistributionCalibrator(returns, frequency=frequency).fit_all(params.get('models'))
+    result['series'] = {
+        'name': params['series'],
+        'start_date': dates[0],
+        'end_date': dates[-1],
+        'n_obs': len(returns),
+        'frequency': frequency,
+    }
+
+    result['registered'] = []
+    if params.get('register', True):
+        for model, fitted in result['fits'].items():
+            if 'aic' not in fitted:
+                continue
+            row = register_model(
+                cur,
+                f"{params['series']} {model}",
+                model,
+                fitted['params'],
+                fit_statistics={
+                    'log_likelihood': fitted['log_likelihood'],
+                    'aic': fitted['aic'],
+                    'bic': fitted['bic'],
+                    'best': model == result['best'],
+                    'converged': fitted.get('success', True),
+                },
+                data_source=params['series'],
+                data_start=dates[0],
+                data_end=dates[-1],
+                n_observations=len(returns),
+                frequency=frequency,
+                author=params.get('author'),
+                notes=params.get('notes')
+            )
+            result['registered'].append({
+                'model': model,
+                'model_id': row['model_id'],
+                'model_name': row['model_name'],
+                'version': row['version'],
+            })
+    return result
+
+
+def bayesian(params, cur):
+    if params.get('series'):
+        _, returns = load_series(cur, params['series'], params.get('start_date'), params.get('end_date'))
+    else:
+        returns = params['returns']
+    return bayesian_return_update(
+        returns,
+        params['prior'],
+        frequency=params.get('frequency', 4),
+        credible_level=params.get('credible_level', 0.9)
+    )
+
+
+def calibrations(params, cur):
+    cur.execute(
+        "SELECT DISTINCT ON (model_name) model_id, model_name, model_type, version, parameters, "
+        "fit_statistics, data_source, data_start, data_end, n_observations, frequency, author, calibrated_at "
+        "FROM model_registry WHERE model_type = ANY(%s) "
+        "AND (%s::text IS NULL OR data_source = %s::text) AND (%s::text IS NULL OR model_type = %s::text) "
+        "ORDER BY model_name, version DESC",
+        (list(DistributionCalibrator.MODELS), params.get('series'), params.get('series'),
+         params.get('model'), params.get('model'))
+    )
+    rows = [dict(row) for row in cur.fetchall()]
+    return {'series': params.get('series'), 'calibrations': rows, 'n_calibrations': len(rows)}
+
+
+def _serialize(value):
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'fit': fit,
+    'bayesian': bayesian,
+    'calibrations': calibrations,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        action = params.get('action', 'fit')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        # Posted returns need no database
+        if action != 'calibrations' and not params.get('series'):
+            result = ACTIONS[action](params, None)
+        else:
+            database_url = os.environ.get('DATABASE_URL')
+            if not database_url:
+                raise ValueError("DATABASE_URL is not set")
+
+            conn = psycopg2.connect(database_url)
+            try:
+                with conn:
+                    with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                        result = ACTIONS[action](params, cur)
+            finally:
+                conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Calibration error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,114 @@
+#!/usr/bin/env python3
+"""
+Change feed API script for web interface.
+
+Actions:
+    changes: changes after a cursor (since, a change_id; 0 for the whole feed),
+             oldest first, at most limit of them, with the cursor to pass next
+
+Triggers in schema.sql log every insert, update and delete of funds
+(portfolio_data), fund tags, cash flows and reported NAVs (period_navs) to
+data_changes, with the row's key and, except for deletes, its new values.
+A TRUNCATE is logged as a single 'truncate' change with no key: a consumer
+drops its copy of that table (seed_demo.py reloads the tables this way,
+followed by an insert per new row). Writers log under a transaction-level
+lock, so change_ids become visible in order and a cursor never skips one.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+
+DEFAULT_LIMIT = 1000
+MAX_LIMIT = 10000
+TABLES = ('portfolio_data', 'fund_tags', 'cash_flows', 'period_navs')
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def list_changes(cur, params):
+    since = params.get('since', 0)
+    limit = params.get('limit', DEFAULT_LIMIT)
+    tables = params.get('tables') or list(TABLES)
+    if not isinstance(since, int) or since < 0:
+        raise ValueError("since must be a non-negative integer")
+    if not isinstance(limit, int) or not 1 <= limit <= MAX_LIMIT:
+        raise ValueError(f"limit must be an integer between 1 and {MAX_LIMIT}")
+    unknown = sorted(set(tables) - set(TABLES))
+    if unknown:
+        raise ValueError(f"Unknown tables: {', '.join(unknown)} (available: {', '.join(TABLES)})")
+
+    # One extra row tells whether another page follows
+    changes = fetch_rows(
+        cur,
+        "SELECT change_id, table_name, operation, row_key, row_data, changed_at FROM data_changes "
+        "WHERE change_id > %s AND table_name = ANY(%s) ORDER BY change_id LIMIT %s",
+        (since, tables, limit + 1)
+    )
+    has_more = len(changes) > limit
+    changes = changes[:limit]
+    return {
+        'changes': changes,
+        'count': len(changes),
+        'next_cursor': changes[-1]['change_id'] if changes else since,
+        'has_more': has_more,
+    }
+
+
+def _serialize(value):
+    # NUMERIC columns arrive as Decimal
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'changes': list_changes,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'changes')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Change feed error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
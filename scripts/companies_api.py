@@ -0,0 +1,164 @@
+#!/usr/bin/env python3
+"""
+Portfolio company API script for web interface.
+
+Actions:
+    list: every portfolio company (optionally one sector) with the number of
+          funds holding it and its invested, realized and current value;
+          with tags, group_id or portfolio_id, only companies held by at
+          least one matching fund; fields=[...] keeps only those columns
+          (COMPANY_FIELDS) and format='ndjson' streams one company per
+          line instead
+    page: one page of the list in the /api/v2 envelope ({data, meta})
+    get:  one company and its investments by fund (vw_company_investments)
+
+Reads portfolio_companies and company_investments from DATABASE_URL.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from fund_tags import tag_filter
+from portfolio_groups import group_filter
+from row_stream import check_fields, select_fields, stream_rows
+from list_envelope import list_page
+
+COMPANY_QUERY = (
+    "SELECT c.company_id, c.company_name, c.sector, c.geography, "
+    "COUNT(ci.investment_id) AS num_funds, "
+    "COALESCE(SUM(ci.invested_amount), 0) AS total_invested, "
+    "COALESCE(SUM(ci.realized_amount), 0) AS total_realized, "
+    "COALESCE(SUM(ci.current_valuation), 0) AS total_valuation, "
+    "SUM(ci.realized_amount + ci.current_valuation) / NULLIF(SUM(ci.invested_amount), 0) AS gross_moic, "
+    "BOOL_OR(ci.exit_date IS NULL) AS held "
+    "FROM portfolio_companies c LEFT JOIN company_investments ci ON ci.company_id = c.company_id"
+)
+
+# Columns of a list row, selectable with fields
+COMPANY_FIELDS = (
+    'company_id', 'company_name', 'sector', 'geography', 'num_funds', 'total_invested',
+    'total_realized', 'total_valuation', 'gross_moic', 'held'
+)
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def company_list_query(cur, params):
+    condition, args = tag_filter(params.get('tags'), 'held.fund_id')
+    in_group, group_args = group_filter(
+        cur, params.get('group_id'), 'held.fund_id', portfolio_id=params.get('portfolio_id')
+    )
+    unfiltered = not params.get('tags') and params.get('group_id') is None and params.get('portfolio_id') is None
+    return (
+        f"{COMPANY_QUERY} WHERE (%s::text IS NULL OR c.sector = %s::text) "
+        "AND (%s OR EXISTS (SELECT 1 FROM company_investments held "
+        f"WHERE held.company_id = c.company_id AND {condition} AND {in_group})) "
+        "GROUP BY c.company_id ORDER BY total_valuation DESC, c.company_name",
+        [params.get('sector'), params.get('sector'), unfiltered] + args + group_args
+    )
+
+
+def list_companies(cur, params):
+    rows = fetch_rows(cur, *company_list_query(cur, params))
+    companies = [select_fields(row, params.get('fields')) for row in rows]
+    return {
+        'sector': params.get('sector'),
+        'tags': params.get('tags') or {},
+        'group_id': params.get('group_id'),
+        'portfolio_id': params.get('portfolio_id'),
+        'companies': companies,
+        'n_companies': len(companies),
+    }
+
+
+def page_companies(cur, params):
+    return list_page(cur, company_list_query, params)
+
+
+def get_company(cur, params):
+    rows = fetch_rows(cur, f"{COMPANY_QUERY} WHERE c.company_id = %s GROUP BY c.company_id", (params['company_id'],))
+    if not rows:
+        raise ValueError(f"No company with id {params['company_id']}")
+
+    company = rows[0]
+    company['investments'] = fetch_rows(
+        cur,
+        "SELECT v.investment_id, v.fund_id, v.fund_name, v.entry_date, v.exit_date, v.invested_amount, "
+        "v.realized_amount, v.current_valuation, ci.ownership_pct, v.gross_moic, v.value_created, "
+        "v.investment_status "
+        "FROM vw_company_investments v JOIN company_investments ci ON ci.investment_id = v.investment_id "
+        "WHERE v.company_id = %s ORDER BY v.entry_date, v.fund_name",
+        (params['company_id'],)
+    )
+    return company
+
+
+def _serialize(value):
+    # NUMERIC columns arrive as Decimal
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'list': list_companies,
+    'page': page_companies,
+    'get': get_company,
+}
+
+# Actions that can be streamed as NDJSON (format='ndjson'), one row per line
+STREAMS = {
+    'list': company_list_query,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'list')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+        if params.get('format') == 'ndjson' and action not in STREAMS:
+            raise ValueError(f"Action {action} cannot be streamed")
+        if action in ('list', 'page'):
+            check_fields(params.get('fields'), COMPANY_FIELDS)
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            if params.get('format') == 'ndjson':
+                stream_rows(conn, STREAMS[action], params, sys.stdout, default=_serialize)
+            else:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+                print(json.dumps(result, default=_serialize))
+        finally:
+            conn.close()
+
+    except Exception as e:
+        print(json.dumps({"error": f"Company error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,234 @@
+#!/usr/bin/env python3
+"""
+Compliance rule API script for web interface.
+
+Actions:
+    check:      evaluate posted rules against posted positions (with optional
+                what-if changes); nothing is stored
+    set_rules:  create or update stored rules by name (active=false disables one)
+    rules:      list stored rules
+    evaluate:   check the active stored rules against active fund positions
+                and record violations
+    violations: list recorded violations (open, resolved or all)
+
+evaluate is run by seed_demo.py after each data load (trigger 'data_update'),
+alongside the risk limit check. Positions are active funds valued at current
+NAV, with sector, manager, vintage and currency attributes. A rule and group
+that stays in violation keeps one open row (last_seen_at and observed_value
+are refreshed); open rows that pass on a later evaluation are resolved.
+With a group_id or portfolio_id, evaluate checks the positions of that
+portfolio group's subtree or that portfolio only and records nothing.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import ComplianceEngine, ComplianceRule
+from portfolio_groups import group_filter
+
+TRIGGERS = ('data_update', 'scheduled', 'manual')
+POSITION_ATTRIBUTES = ('sector', 'manager', 'vintage', 'currency')
+VIOLATION_FILTERS = {
+    'open': 'WHERE v.resolved_at IS NULL',
+    'resolved': 'WHERE v.resolved_at IS NOT NULL',
+    'all': '',
+}
+
+
+def check(cur, params):
+    rules = [ComplianceRule(**rule) for rule in params['rules']]
+    engine = ComplianceEngine(rules)
+
+    if params.get('changes'):
+        return engine.what_if(params['positions'], params['changes'])
+    return engine.evaluate(params['positions'])
+
+
+def set_rules(cur, params):
+    stored = []
+    for rule in params['rules']:
+        # Validates the rule type, attribute and limit before storing
+        ComplianceRule(rule['rule_name'], rule['rule_type'], rule['limit_value'], rule.get('attribute'))
+        if rule.get('attribute') is not None and rule['attribute'] not in POSITION_ATTRIBUTES:
+            raise ValueError(f"Unknown position attribute: {rule['attribute']}")
+        cur.execute(
+            "INSERT INTO compliance_rules (rule_name, rule_type, limit_value, attribute, active) "
+            "VALUES (%s, %s, %s, %s, %s) "
+            "ON CONFLICT (rule_name) DO UPDATE SET rule_type = EXCLUDED.rule_type, "
+            "limit_value = EXCLUDED.limit_value, attribute = EXCLUDED.attribute, "
+            "active = EXCLUDED.active RETURNING *",
+            (rule['rule_name'], rule['rule_type'], rule['limit_value'], rule.get('attribute'),
+             rule.get('active', True))
+        )
+        stored.append(dict(cur.fetchone()))
+    return {'rules': stored, 'stored': len(stored)}
+
+
+def list_rules(cur, params):
+    cur.execute("SELECT * FROM compliance_rules ORDER BY rule_name")
+    return {'rules': [dict(row) for row in cur.fetchall()]}
+
+
+def evaluate_rules(cur, params):
+    trigger = params.get('trigger', 'manual')
+    if trigger not in TRIGGERS:
+        raise ValueError(f"Unknown trigger: {trigger}")
+
+    cur.execute(
+        "SELECT compliance_rule_id, rule_name, rule_type, limit_value, attribute "
+        "FROM compliance_rules WHERE active ORDER BY rule_name"
+    )
+    rows = cur.fetchall()
+    rule_ids = {row['rule_name']: row['compliance_rule_id'] for row in rows}
+    rules = [
+        ComplianceRule(row['rule_name'], row['rule_type'], float(row['limit_value']), row['attribute'])
+        for row in rows
+    ]
+
+    group_id = params.get('group_id')
+    portfolio_id = params.get('portfolio_id')
+    condition, args = group_filter(cur, group_id, portfolio_id=portfolio_id)
+    cur.execute(
+        "SELECT p.fund_name, p.current_nav, p.sector, m.manager_name, p.vintage, p.currency "
+        "FROM portfolio_data p LEFT JOIN managers m ON m.manager_id = p.manager_id "
+        f"WHERE p.status = 'Active' AND p.current_nav > 0 AND {condition} ORDER BY p.fund_name",
+        args
+    )
+    positions = [
+        {
+            'name': row['fund_name'],
+            'value': float(row['current_nav']),
+            'sector': row['sector'],
+            'manager': row['manager_name'] or 'Unassigned',
+            'vintage': row['vintage'],
+            'currency': row['currency'],
+        }
+        for row in cur.fetchall()
+    ]
+
+    result = ComplianceEngine(rules).evaluate(positions) if rules and positions else {
+        'compliant': True,
+        'total_value': sum(p['value'] for p in positions),
+        'results': [],
+        'violations': [],
+    }
+    result['n_rules'] = len(rules)
+    result['n_violations'] = len(result['violations'])
+    result['trigger'] = trigger
+    result['group_id'] = group_id
+    result['portfolio_id'] = portfolio_id
+    result['recorded'] = group_id is None and portfolio_id is None
+    if not result['recorded']:
+        return result
+
+    seen = []
+    new_violations = 0
+    for violation in result['violations']:
+        rule_id = rule_ids[violation['rule']]
+        group = violation.get('group', '')
+
+        cur.execute(
+            "UPDATE compliance_violations SET observed_value = %s, limit_value = %s, "
+            "last_seen_at = CURRENT_TIMESTAMP "
+            "WHERE compliance_rule_id = %s AND group_value = %s AND resolved_at IS NULL RETURNING violation_id",
+            (violation['value'], violation['limit'], rule_id, group)
+        )
+        row = cur.fetchone()
+        violation['new_violation'] = row is None
+        if row is None:
+            cur.execute(
+                "INSERT INTO compliance_violations (compliance_rule_id, rule_name, group_value, "
+                "observed_value, limit_value, evaluation_trigger) VALUES (%s, %s, %s, %s, %s, %s) "
+                "RETURNING violation_id",
+                (rule_id, violation['rule'], group, violation['value'], violation['limit'], trigger)
+            )
+            row = cur.fetchone()
+            new_violations += 1
+        violation['violation_id'] = row['violation_id']
+        seen.append(row['violation_id'])
+
+    # Anything still open that did not fail this evaluation now passes (or its rule was disabled)
+    cur.execute(
+        "UPDATE compliance_violations SET resolved_at = CURRENT_TIMESTAMP "
+        "WHERE resolved_at IS NULL AND violation_id <> ALL(%s::int[]) RETURNING violation_id",
+        (seen,)
+    )
+    result['n_resolved'] = len(cur.fetchall())
+    result['n_new_violations'] = new_violations
+    return result
+
+
+def list_violations(cur, params):
+    status = params.get('status', 'open')
+    if status not in VIOLATION_FILTERS:
+        raise ValueError(f"Unknown violation status: {status}")
+    cur.execute(
+        f"SELECT v.* FROM compliance_violations v {VIOLATION_FILTERS[status]} "
+        "ORDER BY v.detected_at DESC, v.violation_id DESC LIMIT %s",
+        (params.get('limit', 100),)
+    )
+    violations = [dict(row) for row in cur.fetchall()]
+    return {'status': status, 'violations': violations, 'n_violations': len(violations)}
+
+
+def _serialize(value):
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'check': check,
+    'set_rules': set_rules,
+    'rules': list_rules,
+    'evaluate': evaluate_rules,
+    'violations': list_violations,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'check')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        # Posted rules and positions need no database
+        if action == 'check':
+            result = check(None, params)
+        else:
+            database_url = os.environ.get('DATABASE_URL')
+            if not database_url:
+                raise ValueError("DATABASE_URL is not set")
+
+            conn = psycopg2.connect(database_url)
+            try:
+                with conn:
+                    with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                        result = ACTIONS[action](cur, params)
+            finally:
+                conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Compliance check error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,68 @@
+#!/usr/bin/env python3
+"""
+CPI series API script for web interface.
+
+Actions:
+    upload: store index levels in cpi_data
+    get:    return a stored series
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import execute_values
+from rates_store import load_cpi, DEFAULT_CPI_SERIES
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'get')
+        series_name = params.get('series_name') or DEFAULT_CPI_SERIES
+
+        if action == 'upload':
+            database_url = os.environ.get('DATABASE_URL')
+            if not database_url:
+                raise ValueError("DATABASE_URL is not set")
+
+            conn = psycopg2.connect(database_url)
+            try:
+                with conn:
+                    with conn.cursor() as cur:
+                        execute_values(
+                            cur,
+                            "INSERT INTO cpi_data (series_name, date, index_level) VALUES %s "
+                            "ON CONFLICT (series_name, date) DO UPDATE SET index_level = EXCLUDED.index_level",
+                            [(series_name, obs['date'], obs['level']) for obs in params['observations']]
+                        )
+            finally:
+                conn.close()
+            result = {'series_name': series_name, 'uploaded': len(params['observations'])}
+        elif action == 'get':
+            cpi = load_cpi(series_name)
+            result = {
+                'series_name': cpi.name,
+                'dates': [d.isoformat() for d in cpi.dates],
+                'levels': cpi.levels.tolist(),
+            }
+        else:
+            raise ValueError(f"Unknown action: {action}")
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"CPI error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,70 @@
+#!/usr/bin/env python3
+"""
+DCF valuation API script for web interface.
+
+The discount curve is taken from the request ('curve' with tenors/rates),
+a flat 'discount_rate', or the latest stored curve, in that order.
+"""
+
+import sys
+import json
+import os
+from datetime import date
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from pricing.rates import YieldCurve
+from pricing.valuation import DCFValuation
+from rates_store import load_curve, DEFAULT_CURVE
+
+
+def resolve_curve(params):
+    if params.get('curve'):
+        return YieldCurve(params['curve']['tenors'], params['curve']['rates'], name='request')
+    if params.get('discount_rate') is not None:
+        return YieldCurve.flat(params['discount_rate'], name='flat')
+
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        raise ValueError("No curve or discount_rate given and DATABASE_URL is not set")
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn.cursor() as cur:
+            curve = load_curve(cur, params.get('curve_name') or DEFAULT_CURVE, params.get('valuation_date'))
+    finally:
+        conn.close()
+
+    if curve is None:
+        raise ValueError("No stored yield curve; upload one or pass curve/discount_rate")
+    return curve
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        valuation_date = date.fromisoformat(params['valuation_date']) if params.get('valuation_date') else None
+        cash_flows = [
+            dict(cf, date=date.fromisoformat(cf['date'])) if 'date' in cf else cf
+            for cf in params['cash_flows']
+        ]
+
+        dcf = DCFValuation(resolve_curve(params), valuation_date=valuation_date)
+        result = dcf.value(cash_flows, scenarios=params.get('scenarios'))
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"DCF valuation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
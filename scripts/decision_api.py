@@ -0,0 +1,95 @@
+#!/usr/bin/env python3
+"""
+New fund decision API script for web interface.
+
+Compares the portfolio with and without a candidate fund commitment.
+Existing funds and unfunded commitments come from the request, or from
+active funds in portfolio_data when omitted (IRR as expected return,
+committed minus invested capital as unfunded).
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import LiquidityScenario, new_fund_decision
+from portfolio_groups import group_filter
+
+
+def load_portfolio(group_id=None, portfolio_id=None):
+    """Active funds (of a group's subtree and/or a portfolio) with NAV, IRR and volatility, and their total unfunded commitments."""
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        raise ValueError("funds and unfunded_commitments must be given when DATABASE_URL is not set")
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            condition, args = group_filter(cur, group_id, portfolio_id=portfolio_id)
+            cur.execute(
+                "SELECT p.fund_name, p.sector, p.current_nav, p.irr, p.volatility, "
+                "GREATEST(p.committed_capital - COALESCE(p.invested_capital, 0), 0) AS unfunded "
+                f"FROM portfolio_data p WHERE p.status = 'Active' AND {condition}",
+                args
+            )
+            rows = cur.fetchall()
+    finally:
+        conn.close()
+
+    funds = [
+        {
+            'name': row['fund_name'],
+            'sector': row['sector'],
+            'nav': float(row['current_nav']),
+            'expected_return': float(row['irr']),
+            'volatility': float(row['volatility']),
+        }
+        for row in rows
+        if row['current_nav'] is not None and row['irr'] is not None and row['volatility'] is not None
+    ]
+    return funds, sum(float(row['unfunded']) for row in rows)
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        funds = params.get('funds')
+        unfunded = params.get('unfunded_commitments')
+        if funds is None or unfunded is None:
+            stored_funds, stored_unfunded = load_portfolio(params.get('group_id'), params.get('portfolio_id'))
+            funds = stored_funds if funds is None else funds
+            unfunded = stored_unfunded if unfunded is None else unfunded
+
+        result = new_fund_decision(
+            funds=funds,
+            candidate=params['candidate'],
+            liquid_assets=params['liquid_assets'],
+            unfunded_commitments=unfunded,
+            horizon_years=params.get('horizon_years', 5.0),
+            correlation=params.get('correlation', 0.5),
+            confidence_level=params.get('confidence_level', 0.95),
+            liquidity_scenario=LiquidityScenario.stressed(**params.get('scenario', {})),
+            quarters=params.get('quarters', 12),
+            n_paths=params.get('n_paths', 20000),
+            seed=params.get('seed', 42)
+        )
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Decision error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,140 @@
+#!/usr/bin/env python3
+"""
+Fund forecast storage and accuracy API script for web interface.
+
+Actions:
+    record_forecasts: append forecast versions to ml_predictions
+    record_actuals:   store realized fund IRR by date in fund_irr_history
+    accuracy:         MAPE, bias, RMSE and MAE per model, version and fund,
+                      with a naive last-actual baseline
+
+Forecasts are never overwritten, so every version stays available for
+scoring. Forecasts without a target_date cannot be scored and are reported
+separately.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor, execute_values
+from analytics import forecast_accuracy
+
+FORECAST_COLUMNS = [
+    'fund_id', 'model_name', 'model_version', 'prediction_date', 'target_date',
+    'predicted_irr', 'confidence_lower', 'confidence_upper', 'features'
+]
+
+
+def record_forecasts(cur, params):
+    rows = [
+        tuple(
+            json.dumps(f[c]) if c == 'features' and f.get(c) is not None else f.get(c)
+            for c in FORECAST_COLUMNS
+        )
+        for f in params['forecasts']
+    ]
+    execute_values(
+        cur,
+        f"INSERT INTO ml_predictions ({', '.join(FORECAST_COLUMNS)}) VALUES %s RETURNING prediction_id",
+        rows
+    )
+    return {'stored': len(rows), 'prediction_ids': [row['prediction_id'] for row in cur.fetchall()]}
+
+
+def record_actuals(cur, params):
+    rows = [(a['fund_id'], a['as_of_date'], a['irr']) for a in params['actuals']]
+    execute_values(
+        cur,
+        "INSERT INTO fund_irr_history (fund_id, as_of_date, irr) VALUES %s "
+        "ON CONFLICT (fund_id, as_of_date) DO UPDATE SET irr = EXCLUDED.irr",
+        rows
+    )
+    return {'stored': len(rows)}
+
+
+def accuracy(cur, params):
+    filters, args = ["mp.predicted_irr IS NOT NULL"], []
+    if params.get('model_name'):
+        filters.append("mp.model_name = %s")
+        args.append(params['model_name'])
+    if params.get('fund_id'):
+        filters.append("mp.fund_id = %s")
+        args.append(params['fund_id'])
+
+    cur.execute(
+        "SELECT p.fund_name, mp.model_name, mp.model_version, mp.prediction_date, mp.target_date, "
+        "mp.predicted_irr FROM ml_predictions mp JOIN portfolio_data p ON p.fund_id = mp.fund_id "
+        f"WHERE {' AND '.join(filters)}",
+        args
+    )
+    rows = cur.fetchall()
+    forecasts = [
+        {
+            'fund': row['fund_name'],
+            'model': row['model_name'],
+            'version': row['model_version'],
+            'prediction_date': row['prediction_date'],
+            'target_date': row['target_date'],
+            'predicted': float(row['predicted_irr']),
+        }
+        for row in rows if row['target_date'] is not None
+    ]
+
+    cur.execute(
+        "SELECT p.fund_name, h.as_of_date, h.irr FROM fund_irr_history h "
+        "JOIN portfolio_data p ON p.fund_id = h.fund_id"
+    )
+    actuals = [
+        {'fund': row['fund_name'], 'date': row['as_of_date'], 'actual': float(row['irr'])}
+        for row in cur.fetchall()
+    ]
+
+    result = forecast_accuracy(forecasts, actuals)
+    result['n_without_target_date'] = len(rows) - len(forecasts)
+    return result
+
+
+ACTIONS = {
+    'record_forecasts': record_forecasts,
+    'record_actuals': record_actuals,
+    'accuracy': accuracy,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'accuracy')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=float))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Forecast error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
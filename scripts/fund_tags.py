@@ -0,0 +1,25 @@
+"""
+Fund tag filters shared by the API scripts.
+
+Tags are free-form key/value pairs per fund in fund_tags, set through
+scripts/funds_api.py. List actions accept tags as {key: value}; a fund
+matches when it carries every pair. Lists of managers and companies keep
+the rows related to at least one matching fund.
+"""
+
+from typing import Dict, List, Optional, Tuple
+
+
+def tag_filter(tags: Optional[Dict[str, str]], fund_id_column: str = 'p.fund_id') -> Tuple[str, List[str]]:
+    """SQL condition on a fund id column (trusted) and its arguments."""
+    if not tags:
+        return 'TRUE', []
+
+    clauses, args = [], []
+    for key, value in sorted(tags.items()):
+        clauses.append(
+            f"EXISTS (SELECT 1 FROM fund_tags t WHERE t.fund_id = {fund_id_column} "
+            "AND t.tag_key = %s AND t.tag_value = %s)"
+        )
+        args.extend([key, str(value)])
+    return ' AND '.join(clauses), args
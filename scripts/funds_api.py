@@ -0,0 +1,213 @@
+#!/usr/bin/env python3
+"""
+Fund and fund tag API script for web interface.
+
+Actions:
+    list:      funds with their tags, filtered by tags ({key: value}, all
+               must match) and optionally sector, vintage, status and
+               portfolio group (group_id, including its subgroups) and
+               portfolio (portfolio_id);
+               fields=[...] keeps only those columns (FUND_FIELDS) and
+               format='ndjson' streams one fund per line instead
+    page:      one page of the list in the /api/v2 envelope ({data, meta})
+    tags:      one fund's tags
+    set_tags:  add or change a fund's tags (a null value removes a tag;
+               replace=true also removes tags that are not given)
+    aggregate: funds grouped by the value of one tag key (untagged funds
+               under 'Untagged'), with capital, NAV, commitment-weighted IRR
+               and share of NAV, optionally filtered by other tags, group
+               and portfolio
+
+Reads portfolio_data, managers and fund_tags from DATABASE_URL.
+"""
+
+import sys
+import json
+import os
+from datetime import datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from fund_tags import tag_filter
+from portfolio_groups import group_filter
+from row_stream import check_fields, select_fields, stream_rows
+from list_envelope import list_page
+
+UNTAGGED = 'Untagged'
+
+# Columns of a list row, selectable with fields
+FUND_FIELDS = (
+    'fund_id', 'fund_name', 'manager_name', 'vintage', 'sector', 'committed_capital',
+    'invested_capital', 'current_nav', 'irr', 'tvpi', 'dpi', 'status', 'tags'
+)
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def fund_tags(cur, fund_id):
+    cur.execute("SELECT 1 FROM portfolio_data WHERE fund_id = %s", (fund_id,))
+    if cur.fetchone() is None:
+        raise ValueError(f"No fund with id {fund_id}")
+    rows = fetch_rows(cur, "SELECT tag_key, tag_value FROM fund_tags WHERE fund_id = %s ORDER BY tag_key", (fund_id,))
+    return {row['tag_key']: row['tag_value'] for row in rows}
+
+
+def fund_list_query(cur, params):
+    condition, args = tag_filter(params.get('tags'))
+    in_group, group_args = group_filter(cur, params.get('group_id'), portfolio_id=params.get('portfolio_id'))
+    return (
+        "SELECT p.fund_id, p.fund_name, m.manager_name, p.vintage, p.sector, p.committed_capital, "
+        "p.invested_capital, p.current_nav, p.irr, p.tvpi, p.dpi, p.status, "
+        "COALESCE((SELECT jsonb_object_agg(t.tag_key, t.tag_value) FROM fund_tags t "
+        "WHERE t.fund_id = p.fund_id), '{}'::jsonb) AS tags "
+        "FROM portfolio_data p LEFT JOIN managers m ON m.manager_id = p.manager_id "
+        f"WHERE {condition} AND {in_group} "
+        "AND (%s::text IS NULL OR p.sector = %s::text) AND (%s::int IS NULL OR p.vintage = %s::int) "
+        "AND (%s::text IS NULL OR p.status = %s::text) "
+        "ORDER BY p.fund_name",
+        args + group_args + [params.get('sector'), params.get('sector'), params.get('vintage'), params.get('vintage'),
+                params.get('status'), params.get('status')]
+    )
+
+
+def list_funds(cur, params):
+    rows = fetch_rows(cur, *fund_list_query(cur, params))
+    funds = [select_fields(row, params.get('fields')) for row in rows]
+    return {
+        'tags': params.get('tags') or {},
+        'group_id': params.get('group_id'),
+        'portfolio_id': params.get('portfolio_id'),
+        'funds': funds,
+        'n_funds': len(funds),
+    }
+
+
+def page_funds(cur, params):
+    return list_page(cur, fund_list_query, params)
+
+
+def get_tags(cur, params):
+    return {'fund_id': params['fund_id'], 'tags': fund_tags(cur, params['fund_id'])}
+
+
+def set_tags(cur, params):
+    fund_id = params['fund_id']
+    current = fund_tags(cur, fund_id)
+    tags = params['tags']
+
+    removed = [key for key, value in tags.items() if value is None and key in current]
+    if params.get('replace'):
+        removed += [key for key in current if key not in tags]
+    if removed:
+        cur.execute("DELETE FROM fund_tags WHERE fund_id = %s AND tag_key = ANY(%s)", (fund_id, removed))
+
+    for key, value in sorted(tags.items()):
+        if value is None:
+            continue
+        cur.execute(
+            "INSERT INTO fund_tags (fund_id, tag_key, tag_value) VALUES (%s, %s, %s) "
+            "ON CONFLICT (fund_id, tag_key) DO UPDATE SET tag_value = EXCLUDED.tag_value",
+            (fund_id, key, str(value))
+        )
+
+    return {'fund_id': fund_id, 'tags': fund_tags(cur, fund_id), 'removed': sorted(removed)}
+
+
+def aggregate(cur, params):
+    key = params['key']
+    condition, args = tag_filter(params.get('tags'))
+    in_group, group_args = group_filter(cur, params.get('group_id'), portfolio_id=params.get('portfolio_id'))
+    groups = fetch_rows(
+        cur,
+        "SELECT COALESCE(g.tag_value, %s) AS tag_value, "
+        "COUNT(p.fund_id) AS num_funds, "
+        "SUM(p.committed_capital) AS total_committed, "
+        "SUM(p.invested_capital) AS total_invested, "
+        "SUM(p.current_nav) AS total_nav, "
+        "SUM(p.irr * p.committed_capital) / NULLIF(SUM(p.committed_capital), 0) AS commitment_weighted_irr, "
+        "AVG(p.tvpi) AS avg_tvpi, "
+        "SUM(p.current_nav) / NULLIF(SUM(SUM(p.current_nav)) OVER (), 0) AS nav_share "
+        "FROM portfolio_data p LEFT JOIN fund_tags g ON g.fund_id = p.fund_id AND g.tag_key = %s "
+        f"WHERE {condition} AND {in_group} AND (%s::text IS NULL OR p.status = %s::text) "
+        "GROUP BY 1 "
+        "ORDER BY total_nav DESC NULLS LAST, tag_value",
+        [UNTAGGED, key] + args + group_args + [params.get('status'), params.get('status')]
+    )
+    return {
+        'key': key,
+        'tags': params.get('tags') or {},
+        'group_id': params.get('group_id'),
+        'portfolio_id': params.get('portfolio_id'),
+        'groups': groups,
+        'n_groups': len(groups),
+    }
+
+
+def _serialize(value):
+    # NUMERIC columns arrive as Decimal
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, datetime):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'list': list_funds,
+    'page': page_funds,
+    'tags': get_tags,
+    'set_tags': set_tags,
+    'aggregate': aggregate,
+}
+
+# Actions that can be streamed as NDJSON (format='ndjson'), one row per line
+STREAMS = {
+    'list': fund_list_query,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'list')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+        if params.get('format') == 'ndjson' and action not in STREAMS:
+            raise ValueError(f"Action {action} cannot be streamed")
+        if action in ('list', 'page'):
+            check_fields(params.get('fields'), FUND_FIELDS)
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            if params.get('format') == 'ndjson':
+                stream_rows(conn, STREAMS[action], params, sys.stdout, default=_serialize)
+            else:
+                with conn:
+                    with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                        result = ACTIONS[action](cur, params)
+                print(json.dumps(result, default=_serialize))
+        finally:
+            conn.close()
+
+    except Exception as e:
+        print(json.dumps({"error": f"Fund error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,195 @@
+#!/usr/bin/env python3
+"""
+Portfolio group API script for web interface.
+
+Actions:
+    tree:   every group with the figures of its own funds ('direct') and of
+            its whole subtree ('rollup'), nested under its parent
+    get:    one group's subtree and the funds in it
+    create: add a group (under parent_id, or as a root)
+    update: rename, re-describe or move a group (not under its own subtree)
+    delete: remove a group without children (its funds become unassigned)
+    assign: assign funds to a group (group_id null unassigns them)
+
+Analytics that read funds from the database accept group_id (see
+portfolio_groups.group_filter); figures roll up with analytics.rollup_groups.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import rollup_groups
+from portfolio_groups import SUBTREE_QUERY, group_filter
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def build_tree(cur):
+    groups = fetch_rows(
+        cur,
+        "SELECT g.group_id, g.group_name, g.parent_id, g.description, "
+        "COUNT(p.fund_id) AS num_funds, "
+        "COALESCE(SUM(p.committed_capital), 0) AS total_committed, "
+        "COALESCE(SUM(p.invested_capital), 0) AS total_invested, "
+        "COALESCE(SUM(p.current_nav), 0) AS total_nav, "
+        "COALESCE(SUM(p.irr * p.committed_capital), 0) AS irr_weighted, "
+        "COALESCE(SUM(p.committed_capital) FILTER (WHERE p.irr IS NOT NULL), 0) AS irr_committed "
+        "FROM portfolio_groups g "
+        "LEFT JOIN portfolio_group_funds gf ON gf.group_id = g.group_id "
+        "LEFT JOIN portfolio_data p ON p.fund_id = gf.fund_id "
+        "GROUP BY g.group_id"
+    )
+    return rollup_groups(groups)
+
+
+def find_node(nodes, group_id):
+    for node in nodes:
+        if node['group_id'] == group_id:
+            return node
+        found = find_node(node['children'], group_id)
+        if found is not None:
+            return found
+    return None
+
+
+def tree(cur, params):
+    roots = build_tree(cur)
+    cur.execute("SELECT COUNT(*) AS n FROM portfolio_data WHERE fund_id NOT IN (SELECT fund_id FROM portfolio_group_funds)")
+    return {'groups': roots, 'n_unassigned_funds': cur.fetchone()['n']}
+
+
+def get_group(cur, params):
+    condition, args = group_filter(cur, params['group_id'])
+    node = find_node(build_tree(cur), params['group_id'])
+    node['funds'] = fetch_rows(
+        cur,
+        "SELECT p.fund_id, p.fund_name, gf.group_id, p.vintage, p.sector, p.committed_capital, "
+        "p.current_nav, p.irr, p.tvpi, p.status "
+        "FROM portfolio_data p JOIN portfolio_group_funds gf ON gf.fund_id = p.fund_id "
+        f"WHERE {condition} ORDER BY p.fund_name",
+        args
+    )
+    return node
+
+
+def create_group(cur, params):
+    cur.execute(
+        "INSERT INTO portfolio_groups (group_name, parent_id, description) VALUES (%s, %s, %s) RETURNING *",
+        (params['group_name'], params.get('parent_id'), params.get('description'))
+    )
+    return dict(cur.fetchone())
+
+
+def update_group(cur, params):
+    group_id = params['group_id']
+    group_filter(cur, group_id)
+
+    if params.get('parent_id') is not None:
+        cur.execute(SUBTREE_QUERY, (group_id,))
+        if params['parent_id'] in {row['group_id'] for row in cur.fetchall()}:
+            raise ValueError("A group cannot be moved under itself or its own subgroups")
+
+    fields = [k for k in ('group_name', 'parent_id', 'description') if k in params]
+    if not fields:
+        raise ValueError("Nothing to update")
+    cur.execute(
+        f"UPDATE portfolio_groups SET {', '.join(f'{k} = %s' for k in fields)} WHERE group_id = %s RETURNING *",
+        [params[k] for k in fields] + [group_id]
+    )
+    return dict(cur.fetchone())
+
+
+def delete_group(cur, params):
+    group_filter(cur, params['group_id'])
+    cur.execute("SELECT COUNT(*) AS n FROM portfolio_groups WHERE parent_id = %s", (params['group_id'],))
+    if cur.fetchone()['n'] > 0:
+        raise ValueError("Delete or move the group's subgroups first")
+    cur.execute("DELETE FROM portfolio_group_funds WHERE group_id = %s", (params['group_id'],))
+    unassigned = cur.rowcount
+    cur.execute("DELETE FROM portfolio_groups WHERE group_id = %s", (params['group_id'],))
+    return {'group_id': params['group_id'], 'deleted': True, 'unassigned_funds': unassigned}
+
+
+def assign(cur, params):
+    fund_ids = params['fund_ids']
+    cur.execute("SELECT fund_id FROM portfolio_data WHERE fund_id = ANY(%s)", (fund_ids,))
+    missing = sorted(set(fund_ids) - {row['fund_id'] for row in cur.fetchall()})
+    if missing:
+        raise ValueError(f"No funds with ids {', '.join(map(str, missing))}")
+
+    group_id = params.get('group_id')
+    if group_id is None:
+        cur.execute("DELETE FROM portfolio_group_funds WHERE fund_id = ANY(%s)", (fund_ids,))
+    else:
+        group_filter(cur, group_id)
+        for fund_id in fund_ids:
+            cur.execute(
+                "INSERT INTO portfolio_group_funds (fund_id, group_id) VALUES (%s, %s) "
+                "ON CONFLICT (fund_id) DO UPDATE SET group_id = EXCLUDED.group_id, assigned_at = CURRENT_TIMESTAMP",
+                (fund_id, group_id)
+            )
+    return {'group_id': group_id, 'fund_ids': fund_ids, 'assigned': len(fund_ids)}
+
+
+def _serialize(value):
+    # NUMERIC columns arrive as Decimal
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'tree': tree,
+    'get': get_group,
+    'create': create_group,
+    'update': update_group,
+    'delete': delete_group,
+    'assign': assign,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'tree')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Portfolio group error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,62 @@
+#!/usr/bin/env python3
+"""
+Liquidity stress test API script for web interface.
+
+Runs the stressed scenario (the GFC-style preset with any overrides) and,
+unless compare_base is false, the base case on the same random draws.
+With an assumption_set_id, the set's pacing parameters replace the scenario
+defaults in both runs; scenario and base_scenario overrides still win.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+from analytics import LiquidityScenario, LiquidityStressTest
+from assumptions_store import resolve_assumption_set
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        test = LiquidityStressTest(
+            liquid_assets=params['liquid_assets'],
+            private_nav=params['private_nav'],
+            unfunded_commitments=params['unfunded_commitments'],
+            n_paths=params.get('n_paths', 10000),
+            seed=params.get('seed', 42)
+        )
+        options = {
+            'quarters': params.get('quarters', 12),
+            'liquidity_floor': params.get('liquidity_floor', 0.0),
+            'max_private_weight': params.get('max_private_weight'),
+        }
+
+        assumptions, assumption_set = resolve_assumption_set(params)
+        pacing = assumptions.pacing if assumptions is not None else {}
+
+        stress = LiquidityScenario.stressed(**{**pacing, **params.get('scenario', {})})
+        result = {'stress': test.run(stress, **options)}
+        if params.get('compare_base', True):
+            base = LiquidityScenario(**{**pacing, **params.get('base_scenario', {})})
+            result['base'] = test.run(base, **options)
+        if assumption_set is not None:
+            result['assumption_set'] = assumption_set
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Liquidity stress test error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,47 @@
+"""
+Paged list envelope shared by the /api/v2 list endpoints.
+
+A page action runs a list query for one page of rows and wraps them as
+{data, meta}: meta carries count (rows on this page), total (rows matching
+the filters), limit, offset, took_ms (time spent reading the page) and
+as_of (database time the page was read), so clients need no out-of-band
+headers to page through a list or tell how fresh it is.
+"""
+
+import time
+from typing import Callable, Dict, List, Tuple
+
+from row_stream import select_fields
+
+DEFAULT_LIMIT = 100
+MAX_LIMIT = 1000
+
+
+def list_page(cur, build_query: Callable[..., Tuple[str, List]], params: Dict) -> Dict:
+    """One page of the rows of build_query(cur, params) in the v2 envelope (cur returns dict rows)."""
+    limit = params.get('limit', DEFAULT_LIMIT)
+    offset = params.get('offset', 0)
+    if not isinstance(limit, int) or not 1 <= limit <= MAX_LIMIT:
+        raise ValueError(f"limit must be an integer between 1 and {MAX_LIMIT}")
+    if not isinstance(offset, int) or offset < 0:
+        raise ValueError("offset must be a non-negative integer")
+
+    started = time.perf_counter()
+    query, args = build_query(cur, params)
+
+    cur.execute(f"SELECT COUNT(*) AS total, CURRENT_TIMESTAMP AS as_of FROM ({query}) AS listed", args)
+    summary = cur.fetchone()
+    cur.execute(f"{query} LIMIT %s OFFSET %s", list(args) + [limit, offset])
+    rows = [select_fields(row, params.get('fields')) for row in cur.fetchall()]
+
+    return {
+        'data': rows,
+        'meta': {
+            'count': len(rows),
+            'total': summary['total'],
+            'limit': limit,
+            'offset': offset,
+            'took_ms': round((time.perf_counter() - started) * 1000, 1),
+            'as_of': summary['as_of'],
+        },
+    }
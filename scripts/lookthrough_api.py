@@ -0,0 +1,70 @@
+#!/usr/bin/env python3
+"""
+Look-through exposure API script for web interface.
+
+Reads funds, portfolio companies and company investments from DATABASE_URL.
+An optional JSON argument {"group_id": N, "portfolio_id": N} limits the
+funds to a portfolio group's subtree and/or one portfolio.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import lookthrough_exposure
+from portfolio_groups import group_filter
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def main():
+    try:
+        params = json.loads(sys.argv[1]) if len(sys.argv) > 1 else {}
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                condition, args = group_filter(cur, params.get('group_id'), portfolio_id=params.get('portfolio_id'))
+                funds = fetch_rows(
+                    cur,
+                    f"SELECT p.fund_id, p.sector, p.current_nav FROM portfolio_data p WHERE p.status = 'Active' AND {condition}",
+                    args
+                )
+                companies = fetch_rows(cur, "SELECT company_id, company_name, sector, geography FROM portfolio_companies")
+                investments = fetch_rows(
+                    cur,
+                    "SELECT fund_id, company_id, current_valuation FROM company_investments WHERE exit_date IS NULL"
+                )
+        finally:
+            conn.close()
+
+        active = {f['fund_id'] for f in funds}
+        investments = [inv for inv in investments if inv['fund_id'] in active]
+
+        result = lookthrough_exposure(funds, companies, investments)
+        for scope in ('group_id', 'portfolio_id'):
+            if params.get(scope) is not None:
+                result[scope] = params[scope]
+
+        # NUMERIC columns arrive as Decimal
+        print(json.dumps(result, default=float))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Look-through error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
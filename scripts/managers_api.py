@@ -0,0 +1,151 @@
+#!/usr/bin/env python3
+"""
+Manager API script for web interface.
+
+Actions:
+    list: every manager with its fund count, capital, NAV, commitment-weighted
+          IRR and share of the portfolio (vw_manager_summary); with tags,
+          group_id or portfolio_id, only managers of at least one matching
+          fund; fields=[...] keeps only those columns (MANAGER_FIELDS) and
+          format='ndjson' streams one manager per line instead
+    page: one page of the list in the /api/v2 envelope ({data, meta})
+    get:  one manager's summary and its funds
+
+Reads managers and portfolio_data from DATABASE_URL.
+"""
+
+import sys
+import json
+import os
+from datetime import datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from fund_tags import tag_filter
+from portfolio_groups import group_filter
+from row_stream import check_fields, select_fields, stream_rows
+from list_envelope import list_page
+
+SUMMARY_QUERY = (
+    "SELECT s.*, m.headquarters, m.primary_strategy, m.founded_year, m.aum "
+    "FROM vw_manager_summary s JOIN managers m ON m.manager_id = s.manager_id"
+)
+
+# Columns of a list row, selectable with fields
+MANAGER_FIELDS = (
+    'manager_id', 'manager_name', 'num_funds', 'total_committed', 'total_invested',
+    'total_nav', 'commitment_weighted_irr', 'avg_tvpi', 'commitment_share',
+    'nav_share', 'headquarters', 'primary_strategy', 'founded_year', 'aum'
+)
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def manager_list_query(cur, params):
+    condition, args = tag_filter(params.get('tags'))
+    in_group, group_args = group_filter(cur, params.get('group_id'), portfolio_id=params.get('portfolio_id'))
+    unfiltered = not params.get('tags') and params.get('group_id') is None and params.get('portfolio_id') is None
+    return (
+        f"{SUMMARY_QUERY} WHERE %s OR EXISTS (SELECT 1 FROM portfolio_data p "
+        f"WHERE p.manager_id = s.manager_id AND {condition} AND {in_group}) "
+        "ORDER BY s.total_nav DESC NULLS LAST, s.manager_name",
+        [unfiltered] + args + group_args
+    )
+
+
+def list_managers(cur, params):
+    rows = fetch_rows(cur, *manager_list_query(cur, params))
+    managers = [select_fields(row, params.get('fields')) for row in rows]
+    return {
+        'tags': params.get('tags') or {},
+        'group_id': params.get('group_id'),
+        'portfolio_id': params.get('portfolio_id'),
+        'managers': managers,
+        'n_managers': len(managers),
+    }
+
+
+def page_managers(cur, params):
+    return list_page(cur, manager_list_query, params)
+
+
+def get_manager(cur, params):
+    rows = fetch_rows(cur, f"{SUMMARY_QUERY} WHERE s.manager_id = %s", (params['manager_id'],))
+    if not rows:
+        raise ValueError(f"No manager with id {params['manager_id']}")
+
+    manager = rows[0]
+    manager['funds'] = fetch_rows(
+        cur,
+        "SELECT fund_id, fund_name, vintage, sector, committed_capital, invested_capital, current_nav, "
+        "irr, tvpi, dpi, status FROM portfolio_data WHERE manager_id = %s ORDER BY vintage, fund_name",
+        (params['manager_id'],)
+    )
+    return manager
+
+
+def _serialize(value):
+    # NUMERIC columns arrive as Decimal
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, datetime):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'list': list_managers,
+    'page': page_managers,
+    'get': get_manager,
+}
+
+# Actions that can be streamed as NDJSON (format='ndjson'), one row per line
+STREAMS = {
+    'list': manager_list_query,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'list')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+        if params.get('format') == 'ndjson' and action not in STREAMS:
+            raise ValueError(f"Action {action} cannot be streamed")
+        if action in ('list', 'page'):
+            check_fields(params.get('fields'), MANAGER_FIELDS)
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            if params.get('format') == 'ndjson':
+                stream_rows(conn, STREAMS[action], params, sys.stdout, default=_serialize)
+            else:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+                print(json.dumps(result, default=_serialize))
+        finally:
+            conn.close()
+
+    except Exception as e:
+        print(json.dumps({"error": f"Manager error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,170 @@
+#!/usr/bin/env python3
+"""
+Return series metrics API script for web interface.
+
+Actions:
+    desmooth:    remove appraisal smoothing from reported returns
+    interpolate: fill NAVs between reporting dates
+    risk:        volatility, Sharpe, VaR and correlation (optionally de-smoothed),
+                 plus tail dependence between components when given
+    irr:         IRR of dated cash flows
+    var_contributions: marginal, component and incremental VaR per holding
+                 from simulated joint outcomes
+    shrinkage:   raw and peer-group shrunk fund IRR and volatility (funds from
+                 the request, or active funds in portfolio_data, optionally of
+                 a portfolio group's subtree with group_id and/or a portfolio
+                 with portfolio_id)
+
+risk and irr accept inflation_adjusted=true to deflate by the stored CPI series.
+"""
+
+import sys
+import json
+import os
+from datetime import date
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import (
+    ReturnDesmoother, interpolate_nav, return_risk_metrics, nominal_and_real_irr, tail_dependence,
+    var_contributions, shrink_fund_estimates
+)
+from rates_store import resolve_risk_free_rate, load_cpi
+from portfolio_groups import group_filter
+
+
+def load_fund_estimates(frequency, group_id=None, portfolio_id=None):
+    """Active funds (of a group's subtree and/or a portfolio) with IRR and volatility; history length is taken from the vintage."""
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        raise ValueError("funds must be given when DATABASE_URL is not set")
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            condition, args = group_filter(cur, group_id, portfolio_id=portfolio_id)
+            cur.execute(
+                "SELECT p.fund_name, p.sector, p.vintage, p.irr, p.volatility, "
+                "GREATEST(EXTRACT(YEAR FROM CURRENT_DATE)::int - p.vintage, 1) AS years "
+                "FROM portfolio_data p WHERE p.status = 'Active' AND p.irr IS NOT NULL AND p.volatility IS NOT NULL "
+                f"AND {condition}",
+                args
+            )
+            rows = cur.fetchall()
+    finally:
+        conn.close()
+
+    return [
+        {
+            'name': row['fund_name'],
+            'sector': row['sector'],
+            'vintage': row['vintage'],
+            'irr': float(row['irr']),
+            'volatility': float(row['volatility']),
+            'n_observations': row['years'] * frequency,
+        }
+        for row in rows
+    ]
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'desmooth')
+
+        if action == 'desmooth':
+            desmoother = ReturnDesmoother(params['returns'], frequency=params.get('frequency', 4))
+            method = params.get('method', 'getmansky')
+            if method == 'getmansky':
+                result = desmoother.getmansky(lags=params.get('lags', 2))
+            else:
+                result = desmoother.desmooth(method)
+        elif action == 'interpolate':
+            navs = interpolate_nav(
+                params['navs'],
+                steps=params.get('steps', 3),
+                method=params.get('method', 'geometric')
+            )
+            result = {'navs': navs.tolist(), 'steps': params.get('steps', 3)}
+        elif action == 'risk':
+            risk_free_rate, risk_free_source = resolve_risk_free_rate(params)
+            returns = params['returns']
+            tail_quantile = params.get('tail_quantile', 0.1)
+
+            # Tail dependence on nominal returns; stress periods follow the portfolio
+            dependence = {}
+            if params.get('components'):
+                dependence['historical'] = tail_dependence(
+                    params['components'], reference=returns, quantile=tail_quantile
+                )
+            if params.get('simulated_components'):
+                dependence['simulated'] = tail_dependence(
+                    params['simulated_components'], quantile=tail_quantile
+                )
+            benchmark = params.get('benchmark')
+
+            if params.get('inflation_adjusted'):
+                if not params.get('dates'):
+                    raise ValueError("dates (period boundaries) are required for inflation adjustment")
+                dates = [date.fromisoformat(d) for d in params['dates']]
+                cpi = load_cpi(params.get('cpi_series'))
+                returns = cpi.deflate_returns(dates, returns)
+                if benchmark is not None:
+                    benchmark = cpi.deflate_returns(dates, benchmark)
+                risk_free_rate = cpi.deflate_rate(risk_free_rate, dates[0], dates[-1])
+
+            result = return_risk_metrics(
+                returns,
+                benchmark=benchmark,
+                frequency=params.get('frequency', 4),
+                risk_free_rate=risk_free_rate,
+                confidence_level=params.get('confidence_level', 0.95),
+                desmooth=params.get('desmooth', False),
+                method=params.get('method', 'getmansky'),
+                lags=params.get('lags', 2)
+            )
+            result['risk_free_rate'] = {'rate': risk_free_rate, 'source': risk_free_source}
+            result['inflation_adjusted'] = bool(params.get('inflation_adjusted'))
+            if dependence:
+                result['dependence'] = dependence
+        elif action == 'irr':
+            dates = [date.fromisoformat(cf['date']) for cf in params['cash_flows']]
+            amounts = [cf['amount'] for cf in params['cash_flows']]
+            cpi = load_cpi(params.get('cpi_series')) if params.get('inflation_adjusted') else None
+            result = nominal_and_real_irr(dates, amounts, cpi)
+            result['inflation_adjusted'] = cpi is not None
+        elif action == 'var_contributions':
+            result = var_contributions(
+                params['scenarios'],
+                params['positions'],
+                confidence_level=params.get('confidence_level', 0.95),
+                bandwidth=params.get('bandwidth')
+            )
+        elif action == 'shrinkage':
+            frequency = params.get('frequency', 4)
+            result = shrink_fund_estimates(
+                params.get('funds') or load_fund_estimates(frequency, params.get('group_id'), params.get('portfolio_id')),
+                group_by=params.get('group_by', 'sector'),
+                strength=params.get('strength', 1.0),
+                frequency=frequency,
+                min_group_size=params.get('min_group_size', 3)
+            )
+        else:
+            raise ValueError(f"Unknown action: {action}")
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Metrics error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,89 @@
+"""
+Model registry access shared by the API scripts.
+
+Calibrated parameters are registered through scripts/models_api.py (or
+directly by scripts/calibrate_api.py) into the model_registry table.
+Registering an existing model_name adds a version; stored versions are
+never modified. Simulation scripts call resolve_model_inputs with a
+model_id to take their drift and volatility from the registry.
+"""
+
+import json
+import os
+from typing import Dict, Optional, Tuple
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import validate_model_parameters, simulation_inputs
+
+METADATA_COLUMNS = ('data_source', 'data_start', 'data_end', 'n_observations', 'frequency', 'author', 'notes')
+
+
+def register_model(
+    cur,
+    model_name: str,
+    model_type: str,
+    parameters: Dict,
+    fit_statistics: Optional[Dict] = None,
+    **metadata
+) -> Dict:
+    """Validate and store parameters as the next version of model_name."""
+    validate_model_parameters(model_type, parameters)
+    unknown = [k for k in metadata if k not in METADATA_COLUMNS]
+    if unknown:
+        raise ValueError(f"Unknown model metadata: {', '.join(unknown)}")
+    if model_type != 'factor_loadings' and not metadata.get('frequency'):
+        raise ValueError(f"{model_type} models need the frequency of their calibration data")
+
+    columns = list(METADATA_COLUMNS)
+    cur.execute(
+        "INSERT INTO model_registry (model_name, model_type, version, parameters, fit_statistics, "
+        f"{', '.join(columns)}) "
+        f"SELECT %s, %s, COALESCE(MAX(version), 0) + 1, %s, %s, {', '.join(['%s'] * len(columns))} "
+        "FROM model_registry WHERE model_name = %s RETURNING *",
+        (model_name, model_type, json.dumps(parameters),
+         json.dumps(fit_statistics) if fit_statistics is not None else None,
+         *(metadata.get(c) for c in columns), model_name)
+    )
+    return dict(cur.fetchone())
+
+
+def load_model(cur, model_id: int) -> Dict:
+    """Registered model by id."""
+    cur.execute("SELECT * FROM model_registry WHERE model_id = %s", (model_id,))
+    row = cur.fetchone()
+    if row is None:
+        raise ValueError(f"No registered model with id {model_id}")
+    return dict(row)
+
+
+def resolve_model_inputs(model_id: int) -> Tuple[Dict[str, float], Dict]:
+    """
+    Annual drift and volatility of a registered model.
+
+    Returns:
+        (inputs, reference) with inputs {'mu', 'sigma'} and the model's id,
+        name, version, type and data window
+    """
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        raise ValueError("model_id requires DATABASE_URL")
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            model = load_model(cur, model_id)
+    finally:
+        conn.close()
+
+    inputs = simulation_inputs(model['model_type'], model['parameters'], model['frequency'])
+    reference = {
+        'model_id': model['model_id'],
+        'model_name': model['model_name'],
+        'version': model['version'],
+        'model_type': model['model_type'],
+        'data_source': model['data_source'],
+        'data_start': model['data_start'].isoformat() if model['data_start'] else None,
+        'data_end': model['data_end'].isoformat() if model['data_end'] else None,
+    }
+    return inputs, reference
@@ -0,0 +1,134 @@
+#!/usr/bin/env python3
+"""
+Model registry API script for web interface.
+
+Actions:
+    register: validate calibrated parameters and store them as the next
+              version of model_name, with data window, author and notes
+    list:     latest version of every model (optionally one model_type)
+    versions: all versions of one model_name
+    get:      one model by model_id, or by model_name and version (latest
+              when omitted), with its simulation inputs where defined
+
+Simulations reference a registered model by model_id (see
+model_store.resolve_model_inputs).
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics import simulation_inputs
+from model_store import METADATA_COLUMNS, register_model
+
+SUMMARY_COLUMNS = (
+    "model_id, model_name, model_type, version, data_source, data_start, data_end, "
+    "n_observations, frequency, author, calibrated_at"
+)
+
+
+def register(cur, params):
+    return register_model(
+        cur,
+        params['model_name'],
+        params['model_type'],
+        params['parameters'],
+        fit_statistics=params.get('fit_statistics'),
+        **{c: params.get(c) for c in METADATA_COLUMNS}
+    )
+
+
+def list_models(cur, params):
+    cur.execute(
+        f"SELECT DISTINCT ON (model_name) {SUMMARY_COLUMNS}, "
+        "COUNT(*) OVER (PARTITION BY model_name) AS n_versions "
+        "FROM model_registry WHERE (%s::text IS NULL OR model_type = %s::text) "
+        "ORDER BY model_name, version DESC",
+        (params.get('model_type'), params.get('model_type'))
+    )
+    return {'models': [dict(row) for row in cur.fetchall()]}
+
+
+def versions(cur, params):
+    cur.execute(
+        f"SELECT {SUMMARY_COLUMNS} FROM model_registry WHERE model_name = %s ORDER BY version DESC",
+        (params['model_name'],)
+    )
+    rows = [dict(row) for row in cur.fetchall()]
+    if not rows:
+        raise ValueError(f"No registered model named '{params['model_name']}'")
+    return {'model_name': params['model_name'], 'versions': rows}
+
+
+def get(cur, params):
+    if params.get('model_id') is not None:
+        cur.execute("SELECT * FROM model_registry WHERE model_id = %s", (params['model_id'],))
+    else:
+        cur.execute(
+            "SELECT * FROM model_registry WHERE model_name = %s AND (%s::int IS NULL OR version = %s::int) "
+            "ORDER BY version DESC LIMIT 1",
+            (params['model_name'], params.get('version'), params.get('version'))
+        )
+    row = cur.fetchone()
+    if row is None:
+        raise ValueError("Registered model not found")
+
+    model = dict(row)
+    model['simulation_inputs'] = None
+    if model['model_type'] != 'factor_loadings':
+        model['simulation_inputs'] = simulation_inputs(model['model_type'], model['parameters'], model['frequency'])
+    return model
+
+
+def _serialize(value):
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'register': register,
+    'list': list_models,
+    'versions': versions,
+    'get': get,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'list')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Model registry error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -1,6 +1,13 @@
 #!/usr/bin/env python3
 """
 Monte Carlo API script for web interface.
+
+Every run stores its payoff statistics under a run_id. Passing
+warm_start_from=<run_id> adds n_paths new paths to that run instead of
+starting over, using a fresh seed so the new paths are independent.
+
+Passing model_id instead of sigma takes the volatility from a model in the
+registry (see model_store.resolve_model_inputs).
 """
 
 import sys
@@ -11,7 +18,124 @@ import time
 project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
 sys.path.insert(0, project_root)
 
-from pricing.monte_carlo import MonteCarloEngine
+from pricing.monte_carlo import MonteCarloEngine, RunningStatistics
+from result_cache import cached, load_run, save_run
+from model_store import resolve_model_inputs
+
+SEED = 42
+
+
+def run(params):
+    """Price the option (or extend a stored run) and report convergence."""
+    S = params['S']
+    K = params['K']
+    T = params['T']
+    r = params['r']
+    sigma = params['sigma']
+    option_type = params.get('option_type', 'call')
+    q = params.get('q', 0.0)
+    n_paths = params.get('n_paths', 100000)
+    variance_reduction = params.get('variance_reduction', 'antithetic')
+    precision = params.get('precision', 'float64')
+    warm_start_from = params.get('warm_start_from')
+
+    run_params = {
+        'S': S, 'K': K, 'T': T, 'r': r, 'sigma': sigma, 'option_type': option_type,
+        'q': q, 'variance_reduction': variance_reduction, 'precision': precision
+    }
+
+    # Continue a stored run, or start a new one
+    prior, prior_pairs, seed, history = None, None, SEED, []
+    if warm_start_from:
+        prior_run = load_run(warm_start_from)
+        changed = [k for k in run_params if prior_run['params'].get(k) != run_params[k]]
+        if changed:
+            raise ValueError(f"Warm start parameters differ from run {warm_start_from}: {', '.join(changed)}")
+        prior = RunningStatistics.from_dict(prior_run['moments'])
+        if prior_run.get('pair_moments'):
+            prior_pairs = RunningStatistics.from_dict(prior_run['pair_moments'])
+        seed = prior_run['next_seed']
+        history = prior_run['history']
+
+    # Create Monte Carlo engine
+    mc = MonteCarloEngine(
+        n_paths=n_paths,
+        n_steps=252,
+        variance_reduction=variance_reduction,
+        seed=seed,
+        precision=precision
+    )
+
+    # Price the option and measure time
+    start = time.perf_counter()
+    priced = mc.price_with_statistics(
+        S0=S, K=K, T=T, r=r, sigma=sigma,
+        option_type=option_type, q=q, prior=prior, prior_pairs=prior_pairs
+    )
+    elapsed = (time.perf_counter() - start) * 1000
+
+    history = history + [{
+        'n_paths': priced['n_paths'],
+        'price': float(priced['price']),
+        # None under Sobol sampling (no error estimate from one sequence)
+        'std_error': priced['std_error'],
+        'time_ms': float(elapsed)
+    }]
+    run_id = save_run({
+        'params': run_params,
+        'moments': priced['state'],
+        'pair_moments': priced.get('pair_state'),
+        'next_seed': seed + 1,
+        'history': history,
+        'parent': warm_start_from
+    })
+
+    result = {
+        'price': float(priced['price']),
+        'time_ms': float(elapsed),
+        'precision': precision,
+        'run_id': run_id,
+        'n_paths_total': priced['n_paths'],
+        'statistics': {k: priced[k] for k in ('std_error', 'std', 'skewness', 'kurtosis', 'jarque_bera')}
+    }
+
+    # A warm start reports how the estimate tightened across increments
+    # rather than re-running the convergence analysis from scratch
+    if warm_start_from:
+        result['warm_start_from'] = warm_start_from
+        result['convergence'] = history
+        return result
+
+    # Also compute convergence analysis with different path counts
+    convergence = []
+    path_counts = [10_000, 50_000, 100_000]
+    if n_paths > 100_000:
+        path_counts.append(n_paths)
+
+    for n in path_counts:
+        if n <= n_paths:
+            mc_conv = MonteCarloEngine(
+                n_paths=n,
+                n_steps=252,
+                variance_reduction=variance_reduction,
+                seed=SEED,
+                precision=precision
+            )
+            start_conv = time.perf_counter()
+            price_conv = mc_conv.price_european_option(
+                S0=S, K=K, T=T, r=r, sigma=sigma,
+                option_type=option_type, q=q
+            )
+            elapsed_conv = (time.perf_counter() - start_conv) * 1000
+
+            convergence.append({
+                'n_paths': n,
+                'price': float(price_conv),
+                'time_ms': float(elapsed_conv)
+            })
+
+    result['convergence'] = convergence
+    return result
 
 
 def main():
@@ -22,64 +146,15 @@ def main():
     try:
         params = json.loads(sys.argv[1])
 
-        S = params['S']
-        K = params['K']
-        T = params['T']
-        r = params['r']
-        sigma = params['sigma']
-        option_type = params.get('option_type', 'call')
-        q = params.get('q', 0.0)
-        n_paths = params.get('n_paths', 100000)
-        variance_reduction = params.get('variance_reduction', 'antithetic')
-
-        # Create Monte Carlo engine
-        mc = MonteCarloEngine(
-            n_paths=n_paths,
-            n_steps=252,
-            variance_reduction=variance_reduction,
-            seed=42
-        )
-
-        # Price the option and measure time
-        start = time.perf_counter()
-        price = mc.price_european_option(
-            S0=S, K=K, T=T, r=r, sigma=sigma,
-            option_type=option_type, q=q
-        )
-        elapsed = (time.perf_counter() - start) * 1000
-
-        # Also compute convergence analysis with different path counts
-        convergence = []
-        path_counts = [10_000, 50_000, 100_000]
-        if n_paths > 100_000:
-            path_counts.append(n_paths)
-
-        for n in path_counts:
-            if n <= n_paths:
-                mc_conv = MonteCarloEngine(
-                    n_paths=n,
-                    n_steps=252,
-                    variance_reduction=variance_reduction,
-                    seed=42
-                )
-                start_conv = time.perf_counter()
-                price_conv = mc_conv.price_european_option(
-                    S0=S, K=K, T=T, r=r, sigma=sigma,
-                    option_type=option_type, q=q
-                )
-                elapsed_conv = (time.perf_counter() - start_conv) * 1000
-
-                convergence.append({
-                    'n_paths': n,
-                    'price': float(price_conv),
-                    'time_ms': float(elapsed_conv)
-                })
-
-        result = {
-            'price': float(price),
-            'time_ms': float(elapsed),
-            'convergence': convergence
-        }
+        # Registered models are immutable, so the resolved sigma is safe to cache under model_id
+        model = None
+        if params.get('model_id') is not None:
+            inputs, model = resolve_model_inputs(params['model_id'])
+            params['sigma'] = inputs['sigma']
+
+        result = cached('monte_carlo', params, lambda: run(params))
+        if model is not None:
+            result['model'] = model
 
         print(json.dumps(result))
 
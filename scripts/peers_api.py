@@ -0,0 +1,132 @@
+#!/usr/bin/env python3
+"""
+Peer universe benchmarking API script for web interface.
+
+Actions:
+    upload: store quartile breakpoints in peer_benchmarks
+    rank:   rank active funds (optionally of a portfolio group's subtree,
+            group_id, and/or a portfolio, portfolio_id) against each
+            source's latest breakpoints, or one source's when source is given
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor, execute_values
+from analytics import rank_funds
+from analytics.peers import METRICS
+from portfolio_groups import group_filter
+
+BREAKPOINT_COLUMNS = [
+    'source', 'vintage', 'strategy', 'metric', 'upper_quartile', 'median',
+    'lower_quartile', 'fund_count', 'as_of_date'
+]
+
+
+def upload(cur, params):
+    rows = []
+    for i, bp in enumerate(params['breakpoints']):
+        if bp.get('metric') not in METRICS:
+            raise ValueError(f"Breakpoint {i}: metric must be one of {', '.join(METRICS)}")
+        quartiles = [bp.get(k) for k in ('lower_quartile', 'median', 'upper_quartile')]
+        if not all(isinstance(q, (int, float)) for q in quartiles):
+            raise ValueError(f"Breakpoint {i}: lower_quartile, median and upper_quartile must be numbers")
+        if not quartiles[0] <= quartiles[1] <= quartiles[2]:
+            raise ValueError(f"Breakpoint {i}: requires lower_quartile <= median <= upper_quartile")
+        row = dict(bp, source=params['source'], as_of_date=params['as_of_date'])
+        row.setdefault('strategy', 'All')
+        row.setdefault('fund_count', None)
+        rows.append(tuple(row[c] for c in BREAKPOINT_COLUMNS))
+
+    execute_values(
+        cur,
+        f"INSERT INTO peer_benchmarks ({', '.join(BREAKPOINT_COLUMNS)}) VALUES %s "
+        "ON CONFLICT (source, vintage, strategy, metric, as_of_date) DO UPDATE SET "
+        "upper_quartile = EXCLUDED.upper_quartile, median = EXCLUDED.median, "
+        "lower_quartile = EXCLUDED.lower_quartile, fund_count = EXCLUDED.fund_count",
+        rows
+    )
+    return {'source': params['source'], 'as_of_date': params['as_of_date'], 'uploaded': len(rows)}
+
+
+def rank(cur, params):
+    # Sources are ranked separately; mixing their breakpoints would compare
+    # one fund against several universes at once
+    source = params.get('source')
+    cur.execute(
+        "SELECT DISTINCT ON (source, vintage, strategy, metric) vintage, strategy, metric, "
+        "upper_quartile, median, lower_quartile, source, as_of_date "
+        "FROM peer_benchmarks WHERE (%s IS NULL OR source = %s) "
+        "ORDER BY source, vintage, strategy, metric, as_of_date DESC",
+        (source, source)
+    )
+    by_source = {}
+    for row in cur.fetchall():
+        by_source.setdefault(row['source'], []).append(dict(row))
+
+    condition, args = group_filter(cur, params.get('group_id'), portfolio_id=params.get('portfolio_id'))
+    cur.execute(
+        "SELECT p.fund_id, p.fund_name, p.vintage, m.primary_strategy AS strategy, p.irr, p.tvpi "
+        "FROM portfolio_data p LEFT JOIN managers m ON m.manager_id = p.manager_id "
+        f"WHERE p.status = 'Active' AND {condition} ORDER BY p.fund_id",
+        args
+    )
+    funds = [dict(row) for row in cur.fetchall()]
+
+    sources = []
+    for name, breakpoints in sorted(by_source.items()):
+        result = rank_funds(funds, breakpoints)
+        result['source'] = name
+        result['as_of_date'] = max(bp['as_of_date'] for bp in breakpoints)
+        sources.append(result)
+
+    return {'group_id': params.get('group_id'), 'portfolio_id': params.get('portfolio_id'), 'sources': sources}
+
+
+def _serialize(value):
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'rank')
+        if action not in ('upload', 'rank'):
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = upload(cur, params) if action == 'upload' else rank(cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Peer benchmarking error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
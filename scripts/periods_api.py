@@ -0,0 +1,259 @@
+#!/usr/bin/env python3
+"""
+Reporting period close API script for web interface.
+
+Actions:
+    periods:     closed periods with their reported NAV and adjustment counts
+    close:       close a quarter (period_end) after the latest closed one,
+                 recording every fund's current NAV as its reported NAV
+    adjust:      change a locked cash flow (insert, update or delete) or a
+                 reported NAV (update), with a reason and author
+    adjustments: the audit trail (optionally of one period or fund)
+
+Once a quarter is closed, triggers in schema.sql reject any write to cash
+flows dated on or before the latest closed period end, and to reported NAVs.
+adjust is the only way through: it enables the write for its own transaction
+(helios.closed_period_write) and records old and new values in
+period_adjustments under the closed period the change falls in.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+
+CASH_FLOW_FIELDS = ('fund_id', 'flow_date', 'flow_type', 'amount', 'description')
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def allow_closed_period_write(cur):
+    """Lift the closed period lock until this transaction ends."""
+    cur.execute("SELECT set_config('helios.closed_period_write', 'on', true)")
+
+
+def closed_through(cur):
+    cur.execute("SELECT MAX(period_end) AS period_end FROM reporting_periods")
+    return cur.fetchone()['period_end']
+
+
+def period_of(cur, when):
+    """The closed period a date falls in, or None when it is after the latest close."""
+    cur.execute("SELECT MIN(period_end) AS period_end FROM reporting_periods WHERE period_end >= %s", (when,))
+    return cur.fetchone()['period_end']
+
+
+def list_periods(cur, params):
+    periods = fetch_rows(
+        cur,
+        "SELECT r.period_end, r.closed_by, r.closed_at, "
+        "(SELECT COUNT(*) FROM period_navs n WHERE n.period_end = r.period_end) AS n_navs, "
+        "(SELECT COUNT(*) FROM period_adjustments a WHERE a.period_end = r.period_end) AS n_adjustments "
+        "FROM reporting_periods r ORDER BY r.period_end DESC"
+    )
+    return {'closed_through': closed_through(cur), 'periods': periods}
+
+
+def close_period(cur, params):
+    period_end = date.fromisoformat(params['period_end'])
+    if period_end >= date.today():
+        raise ValueError("Only a quarter that has ended can be closed")
+    latest = closed_through(cur)
+    if latest is not None and period_end <= latest:
+        raise ValueError(f"Periods through {latest.isoformat()} are already closed")
+
+    allow_closed_period_write(cur)
+    cur.execute(
+        "INSERT INTO reporting_periods (period_end, closed_by) VALUES (%s, %s) RETURNING *",
+        (period_end, params['closed_by'])
+    )
+    period = dict(cur.fetchone())
+    cur.execute(
+        "INSERT INTO period_navs (fund_id, period_end, nav) SELECT fund_id, %s, current_nav FROM portfolio_data",
+        (period_end,)
+    )
+    period['n_navs'] = cur.rowcount
+    period['cash_flows'] = fetch_rows(
+        cur,
+        "SELECT flow_type, COUNT(*) AS n, SUM(amount) AS total FROM cash_flows "
+        "WHERE flow_date > COALESCE(%s, '-infinity'::date) AND flow_date <= %s GROUP BY flow_type ORDER BY flow_type",
+        (latest, period_end)
+    )
+    return period
+
+
+def adjust_cash_flow(cur, params):
+    operation = params['operation']
+    old = None
+    if operation in ('update', 'delete'):
+        cur.execute(
+            f"SELECT cash_flow_id, {', '.join(CASH_FLOW_FIELDS)} FROM cash_flows WHERE cash_flow_id = %s FOR UPDATE",
+            (params['cash_flow_id'],)
+        )
+        row = cur.fetchone()
+        if row is None:
+            raise ValueError(f"No cash flow with id {params['cash_flow_id']}")
+        old = dict(row)
+
+    changes = params.get('values') or {}
+    unknown = sorted(set(changes) - set(CASH_FLOW_FIELDS))
+    if unknown:
+        raise ValueError(f"Unknown cash flow fields: {', '.join(unknown)}")
+    if operation == 'insert':
+        missing = [k for k in ('fund_id', 'flow_date', 'flow_type', 'amount') if changes.get(k) is None]
+        if missing:
+            raise ValueError(f"A new cash flow needs {', '.join(missing)}")
+        new = {k: changes.get(k) for k in CASH_FLOW_FIELDS}
+    elif operation == 'update':
+        if not changes:
+            raise ValueError("Nothing to update")
+        new = {**old, **changes}
+    else:
+        new = None
+
+    # The adjustment belongs to the earliest closed period it touches
+    dates = [date.fromisoformat(str(row['flow_date'])) for row in (old, new) if row is not None]
+    period_end = period_of(cur, min(dates))
+    if period_end is None:
+        raise ValueError("The cash flow is not in a closed period; change it directly")
+
+    allow_closed_period_write(cur)
+    if operation == 'insert':
+        cur.execute(
+            f"INSERT INTO cash_flows ({', '.join(CASH_FLOW_FIELDS)}) VALUES (%s, %s, %s, %s, %s) RETURNING cash_flow_id",
+            [new[k] for k in CASH_FLOW_FIELDS]
+        )
+        cash_flow_id = cur.fetchone()['cash_flow_id']
+    elif operation == 'update':
+        cur.execute(
+            f"UPDATE cash_flows SET {', '.join(f'{k} = %s' for k in changes)} WHERE cash_flow_id = %s",
+            list(changes.values()) + [old['cash_flow_id']]
+        )
+        cash_flow_id = old['cash_flow_id']
+    else:
+        cur.execute("DELETE FROM cash_flows WHERE cash_flow_id = %s", (old['cash_flow_id'],))
+        cash_flow_id = old['cash_flow_id']
+
+    fund_id = (new or old)['fund_id']
+    return period_end, fund_id, cash_flow_id, old, new
+
+
+def adjust_nav(cur, params):
+    if params['operation'] != 'update':
+        raise ValueError("Reported NAVs can only be updated")
+    period_end = date.fromisoformat(params['period_end'])
+    cur.execute(
+        "SELECT fund_id, period_end, nav FROM period_navs WHERE fund_id = %s AND period_end = %s FOR UPDATE",
+        (params['fund_id'], period_end)
+    )
+    row = cur.fetchone()
+    if row is None:
+        raise ValueError(f"No reported NAV for fund {params['fund_id']} at {period_end.isoformat()}")
+    old = dict(row)
+    new = {**old, 'nav': params['values']['nav']}
+
+    allow_closed_period_write(cur)
+    cur.execute(
+        "UPDATE period_navs SET nav = %s WHERE fund_id = %s AND period_end = %s",
+        (new['nav'], params['fund_id'], period_end)
+    )
+    return period_end, params['fund_id'], None, old, new
+
+
+def adjust(cur, params):
+    if not params.get('reason') or not params.get('adjusted_by'):
+        raise ValueError("An adjustment needs a reason and adjusted_by")
+    if params.get('operation') not in ('insert', 'update', 'delete'):
+        raise ValueError("operation must be insert, update or delete")
+
+    target = params.get('target')
+    if target == 'cash_flow':
+        period_end, fund_id, cash_flow_id, old, new = adjust_cash_flow(cur, params)
+    elif target == 'nav':
+        period_end, fund_id, cash_flow_id, old, new = adjust_nav(cur, params)
+    else:
+        raise ValueError("target must be cash_flow or nav")
+
+    cur.execute(
+        "INSERT INTO period_adjustments (period_end, target, operation, fund_id, cash_flow_id, old_values, "
+        "new_values, reason, adjusted_by) VALUES (%s, %s, %s, %s, %s, %s::jsonb, %s::jsonb, %s, %s) RETURNING *",
+        (
+            period_end, target, params['operation'], fund_id, cash_flow_id,
+            json.dumps(old, default=_serialize) if old is not None else None,
+            json.dumps(new, default=_serialize) if new is not None else None,
+            params['reason'], params['adjusted_by']
+        )
+    )
+    return dict(cur.fetchone())
+
+
+def list_adjustments(cur, params):
+    adjustments = fetch_rows(
+        cur,
+        "SELECT * FROM period_adjustments "
+        "WHERE (%s::date IS NULL OR period_end = %s::date) AND (%s::int IS NULL OR fund_id = %s::int) "
+        "ORDER BY adjusted_at DESC, adjustment_id DESC",
+        (params.get('period_end'), params.get('period_end'), params.get('fund_id'), params.get('fund_id'))
+    )
+    return {'adjustments': adjustments, 'n_adjustments': len(adjustments)}
+
+
+def _serialize(value):
+    # NUMERIC columns arrive as Decimal
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'periods': list_periods,
+    'close': close_period,
+    'adjust': adjust,
+    'adjustments': list_adjustments,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'periods')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Reporting period error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
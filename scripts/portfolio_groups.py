@@ -0,0 +1,50 @@
+"""
+Portfolio group and portfolio filters shared by the API scripts.
+
+Groups form a hierarchy in portfolio_groups, and each fund is assigned to at
+most one node (portfolio_group_funds), through scripts/groups_api.py.
+Analytics that read funds from the database accept group_id and then cover
+the funds assigned to that node or any node beneath it.
+
+Portfolios (portfolios, managed through scripts/portfolios_api.py) are
+separate mandates whose funds may overlap. The same analytics accept
+portfolio_id, alone or together with group_id, to cover one portfolio's funds.
+"""
+
+from typing import List, Optional, Tuple
+
+SUBTREE_QUERY = (
+    "WITH RECURSIVE subtree AS ("
+    "SELECT group_id FROM portfolio_groups WHERE group_id = %s "
+    "UNION ALL SELECT g.group_id FROM portfolio_groups g JOIN subtree s ON g.parent_id = s.group_id"
+    ") SELECT group_id FROM subtree"
+)
+
+
+def group_filter(
+    cur,
+    group_id: Optional[int],
+    fund_id_column: str = 'p.fund_id',
+    portfolio_id: Optional[int] = None
+) -> Tuple[str, List[int]]:
+    """SQL condition on a fund id column (trusted) for a group's subtree and/or a portfolio, and its arguments."""
+    clauses, args = [], []
+
+    if group_id is not None:
+        cur.execute("SELECT 1 FROM portfolio_groups WHERE group_id = %s", (group_id,))
+        if cur.fetchone() is None:
+            raise ValueError(f"No portfolio group with id {group_id}")
+        clauses.append(
+            f"{fund_id_column} IN (SELECT gf.fund_id FROM portfolio_group_funds gf "
+            f"WHERE gf.group_id IN ({SUBTREE_QUERY}))"
+        )
+        args.append(group_id)
+
+    if portfolio_id is not None:
+        cur.execute("SELECT 1 FROM portfolios WHERE portfolio_id = %s", (portfolio_id,))
+        if cur.fetchone() is None:
+            raise ValueError(f"No portfolio with id {portfolio_id}")
+        clauses.append(f"{fund_id_column} IN (SELECT pf.fund_id FROM portfolio_funds pf WHERE pf.portfolio_id = %s)")
+        args.append(portfolio_id)
+
+    return ' AND '.join(clauses) or 'TRUE', args
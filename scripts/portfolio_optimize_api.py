@@ -11,6 +11,8 @@ project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
 sys.path.insert(0, project_root)
 
 from optimization import MarkowitzOptimizer, RiskParityOptimizer, CVaROptimizer, generate_sample_returns
+from rates_store import resolve_risk_free_rate
+from assumptions_store import resolve_assumption_set
 import numpy as np
 
 
@@ -23,19 +25,35 @@ def main():
         params = json.loads(sys.argv[1])
 
         n_assets = params.get('n_assets', 10)
-        risk_free_rate = params.get('risk_free_rate', 0.02)
+        risk_free_rate, _ = resolve_risk_free_rate(params)
         method = params.get('method', 'all')
 
-        # Generate sample returns (in production, would load real data)
-        # Using seed for consistency in demos
-        returns = generate_sample_returns(n_assets=n_assets, n_periods=252, seed=42)
+        # Optional liquidity constraints (applied to the Markowitz optimizer)
+        liquidity_keys = [
+            'illiquid_assets', 'max_illiquid_weight', 'locked_assets',
+            'current_weights', 'unfunded_commitments', 'min_call_coverage'
+        ]
+        liquidity = {k: params[k] for k in liquidity_keys if params.get(k) is not None}
+        if 'current_weights' in liquidity:
+            liquidity['current_weights'] = np.array(liquidity['current_weights'])
+
+        # Scenarios matching a stored assumption set, or sample returns
+        # (seeded for consistency in demos)
+        assumptions, assumption_set = resolve_assumption_set(params)
+        if assumptions is not None:
+            returns = assumptions.scenario_returns(n_periods=252, frequency=252, seed=42)
+        else:
+            returns = generate_sample_returns(n_assets=n_assets, n_periods=252, seed=42)
 
         results = {}
 
         if method == 'all' or method == 'markowitz':
             # Markowitz optimizer
             mv = MarkowitzOptimizer(returns, risk_free_rate=risk_free_rate)
-            max_sharpe = mv.max_sharpe_ratio()
+            if liquidity:
+                max_sharpe = mv.optimize_with_constraints(objective='max_sharpe', **liquidity)
+            else:
+                max_sharpe = mv.max_sharpe_ratio()
 
             results['markowitz'] = {
                 'method': 'markowitz',
@@ -77,6 +95,30 @@ def main():
                 'sharpe_ratio': float((cvar_result['return'] - risk_free_rate) / cvar_result['volatility']) if cvar_result['volatility'] > 0 else 0.0
             }
 
+        if method == 'resampled':
+            # Resampled frontier (not part of 'all' since it runs many optimizations)
+            mv = MarkowitzOptimizer(returns, risk_free_rate=risk_free_rate)
+            frontier = mv.resampled_frontier(
+                n_simulations=params.get('n_simulations', 50),
+                n_points=params.get('n_points', 20),
+                seed=42
+            )
+
+            results['resampled'] = {
+                'method': 'resampled',
+                'n_simulations': frontier['n_simulations'],
+                'weight_std': frontier['weight_std'].tolist()
+            }
+            for key in ('point_estimate', 'resampled'):
+                results['resampled'][key] = {
+                    name: values.tolist() for name, values in frontier[key].items()
+                }
+
+        if assumptions is not None:
+            for entry in results.values():
+                entry['assets'] = assumptions.assets
+                entry['assumption_set'] = assumption_set
+
         print(json.dumps(results))
 
     except Exception as e:
@@ -0,0 +1,186 @@
+#!/usr/bin/env python3
+"""
+Portfolio API script for web interface.
+
+Actions:
+    list:   every portfolio with its fund count, capital, NAV and
+            commitment-weighted IRR
+    get:    one portfolio and its funds
+    create: add a portfolio (name, optional mandate and description)
+    update: rename or re-describe a portfolio
+    delete: remove a portfolio (its funds stay, in any other portfolios)
+    add:    add funds to a portfolio (funds already in it are left as they are)
+    remove: take funds out of a portfolio
+
+A fund may belong to several portfolios, e.g. a co-investment held by both
+a pension and an endowment mandate. Analytics that read funds from the
+database accept portfolio_id (see portfolio_groups.group_filter).
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from portfolio_groups import group_filter
+
+SUMMARY_QUERY = (
+    "SELECT f.portfolio_id, f.portfolio_name, f.mandate, f.description, "
+    "COUNT(p.fund_id) AS num_funds, "
+    "COALESCE(SUM(p.committed_capital), 0) AS total_committed, "
+    "COALESCE(SUM(p.invested_capital), 0) AS total_invested, "
+    "COALESCE(SUM(p.current_nav), 0) AS total_nav, "
+    "SUM(p.irr * p.committed_capital) / NULLIF(SUM(p.committed_capital) FILTER (WHERE p.irr IS NOT NULL), 0) "
+    "AS commitment_weighted_irr "
+    "FROM portfolios f "
+    "LEFT JOIN portfolio_funds pf ON pf.portfolio_id = f.portfolio_id "
+    "LEFT JOIN portfolio_data p ON p.fund_id = pf.fund_id"
+)
+
+
+def fetch_rows(cur, query, args=None):
+    cur.execute(query, args)
+    return [dict(row) for row in cur.fetchall()]
+
+
+def check_funds(cur, fund_ids):
+    cur.execute("SELECT fund_id FROM portfolio_data WHERE fund_id = ANY(%s)", (fund_ids,))
+    missing = sorted(set(fund_ids) - {row['fund_id'] for row in cur.fetchall()})
+    if missing:
+        raise ValueError(f"No funds with ids {', '.join(map(str, missing))}")
+
+
+def list_portfolios(cur, params):
+    portfolios = fetch_rows(cur, f"{SUMMARY_QUERY} GROUP BY f.portfolio_id ORDER BY f.portfolio_name")
+    cur.execute("SELECT COUNT(*) AS n FROM portfolio_data WHERE fund_id NOT IN (SELECT fund_id FROM portfolio_funds)")
+    return {'portfolios': portfolios, 'n_unassigned_funds': cur.fetchone()['n']}
+
+
+def get_portfolio(cur, params):
+    condition, args = group_filter(cur, None, portfolio_id=params['portfolio_id'])
+    portfolio = fetch_rows(
+        cur, f"{SUMMARY_QUERY} WHERE f.portfolio_id = %s GROUP BY f.portfolio_id", (params['portfolio_id'],)
+    )[0]
+    portfolio['funds'] = fetch_rows(
+        cur,
+        "SELECT p.fund_id, p.fund_name, p.vintage, p.sector, p.committed_capital, p.current_nav, p.irr, p.tvpi, "
+        "p.status, (SELECT COUNT(*) FROM portfolio_funds o WHERE o.fund_id = p.fund_id) > 1 AS shared "
+        f"FROM portfolio_data p WHERE {condition} ORDER BY p.fund_name",
+        args
+    )
+    return portfolio
+
+
+def create_portfolio(cur, params):
+    cur.execute(
+        "INSERT INTO portfolios (portfolio_name, mandate, description) VALUES (%s, %s, %s) RETURNING *",
+        (params['portfolio_name'], params.get('mandate'), params.get('description'))
+    )
+    return dict(cur.fetchone())
+
+
+def update_portfolio(cur, params):
+    portfolio_id = params['portfolio_id']
+    group_filter(cur, None, portfolio_id=portfolio_id)
+
+    fields = [k for k in ('portfolio_name', 'mandate', 'description') if k in params]
+    if not fields:
+        raise ValueError("Nothing to update")
+    cur.execute(
+        f"UPDATE portfolios SET {', '.join(f'{k} = %s' for k in fields)} WHERE portfolio_id = %s RETURNING *",
+        [params[k] for k in fields] + [portfolio_id]
+    )
+    return dict(cur.fetchone())
+
+
+def delete_portfolio(cur, params):
+    group_filter(cur, None, portfolio_id=params['portfolio_id'])
+    cur.execute("DELETE FROM portfolio_funds WHERE portfolio_id = %s", (params['portfolio_id'],))
+    removed = cur.rowcount
+    cur.execute("DELETE FROM portfolios WHERE portfolio_id = %s", (params['portfolio_id'],))
+    return {'portfolio_id': params['portfolio_id'], 'deleted': True, 'removed_funds': removed}
+
+
+def add_funds(cur, params):
+    portfolio_id = params['portfolio_id']
+    group_filter(cur, None, portfolio_id=portfolio_id)
+    check_funds(cur, params['fund_ids'])
+
+    added = 0
+    for fund_id in params['fund_ids']:
+        cur.execute(
+            "INSERT INTO portfolio_funds (portfolio_id, fund_id) VALUES (%s, %s) ON CONFLICT DO NOTHING",
+            (portfolio_id, fund_id)
+        )
+        added += cur.rowcount
+    return {'portfolio_id': portfolio_id, 'fund_ids': params['fund_ids'], 'added': added}
+
+
+def remove_funds(cur, params):
+    portfolio_id = params['portfolio_id']
+    group_filter(cur, None, portfolio_id=portfolio_id)
+    cur.execute(
+        "DELETE FROM portfolio_funds WHERE portfolio_id = %s AND fund_id = ANY(%s)",
+        (portfolio_id, params['fund_ids'])
+    )
+    return {'portfolio_id': portfolio_id, 'fund_ids': params['fund_ids'], 'removed': cur.rowcount}
+
+
+def _serialize(value):
+    # NUMERIC columns arrive as Decimal
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'list': list_portfolios,
+    'get': get_portfolio,
+    'create': create_portfolio,
+    'update': update_portfolio,
+    'delete': delete_portfolio,
+    'add': add_funds,
+    'remove': remove_funds,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'list')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Portfolio error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
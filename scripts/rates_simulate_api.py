@@ -0,0 +1,110 @@
+#!/usr/bin/env python3
+"""
+Interest rate and inflation scenario API script for web interface.
+
+With 'scenarios' (each a name, a probability and overrides of rate,
+inflation, asset and correlation), every scenario is simulated and the
+probability-weighted mixture is returned alongside per-scenario results.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import numpy as np
+from pricing.rates import ShortRateParams, InflationParams, RateInflationSimulator
+
+
+def simulate(config, params, seed):
+    """Build a simulator from a configuration and simulate it."""
+    simulator = RateInflationSimulator(
+        ShortRateParams(**config.get('rate', {})),
+        InflationParams(**config.get('inflation', {})),
+        correlation=config.get('correlation'),
+        n_paths=params.get('n_paths', 10000),
+        n_steps=params.get('n_steps', 12),
+        seed=seed
+    )
+
+    asset = config.get('asset') or {}
+    return simulator.simulate(
+        T=params.get('T', 10.0),
+        asset_mu=asset.get('mu'),
+        asset_sigma=asset.get('sigma'),
+        initial_value=asset.get('initial_value', 1.0)
+    )
+
+
+def blend(params):
+    """Probability-weighted mixture over scenario configurations."""
+    seed = params.get('seed', 42)
+    scenarios = []
+    for i, scenario in enumerate(params['scenarios']):
+        # Scenario settings override the shared ones field by field
+        config = {}
+        for key in ('rate', 'inflation', 'asset', 'correlation'):
+            shared, override = params.get(key), scenario.get(key)
+            if isinstance(override, dict):
+                config[key] = {**(shared or {}), **override}
+            else:
+                config[key] = shared if override is None else override
+        scenarios.append({
+            'name': scenario['name'],
+            'probability': scenario['probability'],
+            'paths': simulate(config, params, None if seed is None else seed + i),
+        })
+
+    result = RateInflationSimulator.blended_outcomes(scenarios, queries=params.get('queries'))
+    if params.get('horizons'):
+        result['by_horizon'] = {
+            f'{h:g}': RateInflationSimulator.blended_outcomes(
+                scenarios, queries=params.get('queries'), horizon=h
+            )['blended']
+            for h in sorted(set(params['horizons']))
+        }
+    return result
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        if params.get('scenarios'):
+            print(json.dumps(blend(params)))
+            return
+
+        paths = simulate(params, params, params.get('seed', 42))
+
+        result = RateInflationSimulator.real_outcomes(paths, queries=params.get('queries'))
+        if params.get('horizons'):
+            result['by_horizon'] = RateInflationSimulator.horizon_outcomes(
+                paths, params['horizons'], queries=params.get('queries')
+            )
+
+        # Mean and 5/95% bands over time for charting
+        result['times'] = paths['times'].tolist()
+        result['fan'] = {
+            key: {
+                'mean': np.mean(paths[key], axis=0).tolist(),
+                'p5': np.percentile(paths[key], 5, axis=0).tolist(),
+                'p95': np.percentile(paths[key], 95, axis=0).tolist(),
+            }
+            for key in ('short_rate', 'inflation', 'real_value') if key in paths
+        }
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Rate simulation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
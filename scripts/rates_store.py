@@ -0,0 +1,98 @@
+"""
+Stored yield curve and CPI access shared by the API scripts.
+
+Curves are uploaded through scripts/yield_curve_api.py into the
+yield_curves table. Scripts that need a risk-free rate call
+resolve_risk_free_rate, which prefers an explicit request parameter, then
+the latest stored curve, then DEFAULT_RISK_FREE_RATE.
+
+CPI series are uploaded through scripts/cpi_api.py into cpi_data and
+loaded with load_cpi for inflation-adjusted results.
+"""
+
+import os
+from typing import Dict, Optional, Tuple
+
+import psycopg2
+from pricing.rates import YieldCurve
+from analytics import CPISeries
+
+DEFAULT_RISK_FREE_RATE = 0.02
+DEFAULT_CURVE = 'UST'
+DEFAULT_CPI_SERIES = 'CPI-U'
+
+
+def load_curve(cur, curve_name: str = DEFAULT_CURVE, as_of: Optional[str] = None) -> Optional[YieldCurve]:
+    """Latest curve on or before as_of (or the latest curve overall)."""
+    cur.execute(
+        "SELECT MAX(curve_date) FROM yield_curves "
+        "WHERE curve_name = %s AND (%s::date IS NULL OR curve_date <= %s::date)",
+        (curve_name, as_of, as_of)
+    )
+    curve_date = cur.fetchone()[0]
+    if curve_date is None:
+        return None
+
+    cur.execute(
+        "SELECT tenor_years, zero_rate FROM yield_curves "
+        "WHERE curve_name = %s AND curve_date = %s ORDER BY tenor_years",
+        (curve_name, curve_date)
+    )
+    points = cur.fetchall()
+    return YieldCurve(
+        [float(t) for t, _ in points],
+        [float(r) for _, r in points],
+        curve_date=curve_date,
+        name=curve_name
+    )
+
+
+def resolve_risk_free_rate(params: Dict, horizon: float = 1.0) -> Tuple[float, str]:
+    """
+    Risk-free rate for a request.
+
+    Returns:
+        (rate, source) where source is 'request', the curve name and date,
+        or 'default'
+    """
+    if params.get('risk_free_rate') is not None:
+        return float(params['risk_free_rate']), 'request'
+
+    database_url = os.environ.get('DATABASE_URL')
+    if database_url:
+        try:
+            conn = psycopg2.connect(database_url)
+            try:
+                with conn.cursor() as cur:
+                    curve = load_curve(cur, params.get('curve_name', DEFAULT_CURVE), params.get('as_of'))
+            finally:
+                conn.close()
+            if curve is not None:
+                return curve.risk_free_rate(horizon), f"{curve.name} {curve.curve_date.isoformat()}"
+        except psycopg2.Error:
+            pass
+
+    return DEFAULT_RISK_FREE_RATE, 'default'
+
+
+def load_cpi(series_name: Optional[str] = None) -> CPISeries:
+    """Full stored CPI series; raises if none is stored."""
+    series_name = series_name or DEFAULT_CPI_SERIES
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        raise ValueError("Inflation adjustment requires DATABASE_URL for the stored CPI series")
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn.cursor() as cur:
+            cur.execute(
+                "SELECT date, index_level FROM cpi_data WHERE series_name = %s ORDER BY date",
+                (series_name,)
+            )
+            rows = cur.fetchall()
+    finally:
+        conn.close()
+
+    if len(rows) < 2:
+        raise ValueError(f"No stored CPI series named '{series_name}'")
+    return CPISeries([d for d, _ in rows], [float(level) for _, level in rows], name=series_name)
@@ -0,0 +1,56 @@
+#!/usr/bin/env python3
+"""
+Rebalancing recommendation API script for web interface.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+from optimization import RebalanceRecommender
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        recommender = RebalanceRecommender(
+            holdings=params['holdings'],
+            target_weights=params['target_weights'],
+            cash=params.get('cash', 0.0),
+            lot_size=params.get('lot_size', 0.0),
+            min_trade=params.get('min_trade', 0.0),
+            max_turnover=params.get('max_turnover'),
+            illiquid=params.get('illiquid'),
+            asset_names=params.get('asset_names')
+        )
+        result = recommender.recommend()
+
+        output = {
+            'trades': result['trades'],
+            'turnover': result['turnover'],
+            'unconstrained_turnover': result['unconstrained_turnover'],
+            'current_weights': result['current_weights'].tolist(),
+            'post_trade_weights': result['post_trade_weights'].tolist(),
+            'target_weights': result['target_weights'].tolist(),
+            'tracking_error_before': result['tracking_error_before'],
+            'tracking_error_after': result['tracking_error_after'],
+            'residual_cash': result['residual_cash']
+        }
+
+        print(json.dumps(output))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Rebalance error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,209 @@
+#!/usr/bin/env python3
+"""
+Cash reconciliation API script for web interface.
+
+Actions:
+    reconcile: match bank items against recorded cash flows and store the
+               matched pairs and breaks
+    matches:   list stored matched pairs (optionally between two dates)
+    breaks:    list stored breaks by status
+    resolve:   close a break as Resolved or Ignored with a resolution note
+
+Re-running reconcile over a period replaces its open breaks and the stored
+matches of the items it sees; items already covered by a resolved or
+ignored break are left out of matching.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime, timedelta
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor, execute_values
+from analytics import reconcile_cash
+
+OUTFLOW_TYPES = ('Capital Call', 'Fee')
+BREAK_COLUMNS = [
+    'break_type', 'bank_transaction_id', 'cash_flow_id', 'break_date',
+    'bank_amount', 'record_amount', 'reference'
+]
+MATCH_COLUMNS = [
+    'bank_transaction_id', 'cash_flow_id', 'match_date', 'bank_amount',
+    'record_amount', 'score', 'reference_similarity'
+]
+
+
+def reconcile(cur, params):
+    window = params.get('date_window_days', 5)
+    bank_items = [dict(item, id=str(item['id'])) for item in params['bank_items']]
+    dates = [date.fromisoformat(item['date']) for item in bank_items]
+    start, end = min(dates) - timedelta(days=window), max(dates) + timedelta(days=window)
+
+    cur.execute(
+        "SELECT bank_transaction_id, cash_flow_id FROM reconciliation_breaks "
+        "WHERE status IN ('Resolved', 'Ignored')"
+    )
+    closed = cur.fetchall()
+    closed_bank = {row['bank_transaction_id'] for row in closed if row['bank_transaction_id']}
+    closed_records = {row['cash_flow_id'] for row in closed if row['cash_flow_id']}
+
+    cur.execute(
+        "SELECT c.cash_flow_id, c.flow_date, c.flow_type, c.amount, c.description, p.fund_name "
+        "FROM cash_flows c JOIN portfolio_data p ON p.fund_id = c.fund_id "
+        "WHERE c.flow_date BETWEEN %s AND %s ORDER BY c.flow_date",
+        (start, end)
+    )
+    recorded = [
+        {
+            'id': row['cash_flow_id'],
+            'date': row['flow_date'],
+            'amount': -float(row['amount']) if row['flow_type'] in OUTFLOW_TYPES else float(row['amount']),
+            'reference': f"{row['fund_name']} {row['flow_type']} {row['description'] or ''}",
+        }
+        for row in cur.fetchall() if row['cash_flow_id'] not in closed_records
+    ]
+
+    open_bank = [item for item in bank_items if item['id'] not in closed_bank]
+    result = reconcile_cash(
+        open_bank,
+        recorded,
+        amount_tolerance=params.get('amount_tolerance', 1.0),
+        date_window_days=window,
+        min_reference_similarity=params.get('min_reference_similarity', 0.6)
+    )
+
+    cur.execute(
+        "DELETE FROM reconciliation_matches WHERE bank_transaction_id = ANY(%s) OR cash_flow_id = ANY(%s)",
+        ([item['id'] for item in open_bank], [item['id'] for item in recorded])
+    )
+    if result['matches']:
+        bank_by_id = {item['id']: item for item in open_bank}
+        record_by_id = {item['id']: item for item in recorded}
+        rows = [
+            (
+                m['bank_id'], m['record_id'], bank_by_id[m['bank_id']]['date'],
+                float(bank_by_id[m['bank_id']]['amount']), record_by_id[m['record_id']]['amount'],
+                m['score'], m['reference_similarity']
+            )
+            for m in result['matches']
+        ]
+        execute_values(
+            cur,
+            f"INSERT INTO reconciliation_matches ({', '.join(MATCH_COLUMNS)}) VALUES %s RETURNING match_id",
+            rows
+        )
+        for m, row in zip(result['matches'], cur.fetchall()):
+            m['match_id'] = row['match_id']
+
+    cur.execute(
+        "DELETE FROM reconciliation_breaks WHERE status = 'Open' AND break_date BETWEEN %s AND %s",
+        (start, end)
+    )
+    if result['breaks']:
+        rows = [
+            (
+                br['type'], br.get('bank_id'), br.get('record_id'), br['date'],
+                br.get('bank_amount'), br.get('record_amount'), br.get('reference')
+            )
+            for br in result['breaks']
+        ]
+        execute_values(
+            cur,
+            f"INSERT INTO reconciliation_breaks ({', '.join(BREAK_COLUMNS)}) VALUES %s RETURNING break_id",
+            rows
+        )
+        for br, row in zip(result['breaks'], cur.fetchall()):
+            br['break_id'] = row['break_id']
+
+    result['period'] = {'start': start.isoformat(), 'end': end.isoformat()}
+    return result
+
+
+def list_matches(cur, params):
+    cur.execute(
+        "SELECT m.*, p.fund_name, c.flow_type FROM reconciliation_matches m "
+        "JOIN cash_flows c ON c.cash_flow_id = m.cash_flow_id "
+        "JOIN portfolio_data p ON p.fund_id = c.fund_id "
+        "WHERE (%s::date IS NULL OR m.match_date >= %s::date) AND (%s::date IS NULL OR m.match_date <= %s::date) "
+        "ORDER BY m.match_date, m.match_id",
+        (params.get('start_date'), params.get('start_date'), params.get('end_date'), params.get('end_date'))
+    )
+    matches = [dict(row) for row in cur.fetchall()]
+    return {
+        'start_date': params.get('start_date'),
+        'end_date': params.get('end_date'),
+        'matches': matches,
+        'n_matches': len(matches),
+    }
+
+
+def list_breaks(cur, params):
+    status = params.get('status', 'Open')
+    cur.execute(
+        "SELECT * FROM reconciliation_breaks WHERE status = %s ORDER BY break_date, break_id",
+        (status,)
+    )
+    breaks = [dict(row) for row in cur.fetchall()]
+    return {'status': status, 'breaks': breaks, 'n_breaks': len(breaks)}
+
+
+def resolve(cur, params):
+    cur.execute(
+        "UPDATE reconciliation_breaks SET status = %s, resolution = %s, resolved_by = %s, "
+        "resolved_at = CURRENT_TIMESTAMP WHERE break_id = %s AND status = 'Open' RETURNING *",
+        (params['status'], params['resolution'], params.get('resolved_by'), params['break_id'])
+    )
+    row = cur.fetchone()
+    if row is None:
+        raise ValueError(f"No open break with id {params['break_id']}")
+    return dict(row)
+
+
+def _serialize(value):
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {'reconcile': reconcile, 'matches': list_matches, 'breaks': list_breaks, 'resolve': resolve}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'breaks')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Cash reconciliation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,100 @@
+"""
+Simulation result cache shared by the API scripts.
+
+Results are stored as JSON files keyed by a SHA-256 hash of the canonical
+request (sorted keys, no whitespace), so identical requests with the same
+seed return the stored result instead of recomputing.
+
+Request parameters:
+    bypass_cache: recompute and overwrite the stored result
+    cache_ttl:    maximum age in seconds of a usable entry (default 1 day)
+
+The cache directory defaults to .cache/simulations under the project root
+and can be moved with HELIOS_CACHE_DIR.
+
+Run states (save_run/load_run) live in a runs/ subdirectory and do not
+expire, so a finished simulation can be extended later (warm start).
+"""
+
+import hashlib
+import json
+import os
+import time
+import uuid
+from typing import Callable, Dict
+
+DEFAULT_TTL = 24 * 60 * 60
+CONTROL_KEYS = ('bypass_cache', 'cache_ttl')
+
+_project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+CACHE_DIR = os.environ.get('HELIOS_CACHE_DIR', os.path.join(_project_root, '.cache', 'simulations'))
+
+
+def cache_key(namespace: str, params: Dict) -> str:
+    """Canonical hash of a request, ignoring cache control parameters."""
+    canonical = json.dumps(
+        {k: v for k, v in params.items() if k not in CONTROL_KEYS},
+        sort_keys=True,
+        separators=(',', ':')
+    )
+    return hashlib.sha256(f"{namespace}:{canonical}".encode()).hexdigest()
+
+
+def cached(namespace: str, params: Dict, compute: Callable[[], Dict]) -> Dict:
+    """
+    Return the stored result for params, computing and storing it on a miss.
+
+    The result gains a 'cache' entry with the key, whether it was a hit and
+    when it was computed.
+    """
+    key = cache_key(namespace, params)
+    path = os.path.join(CACHE_DIR, f"{key}.json")
+    ttl = params.get('cache_ttl', DEFAULT_TTL)
+
+    if not params.get('bypass_cache') and os.path.exists(path):
+        age = time.time() - os.path.getmtime(path)
+        if age <= ttl:
+            try:
+                with open(path) as f:
+                    result = json.load(f)
+                result['cache'] = dict(result.get('cache', {}), hit=True, age_seconds=age)
+                return result
+            except (OSError, ValueError):
+                pass  # Unreadable entry: recompute and overwrite
+
+    result = compute()
+    result['cache'] = {'key': key, 'hit': False, 'computed_at': time.time()}
+
+    try:
+        _write_json(path, result)
+    except OSError:
+        pass  # Caching is best effort
+
+    return result
+
+
+def _write_json(path: str, data: Dict):
+    """Write JSON atomically so concurrent readers never see partial files."""
+    os.makedirs(os.path.dirname(path), exist_ok=True)
+    tmp_path = f"{path}.{os.getpid()}.tmp"
+    with open(tmp_path, 'w') as f:
+        json.dump(data, f)
+    os.replace(tmp_path, path)
+
+
+def save_run(state: Dict) -> str:
+    """Store a run's resumable state and return its new run ID."""
+    run_id = uuid.uuid4().hex
+    _write_json(os.path.join(CACHE_DIR, 'runs', f"{run_id}.json"), state)
+    return run_id
+
+
+def load_run(run_id: str) -> Dict:
+    """Load a stored run state, raising ValueError for unknown IDs."""
+    if not run_id.isalnum():
+        raise ValueError(f"Invalid run ID: {run_id}")
+    path = os.path.join(CACHE_DIR, 'runs', f"{run_id}.json")
+    if not os.path.exists(path):
+        raise ValueError(f"Unknown run: {run_id}")
+    with open(path) as f:
+        return json.load(f)
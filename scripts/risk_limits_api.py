@@ -0,0 +1,249 @@
+#!/usr/bin/env python3
+"""
+Risk limit monitoring API script for web interface.
+
+Actions:
+    set_limits:  create or update limits by metric (active=false disables one)
+    limits:      list configured limits
+    evaluate:    compute portfolio risk from active funds, check the active
+                 limits and record warnings and breaches
+    breaches:    list recorded breaches (open, acknowledged, resolved or all)
+    acknowledge: acknowledge an open breach with a note
+
+evaluate is run by seed_demo.py after each data load (trigger 'data_update');
+for a scheduled check run it from cron with trigger 'scheduled'. A limit
+that stays at the same severity keeps one unresolved row (last_seen_at and
+observed_value are refreshed), whether or not it has been acknowledged.
+When the severity changes, the new row supersedes the old one (warning to
+breach, or back); when the limit is ok again or disabled, its rows are
+resolved. Limits whose metric is unavailable are left as they are.
+
+With a group_id or portfolio_id, evaluate checks the limits against the
+funds of that portfolio group's subtree or that portfolio only and records
+nothing, since the breach history tracks all funds.
+"""
+
+import sys
+import json
+import os
+from datetime import date, datetime
+from decimal import Decimal
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from analytics.limits import LIMIT_METRICS, evaluate_limits, portfolio_risk_snapshot
+from portfolio_groups import group_filter
+
+TRIGGERS = ('data_update', 'scheduled', 'manual')
+BREACH_FILTERS = {
+    'open': 'WHERE b.resolved_at IS NULL AND b.acknowledged_at IS NULL',
+    'acknowledged': 'WHERE b.acknowledged_at IS NOT NULL',
+    'resolved': 'WHERE b.resolved_at IS NOT NULL',
+    'all': '',
+}
+
+
+def set_limits(cur, params):
+    stored = []
+    for limit in params['limits']:
+        if limit['metric'] not in LIMIT_METRICS:
+            raise ValueError(f"Unknown limit metric: {limit['metric']}")
+        cur.execute(
+            "INSERT INTO risk_limits (metric, limit_value, warning_value, active) VALUES (%s, %s, %s, %s) "
+            "ON CONFLICT (metric) DO UPDATE SET limit_value = EXCLUDED.limit_value, "
+            "warning_value = EXCLUDED.warning_value, active = EXCLUDED.active RETURNING *",
+            (limit['metric'], limit['limit_value'], limit.get('warning_value'), limit.get('active', True))
+        )
+        stored.append(dict(cur.fetchone()))
+    return {'limits': stored, 'stored': len(stored)}
+
+
+def list_limits(cur, params):
+    cur.execute("SELECT * FROM risk_limits ORDER BY metric")
+    return {'limits': [dict(row) for row in cur.fetchall()]}
+
+
+def evaluate(cur, params):
+    trigger = params.get('trigger', 'manual')
+    if trigger not in TRIGGERS:
+        raise ValueError(f"Unknown trigger: {trigger}")
+
+    cur.execute(
+        "SELECT risk_limit_id, metric, limit_value, warning_value FROM risk_limits WHERE active ORDER BY metric"
+    )
+    limits = [
+        {
+            'risk_limit_id': row['risk_limit_id'],
+            'metric': row['metric'],
+            'limit_value': float(row['limit_value']),
+            'warning_value': float(row['warning_value']) if row['warning_value'] is not None else None,
+        }
+        for row in cur.fetchall()
+    ]
+
+    group_id = params.get('group_id')
+    portfolio_id = params.get('portfolio_id')
+    condition, args = group_filter(cur, group_id, portfolio_id=portfolio_id)
+    cur.execute(
+        "SELECT p.fund_name, p.current_nav, p.sector, p.volatility FROM portfolio_data p "
+        f"WHERE p.status = 'Active' AND {condition}",
+        args
+    )
+    funds = [
+        {
+            'name': row['fund_name'],
+            'nav': float(row['current_nav']) if row['current_nav'] is not None else None,
+            'sector': row['sector'],
+            'volatility': float(row['volatility']) if row['volatility'] is not None else None,
+        }
+        for row in cur.fetchall()
+    ]
+
+    snapshot = portfolio_risk_snapshot(
+        funds,
+        correlation=params.get('correlation', 0.5),
+        confidence_level=params.get('confidence_level', 0.95),
+        horizon_years=params.get('horizon_years', 1.0)
+    )
+    result = evaluate_limits(snapshot['metrics'], limits)
+    result['trigger'] = trigger
+    result['snapshot'] = snapshot
+    result['group_id'] = group_id
+    result['portfolio_id'] = portfolio_id
+    result['recorded'] = group_id is None and portfolio_id is None
+    if not result['recorded']:
+        return result
+
+    new_breaches = 0
+    resolved = 0
+    for evaluation in result['limits']:
+        if evaluation['status'] == 'ok':
+            cur.execute(
+                "UPDATE risk_limit_breaches SET resolved_at = CURRENT_TIMESTAMP "
+                "WHERE risk_limit_id = %s AND resolved_at IS NULL RETURNING breach_id",
+                (evaluation['risk_limit_id'],)
+            )
+            resolved += len(cur.fetchall())
+            continue
+        if evaluation['status'] not in ('warning', 'breach'):
+            continue
+        severity = evaluation['status']
+        threshold = evaluation['limit_value'] if severity == 'breach' else evaluation['warning_value']
+
+        cur.execute(
+            "UPDATE risk_limit_breaches SET observed_value = %s, last_seen_at = CURRENT_TIMESTAMP "
+            "WHERE risk_limit_id = %s AND severity = %s AND resolved_at IS NULL RETURNING breach_id",
+            (evaluation['value'], evaluation['risk_limit_id'], severity)
+        )
+        row = cur.fetchone()
+        evaluation['new_breach'] = row is None
+        if row is None:
+            cur.execute(
+                "INSERT INTO risk_limit_breaches (risk_limit_id, metric, severity, observed_value, "
+                "threshold_value, evaluation_trigger) VALUES (%s, %s, %s, %s, %s, %s) RETURNING breach_id",
+                (evaluation['risk_limit_id'], evaluation['metric'], severity,
+                 evaluation['value'], threshold, trigger)
+            )
+            row = cur.fetchone()
+            new_breaches += 1
+        evaluation['breach_id'] = row['breach_id']
+
+        # An escalation (or step back) supersedes the row at the other severity
+        cur.execute(
+            "UPDATE risk_limit_breaches SET resolved_at = CURRENT_TIMESTAMP, superseded_by = %s "
+            "WHERE risk_limit_id = %s AND severity <> %s AND resolved_at IS NULL RETURNING breach_id",
+            (row['breach_id'], evaluation['risk_limit_id'], severity)
+        )
+        evaluation['superseded'] = [r['breach_id'] for r in cur.fetchall()]
+        resolved += len(evaluation['superseded'])
+
+    # Rows of limits that were disabled since they were recorded
+    cur.execute(
+        "UPDATE risk_limit_breaches SET resolved_at = CURRENT_TIMESTAMP "
+        "WHERE resolved_at IS NULL AND risk_limit_id <> ALL(%s::int[]) RETURNING breach_id",
+        ([limit['risk_limit_id'] for limit in limits],)
+    )
+    resolved += len(cur.fetchall())
+
+    result['n_new_breaches'] = new_breaches
+    result['n_resolved'] = resolved
+    return result
+
+
+def list_breaches(cur, params):
+    status = params.get('status', 'open')
+    if status not in BREACH_FILTERS:
+        raise ValueError(f"Unknown breach status: {status}")
+    cur.execute(
+        f"SELECT b.* FROM risk_limit_breaches b {BREACH_FILTERS[status]} "
+        "ORDER BY b.detected_at DESC, b.breach_id DESC LIMIT %s",
+        (params.get('limit', 100),)
+    )
+    breaches = [dict(row) for row in cur.fetchall()]
+    return {'status': status, 'breaches': breaches, 'n_breaches': len(breaches)}
+
+
+def acknowledge(cur, params):
+    cur.execute(
+        "UPDATE risk_limit_breaches SET acknowledged_by = %s, acknowledgment_note = %s, "
+        "acknowledged_at = CURRENT_TIMESTAMP WHERE breach_id = %s AND acknowledged_at IS NULL RETURNING *",
+        (params['acknowledged_by'], params.get('note'), params['breach_id'])
+    )
+    row = cur.fetchone()
+    if row is None:
+        raise ValueError(f"No open breach with id {params['breach_id']}")
+    return dict(row)
+
+
+def _serialize(value):
+    if isinstance(value, Decimal):
+        return float(value)
+    if isinstance(value, (date, datetime)):
+        return value.isoformat()
+    raise TypeError(f"Cannot serialize {type(value).__name__}")
+
+
+ACTIONS = {
+    'set_limits': set_limits,
+    'limits': list_limits,
+    'evaluate': evaluate,
+    'breaches': list_breaches,
+    'acknowledge': acknowledge,
+}
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'breaches')
+        if action not in ACTIONS:
+            raise ValueError(f"Unknown action: {action}")
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    result = ACTIONS[action](cur, params)
+        finally:
+            conn.close()
+
+        print(json.dumps(result, default=_serialize))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Risk limit error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
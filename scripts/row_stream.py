@@ -0,0 +1,58 @@
+"""
+NDJSON row streaming and sparse field sets shared by the API scripts.
+
+List actions called with format='ndjson' write one JSON object per row to
+stdout instead of returning a single document. Rows are read through a
+server-side (named) cursor in batches of FETCH_SIZE and written as they
+arrive, so memory stays flat however many rows the query returns.
+
+List actions also accept fields, a list of column names: each row (JSON
+or NDJSON) then carries only those columns, in that order.
+"""
+
+import json
+from typing import Callable, Dict, List, Optional, Sequence, Tuple
+
+from psycopg2.extras import RealDictCursor
+
+FETCH_SIZE = 2000
+
+
+def check_fields(fields: Optional[Sequence[str]], available: Sequence[str]) -> None:
+    """Raise ValueError unless fields is None or a non-empty list of distinct available columns."""
+    if fields is None:
+        return
+    if not fields or len(set(fields)) != len(fields):
+        raise ValueError("fields must be a non-empty list of distinct column names")
+    unknown = [field for field in fields if field not in available]
+    if unknown:
+        raise ValueError(f"Unknown fields: {', '.join(unknown)} (available: {', '.join(available)})")
+
+
+def select_fields(row: Dict, fields: Optional[Sequence[str]]) -> Dict:
+    """The row restricted to fields (all columns when fields is None)."""
+    if fields is None:
+        return dict(row)
+    return {field: row[field] for field in fields}
+
+
+def stream_rows(conn, build_query: Callable[..., Tuple[str, List]], params: Dict, out, default=None) -> int:
+    """Write the rows of build_query(cur, params) to out as NDJSON and return the row count."""
+    fields = params.get('fields')
+    count = 0
+    with conn:
+        # Filters may look rows up (e.g. a group's subtree); a named cursor runs a single query
+        with conn.cursor() as cur:
+            query, args = build_query(cur, params)
+
+        with conn.cursor(name='row_stream', cursor_factory=RealDictCursor) as cur:
+            cur.itersize = FETCH_SIZE
+            cur.execute(query, args)
+            while True:
+                rows = cur.fetchmany(FETCH_SIZE)
+                if not rows:
+                    break
+                out.write(''.join(json.dumps(select_fields(row, fields), default=default) + '\n' for row in rows))
+                out.flush()
+                count += len(rows)
+    return count
@@ -0,0 +1,234 @@
+#!/usr/bin/env python3
+"""
+Demo database seeding script.
+
+Applies the schema (creating or upgrading tables) and loads a synthetic portfolio of managers,
+funds, cash flows, portfolio companies and benchmark series into the database at DATABASE_URL.
+Stored benchmark composites are then rebuilt from the new component series, and configured
+risk limits, compliance rules and allocation targets are evaluated against the new data.
+
+Replacing the data would rewrite cash flows in closed reporting periods, so seeding stops
+while any period is closed unless --discard-closed-periods is given, which also removes the
+closed periods, their reported NAVs and their adjustment history. The change feed
+(data_changes, GET /api/v1/changes) records the reload as a truncate of each table followed
+by an insert per new row.
+
+Usage:
+    python scripts/seed_demo.py [--funds 50] [--seed 42]
+    python scripts/seed_demo.py --schema-only    # upgrade an existing database, keeping its data
+    python scripts/seed_demo.py --discard-closed-periods
+"""
+
+import sys
+import os
+import argparse
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import RealDictCursor, execute_values
+from data import SyntheticPortfolioConfig, SyntheticPortfolioGenerator
+from benchmark_composite_api import rebuild_composites
+from risk_limits_api import evaluate
+from compliance_api import evaluate_rules
+from allocation_api import evaluate as evaluate_drift
+
+SCHEMA_PATH = os.path.join(project_root, 'data', 'storage', 'schema.sql')
+
+MANAGER_COLUMNS = [
+    'manager_id', 'manager_name', 'headquarters', 'primary_strategy',
+    'founded_year', 'aum'
+]
+FUND_COLUMNS = [
+    'fund_id', 'manager_id', 'fund_name', 'vintage', 'sector', 'committed_capital',
+    'invested_capital', 'current_nav', 'irr', 'moic', 'tvpi', 'dpi', 'rvpi',
+    'benchmark_return', 'volatility', 'beta', 'alpha', 'sharpe_ratio',
+    'sortino_ratio', 'max_drawdown', 'currency', 'status'
+]
+CASH_FLOW_COLUMNS = ['fund_id', 'flow_date', 'flow_type', 'amount', 'description']
+COMPANY_COLUMNS = ['company_id', 'company_name', 'sector', 'geography']
+INVESTMENT_COLUMNS = [
+    'fund_id', 'company_id', 'entry_date', 'exit_date', 'invested_amount',
+    'realized_amount', 'current_valuation', 'ownership_pct'
+]
+BENCHMARK_COLUMNS = ['benchmark_name', 'date', 'return_value', 'index_level']
+
+
+def ensure_schema(cur):
+    """Apply schema.sql; it is idempotent, so missing tables, columns and indexes are added in place."""
+    with open(SCHEMA_PATH) as f:
+        cur.execute(f.read())
+
+
+def insert_rows(cur, table, columns, rows):
+    values = [tuple(row[c] for c in columns) for row in rows]
+    execute_values(
+        cur,
+        f"INSERT INTO {table} ({', '.join(columns)}) VALUES %s",
+        values
+    )
+
+
+def refresh_composites(conn):
+    """Rebuild stored composites from the reloaded benchmark series (skipped on databases without the table)."""
+    with conn:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            cur.execute("SELECT to_regclass('public.benchmark_composites') AS present")
+            if cur.fetchone()['present'] is None:
+                return None
+            return rebuild_composites(cur)
+
+
+def evaluate_risk_limits(conn):
+    """Check risk limits against the loaded data (skipped on databases without the tables)."""
+    with conn:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            cur.execute("SELECT to_regclass('public.risk_limits') AS present")
+            if cur.fetchone()['present'] is None:
+                return None
+            return evaluate(cur, {'trigger': 'data_update'})
+
+
+def evaluate_compliance_rules(conn):
+    """Check stored compliance rules against the loaded data (skipped on databases without the tables)."""
+    with conn:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            cur.execute("SELECT to_regclass('public.compliance_rules') AS present")
+            if cur.fetchone()['present'] is None:
+                return None
+            return evaluate_rules(cur, {'trigger': 'data_update'})
+
+
+def evaluate_allocation_drift(conn):
+    """Check allocation targets against the loaded data (skipped on databases without the tables)."""
+    with conn:
+        with conn.cursor(cursor_factory=RealDictCursor) as cur:
+            cur.execute("SELECT to_regclass('public.allocation_drift_alerts') AS present")
+            if cur.fetchone()['present'] is None:
+                return None
+            return evaluate_drift(cur, {'trigger': 'data_update'})
+
+
+def main():
+    parser = argparse.ArgumentParser(description='Seed the database with a synthetic demo portfolio')
+    parser.add_argument('--funds', type=int, default=50, help='Number of funds to generate')
+    parser.add_argument('--seed', type=int, default=42, help='Random seed for reproducibility')
+    parser.add_argument('--schema-only', action='store_true',
+                        help='Create or upgrade tables without replacing any data')
+    parser.add_argument('--discard-closed-periods', action='store_true',
+                        help='Also remove closed reporting periods and their adjustments')
+    args = parser.parse_args()
+
+    database_url = os.environ.get('DATABASE_URL')
+    if not database_url:
+        print("DATABASE_URL is not set", file=sys.stderr)
+        sys.exit(1)
+
+    if args.schema_only:
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor() as cur:
+                    ensure_schema(cur)
+        except Exception as e:
+            print(f"Schema upgrade failed: {e}", file=sys.stderr)
+            sys.exit(1)
+        finally:
+            conn.close()
+        print("Schema is up to date")
+        return
+
+    generator = SyntheticPortfolioGenerator(
+        SyntheticPortfolioConfig(n_funds=args.funds, seed=args.seed)
+    )
+    data = generator.generate()
+
+    conn = psycopg2.connect(database_url)
+    try:
+        with conn:
+            with conn.cursor() as cur:
+                ensure_schema(cur)
+
+                cur.execute("SELECT COUNT(*), MAX(period_end) FROM reporting_periods")
+                n_closed, closed_through = cur.fetchone()
+                if n_closed and not args.discard_closed_periods:
+                    raise ValueError(
+                        f"{n_closed} reporting periods are closed (through {closed_through}); "
+                        "pass --discard-closed-periods to replace their data"
+                    )
+                if n_closed:
+                    # Lift the closed period lock for this load (see periods_api.py)
+                    cur.execute("SELECT set_config('helios.closed_period_write', 'on', true)")
+                    cur.execute("TRUNCATE reporting_periods CASCADE")
+
+                # Replace any existing data (cascades to cash flows, metrics, etc.)
+                cur.execute("TRUNCATE managers, portfolio_data, portfolio_companies, benchmark_data "
+                            "RESTART IDENTITY CASCADE")
+
+                insert_rows(cur, 'managers', MANAGER_COLUMNS, data['managers'])
+                insert_rows(cur, 'portfolio_data', FUND_COLUMNS, data['funds'])
+                insert_rows(cur, 'cash_flows', CASH_FLOW_COLUMNS, data['cash_flows'])
+                insert_rows(cur, 'portfolio_companies', COMPANY_COLUMNS, data['companies'])
+                insert_rows(cur, 'company_investments', INVESTMENT_COLUMNS, data['investments'])
+                insert_rows(cur, 'benchmark_data', BENCHMARK_COLUMNS, data['benchmarks'])
+
+                for table, column in (
+                    ('managers', 'manager_id'),
+                    ('portfolio_data', 'fund_id'),
+                    ('portfolio_companies', 'company_id')
+                ):
+                    cur.execute(
+                        f"SELECT setval(pg_get_serial_sequence('{table}', '{column}'), "
+                        f"(SELECT MAX({column}) FROM {table}))"
+                    )
+
+        # Data is committed at this point; a failed check should not fail the load
+        try:
+            composites = refresh_composites(conn)
+        except Exception as e:
+            composites = None
+            print(f"Composite benchmark rebuild failed: {e}", file=sys.stderr)
+        try:
+            limits = evaluate_risk_limits(conn)
+        except Exception as e:
+            limits = None
+            print(f"Risk limit evaluation failed: {e}", file=sys.stderr)
+        try:
+            compliance = evaluate_compliance_rules(conn)
+        except Exception as e:
+            compliance = None
+            print(f"Compliance rule evaluation failed: {e}", file=sys.stderr)
+        try:
+            drift = evaluate_allocation_drift(conn)
+        except Exception as e:
+            drift = None
+            print(f"Allocation drift evaluation failed: {e}", file=sys.stderr)
+    except Exception as e:
+        print(f"Seeding failed: {e}", file=sys.stderr)
+        sys.exit(1)
+    finally:
+        conn.close()
+
+    if n_closed:
+        print(f"Discarded {n_closed} closed reporting periods (through {closed_through})")
+    print(f"Loaded {len(data['managers'])} managers, {len(data['funds'])} funds, "
+          f"{len(data['cash_flows'])} cash flows, {len(data['investments'])} company investments, "
+          f"{len(data['benchmarks'])} benchmark observations")
+    if composites and (composites['rebuilt'] or composites['failed']):
+        print(f"Benchmark composites: {len(composites['rebuilt'])} rebuilt")
+        for failure in composites['failed']:
+            print(f"Composite {failure['name']} not rebuilt: {failure['error']}", file=sys.stderr)
+    if limits and limits['limits']:
+        print(f"Risk limits: {limits['n_breaches']} breaches, {limits['n_warnings']} warnings "
+              f"({limits['n_new_breaches']} new, {limits['n_resolved']} resolved)")
+    if compliance and compliance['n_rules']:
+        print(f"Compliance rules: {compliance['n_violations']} violations "
+              f"({compliance['n_new_violations']} new, {compliance['n_resolved']} resolved)")
+    if drift and drift['n_dimensions']:
+        print(f"Allocation drift: {drift['n_alerts']} alerts "
+              f"({drift['n_new_alerts']} new, {drift['n_resolved']} resolved)")
+
+
+if __name__ == "__main__":
+    main()
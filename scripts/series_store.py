@@ -0,0 +1,47 @@
+"""
+Stored benchmark series access shared by the API scripts.
+
+Benchmark returns are loaded into benchmark_data by scripts/seed_demo.py
+and scripts/benchmark_composite_api.py. Calibration and backtests read them
+by name over an optional date window; the frequency of a series is inferred
+from the spacing of its dates. Cursors are RealDictCursors.
+"""
+
+from typing import List, Optional, Tuple
+
+import numpy as np
+
+FREQUENCIES = (252, 52, 12, 4, 1)
+
+
+def load_series(cur, name: str, start_date: Optional[str] = None, end_date: Optional[str] = None) -> Tuple[List, List[float]]:
+    """Dates and returns of a stored benchmark in the requested date window."""
+    cur.execute(
+        "SELECT date, return_value FROM benchmark_data "
+        "WHERE benchmark_name = %s AND return_value IS NOT NULL "
+        "AND (%s::date IS NULL OR date >= %s::date) AND (%s::date IS NULL OR date <= %s::date) "
+        "ORDER BY date",
+        (name, start_date, start_date, end_date, end_date)
+    )
+    rows = cur.fetchall()
+    if not rows:
+        raise ValueError(f"No stored returns for series '{name}' in the requested window")
+    return [row['date'] for row in rows], [float(row['return_value']) for row in rows]
+
+
+def load_aligned_series(cur, names: List[str], start_date: Optional[str] = None, end_date: Optional[str] = None) -> Tuple[List, np.ndarray]:
+    """Returns matrix (n_dates × n_series) over the dates every series has."""
+    series = [dict(zip(*load_series(cur, name, start_date, end_date))) for name in names]
+    dates = sorted(set.intersection(*(set(s) for s in series)))
+    if not dates:
+        raise ValueError(f"Series {', '.join(names)} have no dates in common")
+    return dates, np.array([[s[d] for s in series] for d in dates])
+
+
+def infer_frequency(dates: List) -> int:
+    """Closest standard periods-per-year to the observed spacing of dates."""
+    if len(dates) < 2:
+        raise ValueError("Need at least 2 dates to infer the frequency")
+    years = (dates[-1] - dates[0]).days / 365.25
+    observed = (len(dates) - 1) / years if years > 0 else FREQUENCIES[0]
+    return min(FREQUENCIES, key=lambda f: abs(np.log(f / observed)))
@@ -0,0 +1,63 @@
+#!/usr/bin/env python3
+"""
+Sobol point streaming API script for web interface.
+
+Writes newline-delimited JSON to stdout: a header object with the sequence
+settings, then one JSON array of coordinates per point. Points are
+generated in batches so memory stays bounded for long streams, and the
+same seed and skip always reproduce the same points.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+from pricing.monte_carlo import SobolSequence
+
+BATCH_SIZE = 4096
+
+
+def stream(params, out):
+    dimension = params['dimension']
+    n = params['n']
+    skip = params.get('skip', 0)
+    seed = params.get('seed', 0)
+    scramble = params.get('scramble', True)
+    transform = params.get('transform', 'uniform')
+    if transform not in ('uniform', 'normal'):
+        raise ValueError(f"Unknown transform: {transform}")
+
+    sobol = SobolSequence(dimension=dimension, scramble=scramble, seed=seed).fast_forward(skip)
+    out.write(json.dumps({
+        'dimension': dimension, 'n': n, 'skip': skip, 'seed': seed,
+        'scramble': 'owen' if scramble else 'none', 'transform': transform
+    }) + '\n')
+
+    remaining = n
+    while remaining > 0:
+        batch = min(BATCH_SIZE, remaining)
+        points = sobol.normal(batch) if transform == 'normal' else sobol.random(batch)
+        out.write(''.join(json.dumps(point) + '\n' for point in points.tolist()))
+        out.flush()
+        remaining -= batch
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        stream(params, sys.stdout)
+
+    except Exception as e:
+        print(json.dumps({"error": f"Sobol generation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
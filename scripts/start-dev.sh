@@ -60,6 +60,14 @@ fi
 echo -e "${GREEN}✅ Dependencies ready${NC}"
 echo ""
 
+# Optionally load the synthetic demo portfolio (./scripts/start-dev.sh --demo)
+if [ "$1" == "--demo" ]; then
+    echo -e "${YELLOW}🌱 Seeding demo portfolio...${NC}"
+    venv/bin/python scripts/seed_demo.py
+    echo -e "${GREEN}✅ Demo data loaded${NC}"
+    echo ""
+fi
+
 # Create log directory
 mkdir -p logs
 
@@ -0,0 +1,51 @@
+#!/usr/bin/env python3
+"""
+Stratified scenario simulation API script for web interface.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+from pricing.monte_carlo import ScenarioBucket, StratifiedSimulator
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        simulator = StratifiedSimulator(
+            [ScenarioBucket(**bucket) for bucket in params['buckets']],
+            n_paths=params.get('n_paths', 100000),
+            allocation=params.get('allocation', 'proportional'),
+            min_paths=params.get('min_paths', 1000),
+            seed=params.get('seed', 42)
+        )
+
+        paths = simulator.simulate(
+            T=params.get('T', 5.0),
+            initial_value=params.get('initial_value', 1.0),
+            steps_per_year=params.get('steps_per_year', 1)
+        )
+        result = simulator.summarize(paths, queries=params.get('queries'))
+        if params.get('horizons'):
+            result['by_horizon'] = simulator.horizon_summaries(
+                paths, params['horizons'], queries=params.get('queries')
+            )
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Stratified simulation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,39 @@
+#!/usr/bin/env python3
+"""
+Reverse stress testing API script for web interface.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+from analytics import reverse_stress_test
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        result = reverse_stress_test(
+            params['holdings'],
+            params['factors'],
+            loss_threshold=params['loss_threshold'],
+            correlation=params.get('correlation')
+        )
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Reverse stress test error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,57 @@
+#!/usr/bin/env python3
+"""
+Synthetic test data API script for web interface.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+from data import SyntheticPortfolioConfig, SyntheticPortfolioGenerator
+
+MAX_FUNDS = 5000
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        config = SyntheticPortfolioConfig()
+        config.n_funds = int(params.get('count', config.n_funds))
+        config.vintage_start = int(params.get('vintage_start', config.vintage_start))
+        config.vintage_end = int(params.get('vintage_end', config.vintage_end))
+        config.mean_irr = float(params.get('mean_irr', config.mean_irr))
+        config.irr_dispersion = float(params.get('irr_dispersion', config.irr_dispersion))
+        config.seed = params.get('seed')
+
+        if 'sector_mix' in params:
+            config.sector_mix = {str(k): float(v) for k, v in params['sector_mix'].items()}
+        if 'volatility_range' in params:
+            low, high = params['volatility_range']
+            config.volatility_range = (float(low), float(high))
+
+        if config.n_funds > MAX_FUNDS:
+            raise ValueError(f"count must not exceed {MAX_FUNDS}")
+
+        data = SyntheticPortfolioGenerator(config).generate()
+
+        if not params.get('include_benchmarks', True):
+            data.pop('benchmarks')
+
+        # Dates are not JSON serializable
+        print(json.dumps(data, default=lambda d: d.isoformat()))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Generation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
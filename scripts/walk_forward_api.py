@@ -0,0 +1,117 @@
+#!/usr/bin/env python3
+"""
+Walk-forward validation API script for web interface.
+
+Validates the IRR forecaster (python/ml_forecast.py PortfolioMLForecaster)
+out of sample on stored funds. Funds with a reported IRR (optionally of a
+portfolio group's subtree, group_id, and/or a portfolio, portfolio_id) are
+ordered by vintage, so each window trains on older vintages and is scored
+on the next ones.
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import pandas as pd
+import psycopg2
+from psycopg2.extras import RealDictCursor
+from backtesting import WalkForwardValidator
+from python.ml_forecast import PortfolioMLForecaster
+from portfolio_groups import group_filter
+
+MODEL_TYPES = ('random_forest', 'gradient_boosting')
+
+
+def load_funds(cur, group_id, portfolio_id=None):
+    """Funds with the forecaster's inputs, oldest vintage first."""
+    condition, args = group_filter(cur, group_id, portfolio_id=portfolio_id)
+    cur.execute(
+        "SELECT p.fund_id, p.vintage, p.sector, p.committed_capital, p.benchmark_return, "
+        "p.volatility, p.irr FROM portfolio_data p "
+        "WHERE p.irr IS NOT NULL AND p.benchmark_return IS NOT NULL AND p.volatility > 0 "
+        f"AND {condition} ORDER BY p.vintage, p.fund_id",
+        args
+    )
+    rows = cur.fetchall()
+    if not rows:
+        raise ValueError("No funds with IRR, benchmark return and volatility to validate on")
+
+    df = pd.DataFrame([dict(row) for row in rows])
+    for column in ('committed_capital', 'benchmark_return', 'volatility', 'irr'):
+        df[column] = df[column].astype(float)
+    return df
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+
+        model_type = params.get('model_type', 'random_forest')
+        if model_type not in MODEL_TYPES:
+            raise ValueError(f"model_type must be one of {', '.join(MODEL_TYPES)}")
+
+        validator = WalkForwardValidator(
+            train_window=params['train_window'],
+            test_window=params['test_window'],
+            step=params.get('step'),
+            expanding=params.get('expanding', False)
+        )
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor(cursor_factory=RealDictCursor) as cur:
+                    funds = load_funds(cur, params.get('group_id'), params.get('portfolio_id'))
+        finally:
+            conn.close()
+
+        X, y = PortfolioMLForecaster(model_type).prepare_features(funds.copy())
+        report = validator.validate(X, y, lambda: PortfolioMLForecaster(model_type))
+
+        # Label windows with vintages and predictions with funds (test windows may overlap)
+        vintages = funds['vintage'].tolist()
+        fund_ids = funds['fund_id'].tolist()
+        for window in report['windows']:
+            window['train_vintages'] = [vintages[window['train_start']], vintages[window['train_end']]]
+            window['test_vintages'] = [vintages[window['test_start']], vintages[window['test_end']]]
+        tested = [fund_ids[i] for _, test_idx in validator.splits(len(funds)) for i in test_idx]
+
+        output = {
+            'model_type': model_type,
+            'group_id': params.get('group_id'),
+            'portfolio_id': params.get('portfolio_id'),
+            'n_funds': len(funds),
+            'train_window': validator.train_window,
+            'test_window': validator.test_window,
+            'step': validator.step,
+            'expanding': validator.expanding,
+            'n_windows': report['n_windows'],
+            'windows': report['windows'],
+            'overall': report['overall'],
+            'predictions': [
+                {'fund_id': fund_id, 'actual': float(actual), 'predicted': float(predicted)}
+                for fund_id, actual, predicted in zip(tested, report['actuals'], report['predictions'])
+            ],
+        }
+
+        print(json.dumps(output))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Walk-forward validation error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,82 @@
+#!/usr/bin/env python3
+"""
+Yield curve API script for web interface.
+
+Actions:
+    upload: store a dated curve in yield_curves
+    get:    return the latest curve on or before a date, optionally
+            interpolated at requested tenors
+"""
+
+import sys
+import json
+import os
+
+project_root = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+sys.path.insert(0, project_root)
+
+import psycopg2
+from psycopg2.extras import execute_values
+from pricing.rates import YieldCurve
+from rates_store import load_curve, DEFAULT_CURVE
+
+
+def main():
+    if len(sys.argv) != 2:
+        print(json.dumps({"error": "Invalid number of arguments"}), file=sys.stderr)
+        sys.exit(1)
+
+    try:
+        params = json.loads(sys.argv[1])
+        action = params.get('action', 'get')
+        curve_name = params.get('curve_name') or DEFAULT_CURVE
+
+        database_url = os.environ.get('DATABASE_URL')
+        if not database_url:
+            raise ValueError("DATABASE_URL is not set")
+
+        conn = psycopg2.connect(database_url)
+        try:
+            with conn:
+                with conn.cursor() as cur:
+                    if action == 'upload':
+                        # Validates tenors and rates before anything is written
+                        curve = YieldCurve(params['tenors'], params['rates'], name=curve_name)
+                        cur.execute(
+                            "DELETE FROM yield_curves WHERE curve_name = %s AND curve_date = %s",
+                            (curve_name, params['curve_date'])
+                        )
+                        execute_values(
+                            cur,
+                            "INSERT INTO yield_curves (curve_name, curve_date, tenor_years, zero_rate) VALUES %s",
+                            [(curve_name, params['curve_date'], t, r) for t, r in zip(curve.tenors, curve.rates)]
+                        )
+                        result = {'curve_name': curve_name, 'curve_date': params['curve_date'], 'points': len(curve.tenors)}
+                    elif action == 'get':
+                        curve = load_curve(cur, curve_name, params.get('as_of'))
+                        if curve is None:
+                            raise ValueError(f"No stored curve named '{curve_name}'")
+                        result = curve.to_dict()
+                        if params.get('tenors'):
+                            result['interpolated'] = [
+                                {
+                                    'tenor': t,
+                                    'zero_rate': curve.zero_rate(t),
+                                    'discount_factor': curve.discount_factor(t),
+                                }
+                                for t in params['tenors']
+                            ]
+                    else:
+                        raise ValueError(f"Unknown action: {action}")
+        finally:
+            conn.close()
+
+        print(json.dumps(result))
+
+    except Exception as e:
+        print(json.dumps({"error": f"Yield curve error: {str(e)}"}), file=sys.stderr)
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,108 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const STATUSES = ['open', 'resolved', 'all']
+const TRIGGERS = ['manual', 'scheduled']
+
+function runAllocationScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'allocation_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Allocation drift alert request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse allocation drift alert result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// List recorded allocation drift alerts
+export async function GET(request: NextRequest) {
+  try {
+    const status = request.nextUrl.searchParams.get('status') ?? 'open'
+    const limitParam = request.nextUrl.searchParams.get('limit')
+    const limit = limitParam === null ? 100 : Number(limitParam)
+
+    if (!STATUSES.includes(status)) {
+      return NextResponse.json(
+        { error: `status must be one of: ${STATUSES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(limit) || limit < 1 || limit > 1000) {
+      return NextResponse.json(
+        { error: 'limit must be an integer between 1 and 1000' },
+        { status: 400 }
+      )
+    }
+
+    return runAllocationScript({ action: 'alerts', status, limit })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Check drift for every dimension with targets and record alerts
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { trigger = 'manual', default_threshold = 0.05 } = body
+
+    // Validate inputs
+    if (!TRIGGERS.includes(trigger)) {
+      return NextResponse.json(
+        { error: `trigger must be one of: ${TRIGGERS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof default_threshold !== 'number' || default_threshold <= 0 || default_threshold >= 1) {
+      return NextResponse.json(
+        { error: 'default_threshold must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    return runAllocationScript({ action: 'evaluate', trigger, default_threshold })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,156 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const DIMENSIONS = ['sector', 'strategy']
+
+function runAllocationScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'allocation_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Allocation drift failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse allocation drift result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Drift of current NAV weights from the stored targets (?group_id= for one portfolio group's subtree, ?portfolio_id= for one portfolio)
+export async function GET(request: NextRequest) {
+  try {
+    const dimension = request.nextUrl.searchParams.get('dimension') ?? 'sector'
+    const thresholdParam = request.nextUrl.searchParams.get('default_threshold')
+    const default_threshold = thresholdParam === null ? 0.05 : Number(thresholdParam)
+
+    if (!DIMENSIONS.includes(dimension)) {
+      return NextResponse.json(
+        { error: `dimension must be one of: ${DIMENSIONS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isFinite(default_threshold) || default_threshold <= 0 || default_threshold >= 1) {
+      return NextResponse.json(
+        { error: 'default_threshold must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    const groupParam = request.nextUrl.searchParams.get('group_id')
+    const group_id = groupParam === null ? null : Number(groupParam)
+    if (group_id !== null && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const portfolioParam = request.nextUrl.searchParams.get('portfolio_id')
+    const portfolio_id = portfolioParam === null ? null : Number(portfolioParam)
+    if (portfolio_id !== null && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runAllocationScript({ action: 'drift', dimension, default_threshold, group_id, portfolio_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Replace the target weights for a dimension
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { dimension = 'sector', targets } = body
+
+    // Validate inputs
+    if (!DIMENSIONS.includes(dimension)) {
+      return NextResponse.json(
+        { error: `dimension must be one of: ${DIMENSIONS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(targets) || targets.length === 0 || targets.length > 100) {
+      return NextResponse.json(
+        { error: 'targets must be an array of 1 to 100 entries' },
+        { status: 400 }
+      )
+    }
+
+    const invalid = targets.find((t) =>
+      typeof t?.bucket !== 'string' ||
+      t.bucket.trim().length === 0 ||
+      typeof t?.target_weight !== 'number' ||
+      t.target_weight < 0 ||
+      t.target_weight > 1 ||
+      (t?.drift_threshold !== undefined && (typeof t.drift_threshold !== 'number' || t.drift_threshold <= 0))
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: 'each target needs a bucket, a target_weight in [0, 1] and an optional positive drift_threshold' },
+        { status: 400 }
+      )
+    }
+
+    const total = targets.reduce((sum: number, t: { target_weight: number }) => sum + t.target_weight, 0)
+    if (Math.abs(total - 1) > 1e-6) {
+      return NextResponse.json(
+        { error: `target weights must sum to 1 (got ${total.toFixed(4)})` },
+        { status: 400 }
+      )
+    }
+
+    const buckets = new Set(targets.map((t: { bucket: string }) => t.bucket))
+    if (buckets.size !== targets.length) {
+      return NextResponse.json(
+        { error: 'each bucket may appear only once' },
+        { status: 400 }
+      )
+    }
+
+    return runAllocationScript({ action: 'set_targets', dimension, targets })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
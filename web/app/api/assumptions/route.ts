@@ -0,0 +1,163 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const PACING_FIELDS = [
+  'call_rate', 'call_volatility', 'distribution_rate',
+  'private_return', 'private_beta', 'private_volatility'
+]
+const MAX_ASSETS = 200
+
+function runAssumptionsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'assumptions_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Assumption set request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse assumption set result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+const isNumberList = (value: unknown, length: number): boolean =>
+  Array.isArray(value) && value.length === length &&
+  value.every((v) => typeof v === 'number' && Number.isFinite(v))
+
+// Shape checks only; values (PSD correlations etc.) are validated in Python
+const isParameters = (value: any): boolean => {
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) return false
+  const { assets, expected_returns, volatilities, correlations, pacing } = value
+  if (assets === undefined && pacing === undefined) return false
+
+  if (assets !== undefined) {
+    if (!Array.isArray(assets) || assets.length === 0 || assets.length > MAX_ASSETS ||
+        !assets.every((a) => typeof a === 'string' && a.length > 0)) return false
+    if (!isNumberList(expected_returns, assets.length) || !isNumberList(volatilities, assets.length)) return false
+    if (correlations !== undefined && typeof correlations !== 'number' &&
+        !(Array.isArray(correlations) && correlations.length === assets.length &&
+          correlations.every((row: unknown) => isNumberList(row, assets.length)))) return false
+  }
+
+  if (pacing !== undefined) {
+    if (typeof pacing !== 'object' || pacing === null || Array.isArray(pacing)) return false
+    if (!Object.entries(pacing).every(([key, v]) =>
+      PACING_FIELDS.includes(key) && typeof v === 'number' && Number.isFinite(v))) return false
+  }
+  return true
+}
+
+// List sets, list the versions of one name, or fetch one set
+export async function GET(request: NextRequest) {
+  try {
+    const searchParams = request.nextUrl.searchParams
+    const idParam = searchParams.get('id')
+    const name = searchParams.get('name')
+    const versionParam = searchParams.get('version')
+
+    if (idParam !== null) {
+      const assumption_set_id = Number(idParam)
+      if (!Number.isInteger(assumption_set_id) || assumption_set_id <= 0) {
+        return NextResponse.json(
+          { error: 'id must be a positive integer' },
+          { status: 400 }
+        )
+      }
+      return runAssumptionsScript({ action: 'get', assumption_set_id })
+    }
+
+    if (name === null) {
+      return runAssumptionsScript({ action: 'list' })
+    }
+
+    if (versionParam === 'all') {
+      return runAssumptionsScript({ action: 'versions', set_name: name })
+    }
+
+    const version = versionParam === null ? undefined : Number(versionParam)
+    if (version !== undefined && (!Number.isInteger(version) || version <= 0)) {
+      return NextResponse.json(
+        { error: "version must be a positive integer or 'all'" },
+        { status: 400 }
+      )
+    }
+
+    return runAssumptionsScript({ action: 'get', set_name: name, version })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Save a set as the next version of its name
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { set_name, description, parameters, created_by } = body
+
+    // Validate inputs
+    if (typeof set_name !== 'string' || set_name.trim().length === 0 || set_name.length > 100) {
+      return NextResponse.json(
+        { error: 'set_name must be a non-empty string of at most 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    if (!isParameters(parameters)) {
+      return NextResponse.json(
+        {
+          error: 'parameters must contain assets with matching expected_returns, volatilities and ' +
+            `optional correlations, and/or pacing with numeric values for: ${PACING_FIELDS.join(', ')}`
+        },
+        { status: 400 }
+      )
+    }
+
+    if ((description !== undefined && typeof description !== 'string') ||
+        (created_by !== undefined && typeof created_by !== 'string')) {
+      return NextResponse.json(
+        { error: 'description and created_by must be strings' },
+        { status: 400 }
+      )
+    }
+
+    return runAssumptionsScript({ action: 'save', set_name, description, parameters, created_by })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
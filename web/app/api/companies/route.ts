@@ -0,0 +1,206 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const TAG_KEY = /^[A-Za-z0-9_.-]{1,50}$/
+const COMPANY_FIELDS = [
+  'company_id', 'company_name', 'sector', 'geography', 'num_funds', 'total_invested',
+  'total_realized', 'total_valuation', 'gross_moic', 'held'
+]
+
+// ?tag=key:value, repeatable; a fund must carry every pair (null when malformed)
+function parseTags(searchParams: URLSearchParams): Record<string, string> | null {
+  const tags: Record<string, string> = {}
+  for (const tag of searchParams.getAll('tag')) {
+    const split = tag.indexOf(':')
+    const key = tag.slice(0, split)
+    const value = tag.slice(split + 1)
+    if (split < 1 || !TAG_KEY.test(key) || value.length === 0 || value.length > 100 || Object.hasOwn(tags, key)) {
+      return null
+    }
+    tags[key] = value
+  }
+  return tags
+}
+
+function runCompaniesScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'companies_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Company request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse company result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Stream list rows as newline-delimited JSON. Respond once the first chunk
+// arrives so failures before any row still return a JSON error
+function streamCompaniesScript(params: object): Promise<Response> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'companies_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise<Response>((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify({ ...params, format: 'ndjson' })])
+    let errorData = ''
+    let started = false
+    let controller: ReadableStreamDefaultController<Uint8Array>
+
+    const stream = new ReadableStream<Uint8Array>({
+      start(c) {
+        controller = c
+      },
+      pull() {
+        pythonProcess.stdout.resume()
+      },
+      cancel() {
+        pythonProcess.kill()
+      }
+    })
+    const respond = () => resolve(
+      new Response(stream, {
+        headers: { 'Content-Type': 'application/x-ndjson' }
+      })
+    )
+
+    pythonProcess.stdout.on('data', (data: Buffer) => {
+      controller.enqueue(new Uint8Array(data))
+      // Let the database cursor wait for a slow client instead of buffering rows
+      if ((controller.desiredSize ?? 1) <= 0) {
+        pythonProcess.stdout.pause()
+      }
+      if (!started) {
+        started = true
+        respond()
+      }
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        if (started) {
+          controller.error(new Error(`Company request failed: ${errorData}`))
+        } else {
+          resolve(
+            NextResponse.json(
+              { error: `Company request failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        }
+      } else {
+        // No rows is an empty stream
+        controller.close()
+        if (!started) {
+          respond()
+        }
+      }
+    })
+  })
+}
+
+// List portfolio companies (?sector=, ?tag=key:value, ?group_id= and ?portfolio_id= to filter, ?fields=a,b for some columns, ?format=ndjson to stream, ?id=N for one company and its investments by fund)
+export async function GET(request: NextRequest) {
+  try {
+    const idParam = request.nextUrl.searchParams.get('id')
+    const sector = request.nextUrl.searchParams.get('sector')
+
+    if (idParam === null) {
+      if (sector !== null && (sector.trim().length === 0 || sector.length > 100)) {
+        return NextResponse.json(
+          { error: 'sector must be 1 to 100 characters' },
+          { status: 400 }
+        )
+      }
+      const tags = parseTags(request.nextUrl.searchParams)
+      if (tags === null) {
+        return NextResponse.json(
+          { error: 'tag filters must be key:value with a key of up to 50 letters, digits, _ . or - and a value of up to 100 characters, one per key' },
+          { status: 400 }
+        )
+      }
+      const groupParam = request.nextUrl.searchParams.get('group_id')
+      const group_id = groupParam === null ? null : Number(groupParam)
+      if (group_id !== null && (!Number.isInteger(group_id) || group_id <= 0)) {
+        return NextResponse.json(
+          { error: 'group_id must be a positive integer' },
+          { status: 400 }
+        )
+      }
+      const portfolioParam = request.nextUrl.searchParams.get('portfolio_id')
+      const portfolio_id = portfolioParam === null ? null : Number(portfolioParam)
+      if (portfolio_id !== null && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+        return NextResponse.json(
+          { error: 'portfolio_id must be a positive integer' },
+          { status: 400 }
+        )
+      }
+      const format = request.nextUrl.searchParams.get('format') ?? 'json'
+      if (format !== 'json' && format !== 'ndjson') {
+        return NextResponse.json(
+          { error: 'format must be json or ndjson' },
+          { status: 400 }
+        )
+      }
+      const fieldsParam = request.nextUrl.searchParams.get('fields')
+      const fields = fieldsParam === null ? null : fieldsParam.split(',')
+      if (fields !== null && (new Set(fields).size !== fields.length || fields.some((field) => !COMPANY_FIELDS.includes(field)))) {
+        return NextResponse.json(
+          { error: `fields must be distinct columns from: ${COMPANY_FIELDS.join(', ')}` },
+          { status: 400 }
+        )
+      }
+      const params = { action: 'list', sector, tags, group_id, portfolio_id, fields }
+      return format === 'ndjson' ? streamCompaniesScript(params) : runCompaniesScript(params)
+    }
+
+    const company_id = Number(idParam)
+    if (!Number.isInteger(company_id) || company_id <= 0) {
+      return NextResponse.json(
+        { error: 'id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runCompaniesScript({ action: 'get', company_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
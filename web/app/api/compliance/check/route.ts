@@ -0,0 +1,72 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { rules, positions, changes } = body
+
+    // Validate inputs
+    if (!Array.isArray(rules) || rules.length === 0) {
+      return NextResponse.json(
+        { error: 'rules must be a non-empty array' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(positions) || positions.length === 0) {
+      return NextResponse.json(
+        { error: 'positions must be a non-empty array' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'compliance_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ rules, positions, changes })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Compliance check failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse compliance result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
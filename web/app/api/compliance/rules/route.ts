@@ -0,0 +1,106 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const RULE_TYPES = ['max_group_weight', 'max_position_weight', 'min_holdings', 'min_groups']
+const GROUP_RULE_TYPES = ['max_group_weight', 'min_groups']
+const ATTRIBUTES = ['sector', 'manager', 'vintage', 'currency']
+const MAX_RULES = 50
+
+function runComplianceScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'compliance_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Compliance rule request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse compliance rule result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// List stored compliance rules
+export async function GET() {
+  try {
+    return runComplianceScript({ action: 'rules' })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Create or update rules by name
+export async function PUT(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { rules } = body
+
+    // Validate inputs
+    if (!Array.isArray(rules) || rules.length === 0 || rules.length > MAX_RULES) {
+      return NextResponse.json(
+        { error: `rules must be an array of 1 to ${MAX_RULES} entries` },
+        { status: 400 }
+      )
+    }
+
+    const invalid = rules.find((r) =>
+      typeof r?.rule_name !== 'string' || r.rule_name.trim().length === 0 || r.rule_name.length > 100 ||
+      !RULE_TYPES.includes(r?.rule_type) ||
+      typeof r?.limit_value !== 'number' || !Number.isFinite(r.limit_value) || r.limit_value < 0 ||
+      (GROUP_RULE_TYPES.includes(r.rule_type) ? !ATTRIBUTES.includes(r?.attribute) : r?.attribute != null) ||
+      (r?.active !== undefined && typeof r.active !== 'boolean')
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: `each rule needs a rule_name, a rule_type (${RULE_TYPES.join(', ')}), a non-negative limit_value, an attribute (${ATTRIBUTES.join(', ')}) for group rules only and an optional active flag` },
+        { status: 400 }
+      )
+    }
+
+    if (new Set(rules.map((r: { rule_name: string }) => r.rule_name)).size !== rules.length) {
+      return NextResponse.json(
+        { error: 'each rule_name may appear only once' },
+        { status: 400 }
+      )
+    }
+
+    return runComplianceScript({ action: 'set_rules', rules })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,115 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const STATUSES = ['open', 'resolved', 'all']
+const TRIGGERS = ['manual', 'scheduled']
+
+function runComplianceScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'compliance_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Compliance violation request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse compliance violation result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// List recorded rule violations
+export async function GET(request: NextRequest) {
+  try {
+    const status = request.nextUrl.searchParams.get('status') ?? 'open'
+    const limitParam = request.nextUrl.searchParams.get('limit')
+    const limit = limitParam === null ? 100 : Number(limitParam)
+
+    if (!STATUSES.includes(status)) {
+      return NextResponse.json(
+        { error: `status must be one of: ${STATUSES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(limit) || limit < 1 || limit > 1000) {
+      return NextResponse.json(
+        { error: 'limit must be an integer between 1 and 1000' },
+        { status: 400 }
+      )
+    }
+
+    return runComplianceScript({ action: 'violations', status, limit })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Evaluate the active rules against current positions and record violations (group_id or portfolio_id checks one portfolio group or portfolio without recording)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { trigger = 'manual', group_id, portfolio_id } = body
+
+    // Validate inputs
+    if (!TRIGGERS.includes(trigger)) {
+      return NextResponse.json(
+        { error: `trigger must be one of: ${TRIGGERS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (group_id !== undefined && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (portfolio_id !== undefined && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runComplianceScript({ action: 'evaluate', trigger, group_id, portfolio_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
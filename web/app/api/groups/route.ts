@@ -0,0 +1,221 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MAX_FUNDS = 500
+
+const isGroupId = (value: unknown): value is number =>
+  Number.isInteger(value) && (value as number) > 0
+
+const isGroupName = (value: unknown): value is string =>
+  typeof value === 'string' && value.trim().length > 0 && value.length <= 100
+
+const isDescription = (value: unknown) =>
+  value === null || (typeof value === 'string' && value.length <= 1000)
+
+function runGroupsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'groups_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Portfolio group request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse portfolio group result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// The group tree with direct and rolled-up figures (?id=N for one group's subtree and its funds)
+export async function GET(request: NextRequest) {
+  try {
+    const idParam = request.nextUrl.searchParams.get('id')
+    if (idParam === null) {
+      return runGroupsScript({ action: 'tree' })
+    }
+
+    const group_id = Number(idParam)
+    if (!isGroupId(group_id)) {
+      return NextResponse.json(
+        { error: 'id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runGroupsScript({ action: 'get', group_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Create a group under parent_id (or as a root)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { group_name, parent_id = null, description = null } = body
+
+    // Validate inputs
+    if (!isGroupName(group_name)) {
+      return NextResponse.json(
+        { error: 'group_name must be 1 to 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    if (parent_id !== null && !isGroupId(parent_id)) {
+      return NextResponse.json(
+        { error: 'parent_id must be a positive integer or null' },
+        { status: 400 }
+      )
+    }
+
+    if (!isDescription(description)) {
+      return NextResponse.json(
+        { error: 'description must be a string of up to 1000 characters or null' },
+        { status: 400 }
+      )
+    }
+
+    return runGroupsScript({ action: 'create', group_name, parent_id, description })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Rename, re-describe or move a group (parent_id null makes it a root)
+export async function PATCH(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { group_id, group_name, parent_id, description } = body
+
+    // Validate inputs
+    if (!isGroupId(group_id)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (group_name === undefined && parent_id === undefined && description === undefined) {
+      return NextResponse.json(
+        { error: 'provide group_name, parent_id or description to update' },
+        { status: 400 }
+      )
+    }
+
+    if (group_name !== undefined && !isGroupName(group_name)) {
+      return NextResponse.json(
+        { error: 'group_name must be 1 to 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    if (parent_id !== undefined && parent_id !== null && !isGroupId(parent_id)) {
+      return NextResponse.json(
+        { error: 'parent_id must be a positive integer or null' },
+        { status: 400 }
+      )
+    }
+
+    if (description !== undefined && !isDescription(description)) {
+      return NextResponse.json(
+        { error: 'description must be a string of up to 1000 characters or null' },
+        { status: 400 }
+      )
+    }
+
+    // Undefined fields are dropped by JSON.stringify and left unchanged
+    return runGroupsScript({ action: 'update', group_id, group_name, parent_id, description })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Delete a group without subgroups (?id=N); its funds become unassigned
+export async function DELETE(request: NextRequest) {
+  try {
+    const group_id = Number(request.nextUrl.searchParams.get('id'))
+    if (!isGroupId(group_id)) {
+      return NextResponse.json(
+        { error: 'id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runGroupsScript({ action: 'delete', group_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Assign funds to a group (group_id null unassigns them)
+export async function PUT(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { fund_ids, group_id = null } = body
+
+    // Validate inputs
+    if (!Array.isArray(fund_ids) || fund_ids.length === 0 || fund_ids.length > MAX_FUNDS ||
+        !fund_ids.every(isGroupId) || new Set(fund_ids).size !== fund_ids.length) {
+      return NextResponse.json(
+        { error: `fund_ids must be 1 to ${MAX_FUNDS} distinct positive integers` },
+        { status: 400 }
+      )
+    }
+
+    if (group_id !== null && !isGroupId(group_id)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer or null' },
+        { status: 400 }
+      )
+    }
+
+    return runGroupsScript({ action: 'assign', fund_ids, group_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,79 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { cash_flows, inflation_adjusted = false, cpi_series } = body
+
+    // Validate inputs
+    if (!Array.isArray(cash_flows) || cash_flows.length < 2 || cash_flows.length > 5000) {
+      return NextResponse.json(
+        { error: 'cash_flows must be an array of 2 to 5000 entries' },
+        { status: 400 }
+      )
+    }
+
+    if (!cash_flows.every((cf) => typeof cf?.amount === 'number' && typeof cf?.date === 'string' && !Number.isNaN(Date.parse(cf.date)))) {
+      return NextResponse.json(
+        { error: 'each cash flow needs a date (YYYY-MM-DD) and a numeric amount' },
+        { status: 400 }
+      )
+    }
+
+    if (!cash_flows.some((cf) => cf.amount < 0) || !cash_flows.some((cf) => cf.amount > 0)) {
+      return NextResponse.json(
+        { error: 'cash_flows need at least one contribution (negative) and one distribution (positive)' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'metrics_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ action: 'irr', cash_flows, inflation_adjusted, cpi_series })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `IRR calculation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse IRR result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
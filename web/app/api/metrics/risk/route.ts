@@ -0,0 +1,174 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const METHODS = ['geltner', 'getmansky']
+const MAX_SIMULATED = 100_000
+
+// Name -> equal-length arrays of finite numbers, at least two names
+const isSeriesMap = (value: unknown, length?: number): boolean => {
+  if (typeof value !== 'object' || value === null || Array.isArray(value)) return false
+  const series = Object.values(value)
+  const n = length ?? (Array.isArray(series[0]) ? series[0].length : -1)
+  return series.length >= 2 && series.every((s) =>
+    Array.isArray(s) && s.length === n && s.every((r) => typeof r === 'number' && Number.isFinite(r))
+  )
+}
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      returns,
+      benchmark,
+      frequency = 4,
+      risk_free_rate,
+      confidence_level = 0.95,
+      desmooth = false,
+      method = 'getmansky',
+      lags = 2,
+      inflation_adjusted = false,
+      dates,
+      cpi_series,
+      components,
+      simulated_components,
+      tail_quantile = 0.1
+    } = body
+
+    // Validate inputs
+    if (!Array.isArray(returns) || returns.length < 8 || returns.length > 5000) {
+      return NextResponse.json(
+        { error: 'returns must be an array of 8 to 5000 observations' },
+        { status: 400 }
+      )
+    }
+
+    if (!returns.every((r) => typeof r === 'number' && Number.isFinite(r))) {
+      return NextResponse.json(
+        { error: 'returns must contain only finite numbers' },
+        { status: 400 }
+      )
+    }
+
+    if (benchmark !== undefined && (!Array.isArray(benchmark) || benchmark.length !== returns.length)) {
+      return NextResponse.json(
+        { error: 'benchmark must be an array the same length as returns' },
+        { status: 400 }
+      )
+    }
+
+    if (confidence_level <= 0 || confidence_level >= 1) {
+      return NextResponse.json(
+        { error: 'confidence_level must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof desmooth !== 'boolean') {
+      return NextResponse.json(
+        { error: 'desmooth must be a boolean' },
+        { status: 400 }
+      )
+    }
+
+    if (!METHODS.includes(method)) {
+      return NextResponse.json(
+        { error: `method must be one of: ${METHODS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (inflation_adjusted && (!Array.isArray(dates) || dates.length !== returns.length + 1)) {
+      return NextResponse.json(
+        { error: 'inflation_adjusted requires dates: period boundaries, one more than returns' },
+        { status: 400 }
+      )
+    }
+
+    if (components !== undefined && !isSeriesMap(components, returns.length)) {
+      return NextResponse.json(
+        { error: 'components must map at least two names to return arrays the same length as returns' },
+        { status: 400 }
+      )
+    }
+
+    if (simulated_components !== undefined &&
+        (!isSeriesMap(simulated_components) ||
+         (Object.values(simulated_components)[0] as number[]).length > MAX_SIMULATED)) {
+      return NextResponse.json(
+        { error: `simulated_components must map at least two names to equal-length arrays of at most ${MAX_SIMULATED} outcomes` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof tail_quantile !== 'number' || tail_quantile <= 0 || tail_quantile > 0.5) {
+      return NextResponse.json(
+        { error: 'tail_quantile must be in (0, 0.5]' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'metrics_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      action: 'risk',
+      returns,
+      benchmark,
+      frequency,
+      risk_free_rate,
+      confidence_level,
+      desmooth,
+      method,
+      lags,
+      inflation_adjusted,
+      dates,
+      cpi_series,
+      components,
+      simulated_components,
+      tail_quantile
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Risk metrics calculation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse risk metrics result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,130 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const GROUPINGS = ['sector', 'vintage', 'sector_vintage']
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { funds, group_id, portfolio_id, group_by = 'sector', strength = 1.0, frequency = 4, min_group_size = 3 } = body
+
+    // Validate inputs (funds are optional; stored active funds are used without them)
+    if (funds !== undefined) {
+      if (!Array.isArray(funds) || funds.length < 2 || funds.length > 5000) {
+        return NextResponse.json(
+          { error: 'funds must be an array of 2 to 5000 entries' },
+          { status: 400 }
+        )
+      }
+
+      const invalid = funds.find((f) =>
+        typeof f?.name !== 'string' ||
+        typeof f?.sector !== 'string' ||
+        !Number.isInteger(f?.vintage) ||
+        typeof f?.irr !== 'number' ||
+        !Number.isFinite(f.irr) ||
+        typeof f?.volatility !== 'number' ||
+        f.volatility < 0 ||
+        !Number.isInteger(f?.n_observations) ||
+        f.n_observations < 2
+      )
+      if (invalid) {
+        return NextResponse.json(
+          { error: 'each fund needs a name, sector, integer vintage, irr, non-negative volatility and n_observations >= 2' },
+          { status: 400 }
+        )
+      }
+    }
+
+    // Without funds, group_id and portfolio_id limit the stored funds to a portfolio group's subtree or a portfolio
+    if (group_id !== undefined && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (portfolio_id !== undefined && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (!GROUPINGS.includes(group_by)) {
+      return NextResponse.json(
+        { error: `group_by must be one of: ${GROUPINGS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof strength !== 'number' || strength < 0 || strength > 1) {
+      return NextResponse.json(
+        { error: 'strength must be between 0 (raw) and 1 (full shrinkage)' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(frequency) || frequency < 1 || frequency > 365) {
+      return NextResponse.json(
+        { error: 'frequency must be an integer between 1 and 365' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(min_group_size) || min_group_size < 2) {
+      return NextResponse.json(
+        { error: 'min_group_size must be an integer of at least 2' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'metrics_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ action: 'shrinkage', funds, group_id, portfolio_id, group_by, strength, frequency, min_group_size })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Shrinkage estimation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse shrinkage result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,119 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const ACTIONS = ['desmooth', 'interpolate']
+const METHODS = ['geltner', 'getmansky']
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { action = 'desmooth', returns, navs, method, lags = 2, steps = 3, frequency = 4 } = body
+
+    // Validate inputs
+    if (!ACTIONS.includes(action)) {
+      return NextResponse.json(
+        { error: `action must be one of: ${ACTIONS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (action === 'desmooth') {
+      if (!Array.isArray(returns) || returns.length < 8 || returns.length > 5000) {
+        return NextResponse.json(
+          { error: 'returns must be an array of 8 to 5000 observations' },
+          { status: 400 }
+        )
+      }
+
+      if (!returns.every((r) => typeof r === 'number' && Number.isFinite(r))) {
+        return NextResponse.json(
+          { error: 'returns must contain only finite numbers' },
+          { status: 400 }
+        )
+      }
+
+      if (method !== undefined && !METHODS.includes(method)) {
+        return NextResponse.json(
+          { error: `method must be one of: ${METHODS.join(', ')}` },
+          { status: 400 }
+        )
+      }
+
+      if (!Number.isInteger(lags) || lags < 1 || lags > 6) {
+        return NextResponse.json(
+          { error: 'lags must be an integer between 1 and 6' },
+          { status: 400 }
+        )
+      }
+    } else {
+      if (!Array.isArray(navs) || navs.length < 2 || navs.length > 5000) {
+        return NextResponse.json(
+          { error: 'navs must be an array of 2 to 5000 values' },
+          { status: 400 }
+        )
+      }
+
+      if (method !== undefined && !['geometric', 'linear'].includes(method)) {
+        return NextResponse.json(
+          { error: 'method must be geometric or linear' },
+          { status: 400 }
+        )
+      }
+
+      if (!Number.isInteger(steps) || steps < 1 || steps > 31) {
+        return NextResponse.json(
+          { error: 'steps must be an integer between 1 and 31' },
+          { status: 400 }
+        )
+      }
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'metrics_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ action, returns, navs, method, lags, steps, frequency })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Metrics calculation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse metrics result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,118 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MAX_SCENARIOS = 100_000
+const MAX_HOLDINGS = 200
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { scenarios, positions, confidence_level = 0.95, bandwidth } = body
+
+    // Validate inputs
+    if (typeof positions !== 'object' || positions === null || Array.isArray(positions) ||
+        Object.keys(positions).length === 0 || Object.keys(positions).length > MAX_HOLDINGS ||
+        !Object.values(positions).every((v) => typeof v === 'number' && Number.isFinite(v))) {
+      return NextResponse.json(
+        { error: `positions must map 1 to ${MAX_HOLDINGS} holding names to numeric position values` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof scenarios !== 'object' || scenarios === null || Array.isArray(scenarios)) {
+      return NextResponse.json(
+        { error: 'scenarios must map holding names to arrays of simulated returns' },
+        { status: 400 }
+      )
+    }
+
+    const names = Object.keys(positions)
+    const missing = names.filter((name) => !Array.isArray(scenarios[name]))
+    if (missing.length > 0) {
+      return NextResponse.json(
+        { error: `No scenarios for holdings: ${missing.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    const nScenarios = scenarios[names[0]].length
+    if (nScenarios < 100 || nScenarios > MAX_SCENARIOS ||
+        !names.every((name) =>
+          scenarios[name].length === nScenarios &&
+          scenarios[name].every((r: unknown) => typeof r === 'number' && Number.isFinite(r))
+        )) {
+      return NextResponse.json(
+        { error: `every holding needs the same number (100 to ${MAX_SCENARIOS}) of finite simulated returns` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof confidence_level !== 'number' || confidence_level <= 0 || confidence_level >= 1) {
+      return NextResponse.json(
+        { error: 'confidence_level must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    if (bandwidth !== undefined && (typeof bandwidth !== 'number' || bandwidth <= 0)) {
+      return NextResponse.json(
+        { error: 'bandwidth must be a positive number' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'metrics_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      action: 'var_contributions',
+      scenarios: Object.fromEntries(names.map((name) => [name, scenarios[name]])),
+      positions,
+      confidence_level,
+      bandwidth
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `VaR decomposition failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse VaR decomposition result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
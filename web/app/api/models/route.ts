@@ -0,0 +1,180 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MODEL_TYPES = ['normal', 'lognormal', 'student_t', 'garch', 'regime_switching', 'factor_loadings']
+
+function runModelsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'models_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Model registry request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse model registry result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+const isDate = (value: unknown): boolean =>
+  typeof value === 'string' && /^\d{4}-\d{2}-\d{2}$/.test(value) && !Number.isNaN(Date.parse(value))
+
+const isOptionalString = (value: unknown): boolean =>
+  value === undefined || value === null || typeof value === 'string'
+
+// List models, list the versions of one model, or fetch one model
+export async function GET(request: NextRequest) {
+  try {
+    const searchParams = request.nextUrl.searchParams
+    const idParam = searchParams.get('id')
+    const name = searchParams.get('name')
+    const versionParam = searchParams.get('version')
+    const modelType = searchParams.get('type') ?? undefined
+
+    if (idParam !== null) {
+      const model_id = Number(idParam)
+      if (!Number.isInteger(model_id) || model_id <= 0) {
+        return NextResponse.json(
+          { error: 'id must be a positive integer' },
+          { status: 400 }
+        )
+      }
+      return runModelsScript({ action: 'get', model_id })
+    }
+
+    if (name === null) {
+      if (modelType !== undefined && !MODEL_TYPES.includes(modelType)) {
+        return NextResponse.json(
+          { error: `type must be one of: ${MODEL_TYPES.join(', ')}` },
+          { status: 400 }
+        )
+      }
+      return runModelsScript({ action: 'list', model_type: modelType })
+    }
+
+    if (versionParam === 'all') {
+      return runModelsScript({ action: 'versions', model_name: name })
+    }
+
+    const version = versionParam === null ? undefined : Number(versionParam)
+    if (version !== undefined && (!Number.isInteger(version) || version <= 0)) {
+      return NextResponse.json(
+        { error: "version must be a positive integer or 'all'" },
+        { status: 400 }
+      )
+    }
+
+    return runModelsScript({ action: 'get', model_name: name, version })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Register calibrated parameters as the next version of a model
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      model_name, model_type, parameters, fit_statistics,
+      data_source, data_start, data_end, n_observations, frequency, author, notes
+    } = body
+
+    // Validate inputs
+    if (typeof model_name !== 'string' || model_name.trim().length === 0 || model_name.length > 100) {
+      return NextResponse.json(
+        { error: 'model_name must be a non-empty string of at most 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    if (!MODEL_TYPES.includes(model_type)) {
+      return NextResponse.json(
+        { error: `model_type must be one of: ${MODEL_TYPES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof parameters !== 'object' || parameters === null || Array.isArray(parameters)) {
+      return NextResponse.json(
+        { error: 'parameters must be an object' },
+        { status: 400 }
+      )
+    }
+
+    if (fit_statistics !== undefined && (typeof fit_statistics !== 'object' || fit_statistics === null)) {
+      return NextResponse.json(
+        { error: 'fit_statistics must be an object' },
+        { status: 400 }
+      )
+    }
+
+    for (const [name, value] of Object.entries({ data_start, data_end })) {
+      if (value !== undefined && !isDate(value)) {
+        return NextResponse.json(
+          { error: `${name} must be a YYYY-MM-DD date` },
+          { status: 400 }
+        )
+      }
+    }
+
+    for (const [name, value] of Object.entries({ n_observations, frequency })) {
+      if (value !== undefined && (!Number.isInteger(value) || value <= 0)) {
+        return NextResponse.json(
+          { error: `${name} must be a positive integer` },
+          { status: 400 }
+        )
+      }
+    }
+
+    if (!isOptionalString(data_source) || !isOptionalString(author) || !isOptionalString(notes)) {
+      return NextResponse.json(
+        { error: 'data_source, author and notes must be strings' },
+        { status: 400 }
+      )
+    }
+
+    return runModelsScript({
+      action: 'register',
+      model_name, model_type, parameters, fit_statistics,
+      data_source, data_start, data_end, n_observations, frequency, author, notes
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
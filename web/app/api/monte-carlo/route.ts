@@ -2,19 +2,126 @@ import { NextRequest, NextResponse } from 'next/server'
 import { spawn } from 'child_process'
 import path from 'path'
 
+const VARIANCE_REDUCTION = ['none', 'antithetic', 'control', 'sobol']
+const MAX_PATHS = 10_000_000
+
+const isFiniteNumber = (value: unknown): value is number =>
+  typeof value === 'number' && Number.isFinite(value)
+
 export async function POST(request: NextRequest) {
   try {
     const body = await request.json()
     const {
-      S, K, T, r, sigma, option_type, q = 0.0,
+      S, K, T, r, sigma, option_type = 'call', q = 0.0,
       n_paths = 100000,
-      variance_reduction = 'antithetic'
+      variance_reduction = 'antithetic',
+      precision = 'float64',
+      bypass_cache = false,
+      cache_ttl,
+      warm_start_from,
+      model_id
     } = body
 
     // Validate inputs
-    if (!S || !K || !T || r === undefined || !sigma) {
+    if (model_id !== undefined && (!Number.isInteger(model_id) || model_id <= 0)) {
+      return NextResponse.json(
+        { error: 'model_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (model_id !== undefined && sigma !== undefined) {
+      return NextResponse.json(
+        { error: 'Give either sigma or model_id, not both' },
+        { status: 400 }
+      )
+    }
+
+    const required = model_id === undefined ? ['S', 'K', 'T', 'r', 'sigma'] : ['S', 'K', 'T', 'r']
+    const missing = required.filter((name) => body[name] === undefined || body[name] === null)
+    if (missing.length > 0) {
+      return NextResponse.json(
+        { error: `Missing required parameters: ${missing.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    const positive = model_id === undefined ? { S, K, T, sigma } : { S, K, T }
+    for (const [name, value] of Object.entries(positive)) {
+      if (!isFiniteNumber(value) || value <= 0) {
+        return NextResponse.json(
+          { error: `${name} must be a positive finite number` },
+          { status: 400 }
+        )
+      }
+    }
+
+    if (!isFiniteNumber(r)) {
+      return NextResponse.json(
+        { error: 'r must be a finite number' },
+        { status: 400 }
+      )
+    }
+
+    if (!isFiniteNumber(q) || q < 0) {
+      return NextResponse.json(
+        { error: 'q must be a non-negative finite number' },
+        { status: 400 }
+      )
+    }
+
+    if (!['call', 'put'].includes(option_type)) {
+      return NextResponse.json(
+        { error: 'option_type must be call or put' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n_paths) || n_paths < 1000 || n_paths > MAX_PATHS) {
+      return NextResponse.json(
+        { error: `n_paths must be an integer between 1000 and ${MAX_PATHS}` },
+        { status: 400 }
+      )
+    }
+
+    if (!VARIANCE_REDUCTION.includes(variance_reduction)) {
+      return NextResponse.json(
+        { error: `variance_reduction must be one of: ${VARIANCE_REDUCTION.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof bypass_cache !== 'boolean') {
+      return NextResponse.json(
+        { error: 'bypass_cache must be a boolean' },
+        { status: 400 }
+      )
+    }
+
+    if (cache_ttl !== undefined && (!isFiniteNumber(cache_ttl) || cache_ttl < 0)) {
+      return NextResponse.json(
+        { error: 'cache_ttl must be a non-negative number of seconds' },
+        { status: 400 }
+      )
+    }
+
+    if (warm_start_from !== undefined && (typeof warm_start_from !== 'string' || !/^[0-9a-f]{32}$/.test(warm_start_from))) {
+      return NextResponse.json(
+        { error: 'warm_start_from must be a run_id returned by an earlier simulation' },
+        { status: 400 }
+      )
+    }
+
+    if (warm_start_from !== undefined && variance_reduction === 'sobol') {
+      return NextResponse.json(
+        { error: 'warm_start_from is not supported with sobol variance reduction' },
+        { status: 400 }
+      )
+    }
+
+    if (!['float64', 'float32'].includes(precision)) {
       return NextResponse.json(
-        { error: 'Missing required parameters' },
+        { error: 'precision must be float64 or float32' },
         { status: 400 }
       )
     }
@@ -24,7 +131,8 @@ export async function POST(request: NextRequest) {
 
     const params = JSON.stringify({
       S, K, T, r, sigma, option_type, q,
-      n_paths, variance_reduction
+      n_paths, variance_reduction, precision,
+      bypass_cache, cache_ttl, warm_start_from, model_id
     })
 
     return new Promise((resolve) => {
@@ -0,0 +1,188 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const QUARTER_END = /^\d{4}-(03-31|06-30|09-30|12-31)$/
+const FLOW_TYPES = ['Capital Call', 'Distribution', 'Dividend', 'Interest', 'Fee', 'Other']
+const OPERATIONS = ['insert', 'update', 'delete']
+
+const isId = (value: unknown): value is number =>
+  Number.isInteger(value) && (value as number) > 0
+
+const isDate = (value: unknown): boolean =>
+  typeof value === 'string' && /^\d{4}-\d{2}-\d{2}$/.test(value) && !Number.isNaN(Date.parse(value))
+
+const isText = (value: unknown, max: number): value is string =>
+  typeof value === 'string' && value.trim().length > 0 && value.length <= max
+
+// Cash flow fields given for an insert or update (null when malformed)
+function cashFlowValues(values: unknown, operation: string): Record<string, unknown> | null {
+  if (typeof values !== 'object' || values === null || Array.isArray(values)) {
+    return null
+  }
+  const v = values as Record<string, unknown>
+  const known = ['fund_id', 'flow_date', 'flow_type', 'amount', 'description']
+  if (Object.keys(v).length === 0 || Object.keys(v).some((key) => !known.includes(key))) {
+    return null
+  }
+  if (operation === 'insert' && ['fund_id', 'flow_date', 'flow_type', 'amount'].some((key) => v[key] === undefined)) {
+    return null
+  }
+  if ((v.fund_id !== undefined && !isId(v.fund_id)) ||
+      (v.flow_date !== undefined && !isDate(v.flow_date)) ||
+      (v.flow_type !== undefined && !FLOW_TYPES.includes(v.flow_type as string)) ||
+      (v.amount !== undefined && (typeof v.amount !== 'number' || !Number.isFinite(v.amount))) ||
+      (v.description !== undefined && v.description !== null && !isText(v.description, 1000))) {
+    return null
+  }
+  return v
+}
+
+function runPeriodsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'periods_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Reporting period request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse reporting period result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Audit trail of closed period adjustments (?period_end=, ?fund_id= to filter)
+export async function GET(request: NextRequest) {
+  try {
+    const period_end = request.nextUrl.searchParams.get('period_end')
+    const fundParam = request.nextUrl.searchParams.get('fund_id')
+    const fund_id = fundParam === null ? null : Number(fundParam)
+
+    // Validate inputs
+    if (period_end !== null && !QUARTER_END.test(period_end)) {
+      return NextResponse.json(
+        { error: 'period_end must be a quarter end date' },
+        { status: 400 }
+      )
+    }
+
+    if (fund_id !== null && !isId(fund_id)) {
+      return NextResponse.json(
+        { error: 'fund_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runPeriodsScript({ action: 'adjustments', period_end, fund_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Adjust a closed period: insert, update or delete a locked cash flow, or update a reported NAV
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { target, operation, cash_flow_id, fund_id, period_end, values, reason, adjusted_by } = body
+
+    // Validate inputs
+    if (!OPERATIONS.includes(operation)) {
+      return NextResponse.json(
+        { error: `operation must be one of: ${OPERATIONS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (!isText(reason, 1000) || !isText(adjusted_by, 100)) {
+      return NextResponse.json(
+        { error: 'reason (up to 1000 characters) and adjusted_by (up to 100) are required' },
+        { status: 400 }
+      )
+    }
+
+    if (target === 'cash_flow') {
+      if (operation !== 'insert' && !isId(cash_flow_id)) {
+        return NextResponse.json(
+          { error: 'cash_flow_id must be a positive integer' },
+          { status: 400 }
+        )
+      }
+
+      if (operation !== 'delete' && cashFlowValues(values, operation) === null) {
+        return NextResponse.json(
+          { error: `values must set fund_id, flow_date (YYYY-MM-DD), flow_type (${FLOW_TYPES.join(', ')}), amount and optional description; an insert needs all but description` },
+          { status: 400 }
+        )
+      }
+
+      return runPeriodsScript({ action: 'adjust', target, operation, cash_flow_id, values, reason, adjusted_by })
+    }
+
+    if (target === 'nav') {
+      if (operation !== 'update') {
+        return NextResponse.json(
+          { error: 'reported NAVs can only be updated' },
+          { status: 400 }
+        )
+      }
+
+      if (!isId(fund_id) || typeof period_end !== 'string' || !QUARTER_END.test(period_end)) {
+        return NextResponse.json(
+          { error: 'fund_id must be a positive integer and period_end a quarter end date' },
+          { status: 400 }
+        )
+      }
+
+      if (typeof values?.nav !== 'number' || !Number.isFinite(values.nav)) {
+        return NextResponse.json(
+          { error: 'values.nav must be a number' },
+          { status: 400 }
+        )
+      }
+
+      return runPeriodsScript({ action: 'adjust', target, operation, fund_id, period_end, values: { nav: values.nav }, reason, adjusted_by })
+    }
+
+    return NextResponse.json(
+      { error: 'target must be cash_flow or nav' },
+      { status: 400 }
+    )
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
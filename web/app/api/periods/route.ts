@@ -0,0 +1,92 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const QUARTER_END = /^\d{4}-(03-31|06-30|09-30|12-31)$/
+
+const isName = (value: unknown): value is string =>
+  typeof value === 'string' && value.trim().length > 0 && value.length <= 100
+
+function runPeriodsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'periods_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Reporting period request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse reporting period result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Closed reporting periods, latest first
+export async function GET() {
+  try {
+    return runPeriodsScript({ action: 'periods' })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Close a quarter: lock its cash flows and record every fund's NAV as reported
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { period_end, closed_by } = body
+
+    // Validate inputs
+    if (typeof period_end !== 'string' || !QUARTER_END.test(period_end)) {
+      return NextResponse.json(
+        { error: 'period_end must be a quarter end date (YYYY-03-31, -06-30, -09-30 or -12-31)' },
+        { status: 400 }
+      )
+    }
+
+    if (!isName(closed_by)) {
+      return NextResponse.json(
+        { error: 'closed_by must be 1 to 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    return runPeriodsScript({ action: 'close', period_end, closed_by })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -7,17 +7,43 @@ export async function POST(request: NextRequest) {
     const body = await request.json()
     const {
       n_assets = 10,
-      risk_free_rate = 0.02,
-      method = 'all'
+      risk_free_rate,
+      method = 'all',
+      illiquid_assets,
+      max_illiquid_weight,
+      locked_assets,
+      current_weights,
+      unfunded_commitments,
+      min_call_coverage,
+      n_simulations,
+      n_points,
+      assumption_set_id
     } = body
 
+    // Validate inputs
+    if (assumption_set_id !== undefined && (!Number.isInteger(assumption_set_id) || assumption_set_id <= 0)) {
+      return NextResponse.json(
+        { error: 'assumption_set_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
     const scriptPath = path.join(process.cwd(), '..', 'scripts', 'portfolio_optimize_api.py')
     const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
 
     const params = JSON.stringify({
       n_assets,
       risk_free_rate,
-      method
+      method,
+      illiquid_assets,
+      max_illiquid_weight,
+      locked_assets,
+      current_weights,
+      unfunded_commitments,
+      min_call_coverage,
+      n_simulations,
+      n_points,
+      assumption_set_id
     })
 
     return new Promise((resolve) => {
@@ -0,0 +1,231 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MAX_FUNDS = 500
+
+const isId = (value: unknown): value is number =>
+  Number.isInteger(value) && (value as number) > 0
+
+const isName = (value: unknown): value is string =>
+  typeof value === 'string' && value.trim().length > 0 && value.length <= 100
+
+const isMandate = (value: unknown) =>
+  value === null || (typeof value === 'string' && value.trim().length > 0 && value.length <= 100)
+
+const isDescription = (value: unknown) =>
+  value === null || (typeof value === 'string' && value.length <= 1000)
+
+function runPortfoliosScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'portfolios_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Portfolio request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse portfolio result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Every portfolio with its figures (?id=N for one portfolio and its funds)
+export async function GET(request: NextRequest) {
+  try {
+    const idParam = request.nextUrl.searchParams.get('id')
+    if (idParam === null) {
+      return runPortfoliosScript({ action: 'list' })
+    }
+
+    const portfolio_id = Number(idParam)
+    if (!isId(portfolio_id)) {
+      return NextResponse.json(
+        { error: 'id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runPortfoliosScript({ action: 'get', portfolio_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Create a portfolio (e.g. a pension or endowment mandate)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { portfolio_name, mandate = null, description = null } = body
+
+    // Validate inputs
+    if (!isName(portfolio_name)) {
+      return NextResponse.json(
+        { error: 'portfolio_name must be 1 to 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    if (!isMandate(mandate)) {
+      return NextResponse.json(
+        { error: 'mandate must be 1 to 100 characters or null' },
+        { status: 400 }
+      )
+    }
+
+    if (!isDescription(description)) {
+      return NextResponse.json(
+        { error: 'description must be a string of up to 1000 characters or null' },
+        { status: 400 }
+      )
+    }
+
+    return runPortfoliosScript({ action: 'create', portfolio_name, mandate, description })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Rename or re-describe a portfolio
+export async function PATCH(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { portfolio_id, portfolio_name, mandate, description } = body
+
+    // Validate inputs
+    if (!isId(portfolio_id)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (portfolio_name === undefined && mandate === undefined && description === undefined) {
+      return NextResponse.json(
+        { error: 'provide portfolio_name, mandate or description to update' },
+        { status: 400 }
+      )
+    }
+
+    if (portfolio_name !== undefined && !isName(portfolio_name)) {
+      return NextResponse.json(
+        { error: 'portfolio_name must be 1 to 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    if (mandate !== undefined && !isMandate(mandate)) {
+      return NextResponse.json(
+        { error: 'mandate must be 1 to 100 characters or null' },
+        { status: 400 }
+      )
+    }
+
+    if (description !== undefined && !isDescription(description)) {
+      return NextResponse.json(
+        { error: 'description must be a string of up to 1000 characters or null' },
+        { status: 400 }
+      )
+    }
+
+    // Undefined fields are dropped by JSON.stringify and left unchanged
+    return runPortfoliosScript({ action: 'update', portfolio_id, portfolio_name, mandate, description })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Delete a portfolio (?id=N); its funds are kept
+export async function DELETE(request: NextRequest) {
+  try {
+    const portfolio_id = Number(request.nextUrl.searchParams.get('id'))
+    if (!isId(portfolio_id)) {
+      return NextResponse.json(
+        { error: 'id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runPortfoliosScript({ action: 'delete', portfolio_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Add funds to a portfolio, or take them out with remove=true
+export async function PUT(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { portfolio_id, fund_ids, remove = false } = body
+
+    // Validate inputs
+    if (!isId(portfolio_id)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(fund_ids) || fund_ids.length === 0 || fund_ids.length > MAX_FUNDS ||
+        !fund_ids.every(isId) || new Set(fund_ids).size !== fund_ids.length) {
+      return NextResponse.json(
+        { error: `fund_ids must be 1 to ${MAX_FUNDS} distinct positive integers` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof remove !== 'boolean') {
+      return NextResponse.json(
+        { error: 'remove must be a boolean' },
+        { status: 400 }
+      )
+    }
+
+    return runPortfoliosScript({ action: remove ? 'remove' : 'add', portfolio_id, fund_ids })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
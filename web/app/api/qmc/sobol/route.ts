@@ -0,0 +1,138 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MAX_DIMENSION = 21201
+const MAX_VALUES = 50_000_000
+const TRANSFORMS = ['uniform', 'normal']
+
+const parseInteger = (value: string | null, fallback: number): number =>
+  value === null ? fallback : Number(value)
+
+// Stream Owen-scrambled Sobol points as newline-delimited JSON
+export async function GET(request: NextRequest) {
+  try {
+    const query = request.nextUrl.searchParams
+    const dimension = parseInteger(query.get('dimension'), NaN)
+    const n = parseInteger(query.get('n'), 1024)
+    const skip = parseInteger(query.get('skip'), 0)
+    const seed = parseInteger(query.get('seed'), 0)
+    const scramble = query.get('scramble') ?? 'owen'
+    const transform = query.get('transform') ?? 'uniform'
+
+    // Validate inputs
+    if (!Number.isInteger(dimension) || dimension < 1 || dimension > MAX_DIMENSION) {
+      return NextResponse.json(
+        { error: `dimension must be an integer between 1 and ${MAX_DIMENSION}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n) || n < 1 || n * dimension > MAX_VALUES) {
+      return NextResponse.json(
+        { error: `n must be a positive integer with n × dimension ≤ ${MAX_VALUES}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(skip) || skip < 0) {
+      return NextResponse.json(
+        { error: 'skip must be a non-negative integer' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(seed) || seed < 0) {
+      return NextResponse.json(
+        { error: 'seed must be a non-negative integer' },
+        { status: 400 }
+      )
+    }
+
+    if (scramble !== 'owen' && scramble !== 'none') {
+      return NextResponse.json(
+        { error: 'scramble must be owen or none' },
+        { status: 400 }
+      )
+    }
+
+    if (!TRANSFORMS.includes(transform)) {
+      return NextResponse.json(
+        { error: `transform must be one of: ${TRANSFORMS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'sobol_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      dimension, n, skip, seed,
+      scramble: scramble === 'owen',
+      transform
+    })
+
+    // Respond once the first chunk arrives so startup failures still
+    // return a JSON error instead of a truncated stream
+    return new Promise<Response>((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let errorData = ''
+      let started = false
+      let controller: ReadableStreamDefaultController<Uint8Array>
+
+      const stream = new ReadableStream<Uint8Array>({
+        start(c) {
+          controller = c
+        },
+        cancel() {
+          pythonProcess.kill()
+        }
+      })
+
+      pythonProcess.stdout.on('data', (data: Buffer) => {
+        controller.enqueue(new Uint8Array(data))
+        if (!started) {
+          started = true
+          resolve(
+            new Response(stream, {
+              headers: { 'Content-Type': 'application/x-ndjson' }
+            })
+          )
+        }
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          if (started) {
+            controller.error(new Error(`Sobol generation failed: ${errorData}`))
+          } else {
+            resolve(
+              NextResponse.json(
+                { error: `Sobol generation failed: ${errorData}` },
+                { status: 500 }
+              )
+            )
+          }
+        } else if (!started) {
+          resolve(
+            NextResponse.json(
+              { error: 'Sobol generation produced no output' },
+              { status: 500 }
+            )
+          )
+        } else {
+          controller.close()
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,88 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+function runCpiScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'cpi_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `CPI request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse CPI result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Stored CPI series (?series_name, default CPI-U)
+export async function GET(request: NextRequest) {
+  try {
+    const series_name = request.nextUrl.searchParams.get('series_name') ?? undefined
+    return runCpiScript({ action: 'get', series_name })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Upload CPI index levels
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { series_name, observations } = body
+
+    // Validate inputs
+    if (!Array.isArray(observations) || observations.length === 0 || observations.length > 5000) {
+      return NextResponse.json(
+        { error: 'observations must be an array of 1 to 5000 { date, level } entries' },
+        { status: 400 }
+      )
+    }
+
+    if (!observations.every((o) => typeof o?.date === 'string' && !Number.isNaN(Date.parse(o.date)) && typeof o?.level === 'number' && o.level > 0)) {
+      return NextResponse.json(
+        { error: 'each observation needs a date (YYYY-MM-DD) and a positive level' },
+        { status: 400 }
+      )
+    }
+
+    return runCpiScript({ action: 'upload', series_name, observations })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,108 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+function runCurveScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'yield_curve_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Yield curve request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse yield curve result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Latest stored curve on or before ?as_of, optionally interpolated at ?tenors=0.5,2,7
+export async function GET(request: NextRequest) {
+  try {
+    const searchParams = request.nextUrl.searchParams
+    const curve_name = searchParams.get('curve_name') ?? undefined
+    const as_of = searchParams.get('as_of') ?? undefined
+    const tenors = searchParams.get('tenors')?.split(',').map(Number)
+
+    if (tenors && !tenors.every((t) => Number.isFinite(t) && t >= 0)) {
+      return NextResponse.json(
+        { error: 'tenors must be a comma-separated list of non-negative numbers' },
+        { status: 400 }
+      )
+    }
+
+    return runCurveScript({ action: 'get', curve_name, as_of, tenors })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Upload a dated zero curve
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { curve_name, curve_date, tenors, rates } = body
+
+    // Validate inputs
+    if (typeof curve_date !== 'string' || Number.isNaN(Date.parse(curve_date))) {
+      return NextResponse.json(
+        { error: 'curve_date must be a date (YYYY-MM-DD)' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(tenors) || tenors.length === 0 || tenors.length > 100 ||
+        !tenors.every((t) => typeof t === 'number' && t > 0)) {
+      return NextResponse.json(
+        { error: 'tenors must be an array of 1 to 100 positive numbers (years)' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(rates) || rates.length !== tenors.length ||
+        !rates.every((r) => typeof r === 'number' && Number.isFinite(r))) {
+      return NextResponse.json(
+        { error: 'rates must be an array of numbers, one per tenor' },
+        { status: 400 }
+      )
+    }
+
+    return runCurveScript({ action: 'upload', curve_name, curve_date, tenors, rates })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
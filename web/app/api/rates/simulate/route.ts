@@ -0,0 +1,190 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const QUERY_TYPES = ['prob_below', 'prob_above', 'mean_below', 'mean_above']
+const QUERY_METRICS = [
+  'nominal_total_return', 'real_total_return',
+  'nominal_annualized_return', 'real_annualized_return'
+]
+const MAX_SCENARIOS = 10
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { rate, inflation, asset, correlation, T = 10, n_paths = 10000, n_steps = 12, seed, queries, horizons, scenarios } = body
+
+    // Validate inputs
+    if (rate?.model !== undefined && !['vasicek', 'cir'].includes(rate.model)) {
+      return NextResponse.json(
+        { error: 'rate.model must be vasicek or cir' },
+        { status: 400 }
+      )
+    }
+
+    if (T <= 0 || T > 50) {
+      return NextResponse.json(
+        { error: 'T must be between 0 and 50 years' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n_paths) || n_paths < 100 || n_paths > 100000) {
+      return NextResponse.json(
+        { error: 'n_paths must be an integer between 100 and 100000' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n_steps) || n_steps < 1 || n_steps > 252) {
+      return NextResponse.json(
+        { error: 'n_steps must be an integer between 1 and 252 per year' },
+        { status: 400 }
+      )
+    }
+
+    if (scenarios !== undefined) {
+      if (!Array.isArray(scenarios) || scenarios.length < 2 || scenarios.length > MAX_SCENARIOS) {
+        return NextResponse.json(
+          { error: `scenarios must be an array of 2 to ${MAX_SCENARIOS} configurations` },
+          { status: 400 }
+        )
+      }
+
+      const invalidScenario = scenarios.find((sc) =>
+        typeof sc?.name !== 'string' ||
+        sc.name.trim().length === 0 ||
+        typeof sc?.probability !== 'number' ||
+        sc.probability <= 0 ||
+        sc.probability > 1 ||
+        (sc?.rate?.model !== undefined && !['vasicek', 'cir'].includes(sc.rate.model)) ||
+        ['rate', 'inflation', 'asset'].some((key) => sc?.[key] !== undefined && (typeof sc[key] !== 'object' || sc[key] === null)) ||
+        (sc?.correlation !== undefined &&
+          (!Array.isArray(sc.correlation) || sc.correlation.length !== 3 || !sc.correlation.every((row: unknown) => Array.isArray(row) && row.length === 3)))
+      )
+      if (invalidScenario) {
+        return NextResponse.json(
+          { error: 'each scenario needs a name, a probability in (0, 1] and optional rate, inflation, asset and correlation overrides' },
+          { status: 400 }
+        )
+      }
+
+      if (new Set(scenarios.map((sc: { name: string }) => sc.name)).size !== scenarios.length) {
+        return NextResponse.json(
+          { error: 'scenario names must be unique' },
+          { status: 400 }
+        )
+      }
+
+      const total = scenarios.reduce((sum: number, sc: { probability: number }) => sum + sc.probability, 0)
+      if (Math.abs(total - 1) > 1e-6) {
+        return NextResponse.json(
+          { error: `scenario probabilities must sum to 1 (got ${total.toFixed(4)})` },
+          { status: 400 }
+        )
+      }
+    }
+
+    if (n_paths * T * n_steps * (scenarios?.length ?? 1) > 20_000_000) {
+      return NextResponse.json(
+        { error: 'n_paths × T × n_steps (× scenarios) must not exceed 20,000,000' },
+        { status: 400 }
+      )
+    }
+
+    if (correlation !== undefined &&
+        (!Array.isArray(correlation) || correlation.length !== 3 || !correlation.every((row) => Array.isArray(row) && row.length === 3))) {
+      return NextResponse.json(
+        { error: 'correlation must be a 3x3 matrix (rate, inflation, asset)' },
+        { status: 400 }
+      )
+    }
+
+    if (horizons !== undefined && (
+      !Array.isArray(horizons) ||
+      horizons.length === 0 ||
+      horizons.length > 20 ||
+      !horizons.every((h) => typeof h === 'number' && h > 0 && h <= T && Math.abs(h * n_steps - Math.round(h * n_steps)) < 1e-6)
+    )) {
+      return NextResponse.json(
+        { error: 'horizons must be 1 to 20 positive numbers of years, each at most T and on the simulation step grid' },
+        { status: 400 }
+      )
+    }
+
+    if (queries !== undefined) {
+      const invalidQuery = !Array.isArray(queries) || queries.length > 20 || queries.find((q) =>
+        !QUERY_TYPES.includes(q?.type) ||
+        typeof q?.threshold !== 'number' ||
+        !Number.isFinite(q.threshold) ||
+        (q?.metric !== undefined && !QUERY_METRICS.includes(q.metric)) ||
+        (q?.name !== undefined && typeof q.name !== 'string')
+      )
+      if (invalidQuery) {
+        return NextResponse.json(
+          { error: `queries must be up to 20 entries with a type (${QUERY_TYPES.join(', ')}), a numeric threshold and an optional metric (${QUERY_METRICS.join(', ')})` },
+          { status: 400 }
+        )
+      }
+
+      const hasAsset = (a: { mu?: unknown, sigma?: unknown } | undefined) =>
+        typeof a?.mu === 'number' && typeof a?.sigma === 'number'
+      const assetMissing = scenarios === undefined
+        ? !hasAsset(asset)
+        : scenarios.some((sc: { asset?: object }) => !hasAsset({ ...asset, ...sc.asset }))
+      if (assetMissing) {
+        return NextResponse.json(
+          { error: 'queries need an asset with mu and sigma' },
+          { status: 400 }
+        )
+      }
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'rates_simulate_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ rate, inflation, asset, correlation, T, n_paths, n_steps, seed, queries, horizons, scenarios })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Rate simulation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse rate simulation result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
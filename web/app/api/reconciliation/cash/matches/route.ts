@@ -0,0 +1,76 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const isDate = (value: unknown): boolean =>
+  typeof value === 'string' && /^\d{4}-\d{2}-\d{2}$/.test(value) && !Number.isNaN(Date.parse(value))
+
+function runReconciliationScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'reconciliation_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Cash reconciliation failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse cash reconciliation result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+
+// List matched bank transactions and cash flows (?start_date= and ?end_date= to limit the period)
+export async function GET(request: NextRequest) {
+  try {
+    const start_date = request.nextUrl.searchParams.get('start_date')
+    const end_date = request.nextUrl.searchParams.get('end_date')
+
+    if ((start_date !== null && !isDate(start_date)) || (end_date !== null && !isDate(end_date))) {
+      return NextResponse.json(
+        { error: 'start_date and end_date must be YYYY-MM-DD dates' },
+        { status: 400 }
+      )
+    }
+
+    if (start_date !== null && end_date !== null && start_date > end_date) {
+      return NextResponse.json(
+        { error: 'start_date must not be after end_date' },
+        { status: 400 }
+      )
+    }
+
+    return runReconciliationScript({ action: 'matches', start_date, end_date })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,180 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const STATUSES = ['Open', 'Resolved', 'Ignored']
+const MAX_BANK_ITEMS = 10000
+
+function runReconciliationScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'reconciliation_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Cash reconciliation failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse cash reconciliation result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// List reconciliation breaks by status
+export async function GET(request: NextRequest) {
+  try {
+    const status = request.nextUrl.searchParams.get('status') ?? 'Open'
+    if (!STATUSES.includes(status)) {
+      return NextResponse.json(
+        { error: `status must be one of: ${STATUSES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+    return runReconciliationScript({ action: 'breaks', status })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Reconcile bank/custodian transactions against recorded cash flows
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      bank_items,
+      amount_tolerance = 1.0,
+      date_window_days = 5,
+      min_reference_similarity = 0.6
+    } = body
+
+    // Validate inputs
+    if (!Array.isArray(bank_items) || bank_items.length === 0 || bank_items.length > MAX_BANK_ITEMS) {
+      return NextResponse.json(
+        { error: `bank_items must be an array of 1 to ${MAX_BANK_ITEMS} transactions` },
+        { status: 400 }
+      )
+    }
+
+    const invalid = bank_items.find((item) =>
+      (typeof item?.id !== 'string' && typeof item?.id !== 'number') ||
+      typeof item?.date !== 'string' ||
+      Number.isNaN(Date.parse(item.date)) ||
+      typeof item?.amount !== 'number' ||
+      !Number.isFinite(item.amount)
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: 'each bank item needs an id, a date (YYYY-MM-DD) and a signed amount' },
+        { status: 400 }
+      )
+    }
+
+    if (new Set(bank_items.map((item: { id: string | number }) => String(item.id))).size !== bank_items.length) {
+      return NextResponse.json(
+        { error: 'bank item ids must be unique' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof amount_tolerance !== 'number' || amount_tolerance < 0) {
+      return NextResponse.json(
+        { error: 'amount_tolerance must be a non-negative number' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(date_window_days) || date_window_days < 0 || date_window_days > 31) {
+      return NextResponse.json(
+        { error: 'date_window_days must be an integer between 0 and 31' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof min_reference_similarity !== 'number' || min_reference_similarity < 0 || min_reference_similarity > 1) {
+      return NextResponse.json(
+        { error: 'min_reference_similarity must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    return runReconciliationScript({
+      action: 'reconcile',
+      bank_items,
+      amount_tolerance,
+      date_window_days,
+      min_reference_similarity
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Resolve or ignore an open break
+export async function PATCH(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { break_id, status, resolution, resolved_by } = body
+
+    // Validate inputs
+    if (!Number.isInteger(break_id) || break_id <= 0) {
+      return NextResponse.json(
+        { error: 'break_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (status !== 'Resolved' && status !== 'Ignored') {
+      return NextResponse.json(
+        { error: 'status must be Resolved or Ignored' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof resolution !== 'string' || resolution.trim().length === 0) {
+      return NextResponse.json(
+        { error: 'resolution is required' },
+        { status: 400 }
+      )
+    }
+
+    return runReconciliationScript({ action: 'resolve', break_id, status, resolution, resolved_by })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,192 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const ALLOCATIONS = ['proportional', 'equal', 'neyman']
+const QUERY_TYPES = ['prob_below', 'prob_above', 'mean_below', 'mean_above']
+const QUERY_METRICS = ['total_return', 'annualized_return']
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      buckets,
+      T = 5,
+      initial_value = 1.0,
+      steps_per_year = 1,
+      n_paths = 100000,
+      allocation = 'proportional',
+      min_paths = 1000,
+      seed = 42,
+      queries,
+      horizons
+    } = body
+
+    // Validate inputs
+    if (!Array.isArray(buckets) || buckets.length === 0 || buckets.length > 20) {
+      return NextResponse.json(
+        { error: 'buckets must be an array of 1 to 20 scenarios' },
+        { status: 400 }
+      )
+    }
+
+    const invalid = buckets.find((b) =>
+      typeof b?.name !== 'string' ||
+      b.name.trim().length === 0 ||
+      typeof b?.probability !== 'number' ||
+      b.probability <= 0 ||
+      b.probability > 1 ||
+      typeof b?.mu !== 'number' ||
+      typeof b?.sigma !== 'number' ||
+      b.sigma < 0
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: 'each bucket needs a name, a probability in (0, 1], a drift mu and a non-negative sigma' },
+        { status: 400 }
+      )
+    }
+
+    const totalProbability = buckets.reduce((sum: number, b: { probability: number }) => sum + b.probability, 0)
+    if (Math.abs(totalProbability - 1) > 1e-6) {
+      return NextResponse.json(
+        { error: `bucket probabilities must sum to 1 (got ${totalProbability.toFixed(4)})` },
+        { status: 400 }
+      )
+    }
+
+    if (new Set(buckets.map((b: { name: string }) => b.name)).size !== buckets.length) {
+      return NextResponse.json(
+        { error: 'bucket names must be unique' },
+        { status: 400 }
+      )
+    }
+
+    if (!ALLOCATIONS.includes(allocation)) {
+      return NextResponse.json(
+        { error: `allocation must be one of: ${ALLOCATIONS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof T !== 'number' || T <= 0 || T > 50) {
+      return NextResponse.json(
+        { error: 'T must be between 0 and 50 years' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof initial_value !== 'number' || initial_value <= 0) {
+      return NextResponse.json(
+        { error: 'initial_value must be positive' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(steps_per_year) || steps_per_year < 1 || steps_per_year > 252) {
+      return NextResponse.json(
+        { error: 'steps_per_year must be an integer between 1 and 252' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(min_paths) || min_paths < 100) {
+      return NextResponse.json(
+        { error: 'min_paths must be an integer of at least 100' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n_paths) || n_paths < min_paths * buckets.length || n_paths > 1_000_000) {
+      return NextResponse.json(
+        { error: 'n_paths must be an integer between min_paths × buckets and 1,000,000' },
+        { status: 400 }
+      )
+    }
+
+    if (n_paths * T * steps_per_year > 20_000_000) {
+      return NextResponse.json(
+        { error: 'n_paths × T × steps_per_year must not exceed 20,000,000' },
+        { status: 400 }
+      )
+    }
+
+    if (horizons !== undefined && (
+      !Array.isArray(horizons) ||
+      horizons.length === 0 ||
+      horizons.length > 20 ||
+      !horizons.every((h) => typeof h === 'number' && h > 0 && h <= T && Math.abs(h * steps_per_year - Math.round(h * steps_per_year)) < 1e-6)
+    )) {
+      return NextResponse.json(
+        { error: 'horizons must be 1 to 20 positive numbers of years, each at most T and on the simulation step grid' },
+        { status: 400 }
+      )
+    }
+
+    if (queries !== undefined) {
+      const invalidQuery = !Array.isArray(queries) || queries.length > 20 || queries.find((q) =>
+        !QUERY_TYPES.includes(q?.type) ||
+        typeof q?.threshold !== 'number' ||
+        !Number.isFinite(q.threshold) ||
+        (q?.metric !== undefined && !QUERY_METRICS.includes(q.metric)) ||
+        (q?.name !== undefined && typeof q.name !== 'string')
+      )
+      if (invalidQuery) {
+        return NextResponse.json(
+          { error: `queries must be up to 20 entries with a type (${QUERY_TYPES.join(', ')}), a numeric threshold and an optional metric (${QUERY_METRICS.join(', ')})` },
+          { status: 400 }
+        )
+      }
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'stratified_simulate_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      buckets, T, initial_value, steps_per_year,
+      n_paths, allocation, min_paths, seed, queries, horizons
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Stratified simulation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse stratified simulation result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,171 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const SCENARIO_FIELDS = [
+  'call_rate', 'call_acceleration', 'call_volatility', 'call_market_correlation',
+  'distribution_rate', 'distribution_haircut', 'liquid_return', 'liquid_volatility',
+  'liquid_shock', 'private_return', 'private_beta', 'private_volatility', 'spending_rate'
+]
+const MAX_PATHS = 200_000
+
+// Scenario overrides: known fields with finite numeric values
+const isScenario = (value: unknown): boolean =>
+  value === undefined ||
+  (typeof value === 'object' && value !== null && !Array.isArray(value) &&
+    Object.entries(value).every(([key, v]) =>
+      SCENARIO_FIELDS.includes(key) && typeof v === 'number' && Number.isFinite(v)
+    ))
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      liquid_assets,
+      private_nav,
+      unfunded_commitments,
+      quarters = 12,
+      liquidity_floor = 0,
+      max_private_weight,
+      scenario,
+      base_scenario,
+      compare_base = true,
+      n_paths = 10000,
+      seed = 42,
+      assumption_set_id
+    } = body
+
+    // Validate inputs
+    if (typeof liquid_assets !== 'number' || liquid_assets <= 0) {
+      return NextResponse.json(
+        { error: 'liquid_assets must be a positive number' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof private_nav !== 'number' || private_nav < 0 ||
+        typeof unfunded_commitments !== 'number' || unfunded_commitments < 0) {
+      return NextResponse.json(
+        { error: 'private_nav and unfunded_commitments must be non-negative numbers' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(quarters) || quarters < 1 || quarters > 40) {
+      return NextResponse.json(
+        { error: 'quarters must be an integer between 1 and 40' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof liquidity_floor !== 'number' || liquidity_floor < 0) {
+      return NextResponse.json(
+        { error: 'liquidity_floor must be a non-negative number' },
+        { status: 400 }
+      )
+    }
+
+    if (max_private_weight !== undefined &&
+        (typeof max_private_weight !== 'number' || max_private_weight <= 0 || max_private_weight >= 1)) {
+      return NextResponse.json(
+        { error: 'max_private_weight must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    if (!isScenario(scenario) || !isScenario(base_scenario)) {
+      return NextResponse.json(
+        { error: `scenario and base_scenario may only set numeric values for: ${SCENARIO_FIELDS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof compare_base !== 'boolean') {
+      return NextResponse.json(
+        { error: 'compare_base must be a boolean' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n_paths) || n_paths < 1000 || n_paths > MAX_PATHS) {
+      return NextResponse.json(
+        { error: `n_paths must be an integer between 1000 and ${MAX_PATHS}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(seed)) {
+      return NextResponse.json(
+        { error: 'seed must be an integer' },
+        { status: 400 }
+      )
+    }
+
+    if (assumption_set_id !== undefined && (!Number.isInteger(assumption_set_id) || assumption_set_id <= 0)) {
+      return NextResponse.json(
+        { error: 'assumption_set_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'liquidity_stress_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      liquid_assets,
+      private_nav,
+      unfunded_commitments,
+      quarters,
+      liquidity_floor,
+      max_private_weight,
+      scenario,
+      base_scenario,
+      compare_base,
+      n_paths,
+      seed,
+      assumption_set_id
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Liquidity stress test failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse liquidity stress test result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
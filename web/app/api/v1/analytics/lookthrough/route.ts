@@ -0,0 +1,71 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+// Look-through exposures of active funds (?group_id= for one portfolio group's subtree, ?portfolio_id= for one portfolio)
+export async function GET(request: NextRequest) {
+  try {
+    const groupParam = request.nextUrl.searchParams.get('group_id')
+    const group_id = groupParam === null ? null : Number(groupParam)
+    if (group_id !== null && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const portfolioParam = request.nextUrl.searchParams.get('portfolio_id')
+    const portfolio_id = portfolioParam === null ? null : Number(portfolioParam)
+    if (portfolio_id !== null && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'lookthrough_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify({ group_id, portfolio_id })])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Look-through analysis failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse look-through result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
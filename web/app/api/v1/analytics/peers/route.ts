@@ -0,0 +1,130 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const METRICS = ['IRR', 'TVPI']
+
+function runPeersScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'peers_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Peer benchmarking failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse peer benchmarking result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Rank active funds against each source's latest peer breakpoints (?source= for one source, ?group_id= for one portfolio group's subtree, ?portfolio_id= for one portfolio)
+export async function GET(request: NextRequest) {
+  try {
+    const source = request.nextUrl.searchParams.get('source') ?? undefined
+    const groupParam = request.nextUrl.searchParams.get('group_id')
+    const group_id = groupParam === null ? null : Number(groupParam)
+    if (group_id !== null && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const portfolioParam = request.nextUrl.searchParams.get('portfolio_id')
+    const portfolio_id = portfolioParam === null ? null : Number(portfolioParam)
+    if (portfolio_id !== null && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runPeersScript({ action: 'rank', source, group_id, portfolio_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Upload a peer universe of quartile breakpoints
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { source, as_of_date, breakpoints } = body
+
+    // Validate inputs
+    if (typeof source !== 'string' || source.trim().length === 0) {
+      return NextResponse.json(
+        { error: 'source is required' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof as_of_date !== 'string' || Number.isNaN(Date.parse(as_of_date))) {
+      return NextResponse.json(
+        { error: 'as_of_date must be a date (YYYY-MM-DD)' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(breakpoints) || breakpoints.length === 0 || breakpoints.length > 5000) {
+      return NextResponse.json(
+        { error: 'breakpoints must be an array of 1 to 5000 entries' },
+        { status: 400 }
+      )
+    }
+
+    const invalid = breakpoints.find((bp) =>
+      !Number.isInteger(bp?.vintage) ||
+      !METRICS.includes(bp?.metric) ||
+      typeof bp?.upper_quartile !== 'number' ||
+      typeof bp?.median !== 'number' ||
+      typeof bp?.lower_quartile !== 'number' ||
+      !(bp.lower_quartile <= bp.median && bp.median <= bp.upper_quartile)
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: `each breakpoint needs an integer vintage, metric (${METRICS.join(', ')}) and ordered lower_quartile <= median <= upper_quartile` },
+        { status: 400 }
+      )
+    }
+
+    return runPeersScript({ action: 'upload', source, as_of_date, breakpoints })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
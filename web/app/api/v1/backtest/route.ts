@@ -0,0 +1,169 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MAX_PACING_PATHS = 5000
+
+const isDate = (value: unknown): boolean =>
+  typeof value === 'string' && /^\d{4}-\d{2}-\d{2}$/.test(value) && !Number.isNaN(Date.parse(value))
+
+const isFiniteNumber = (value: unknown): value is number =>
+  typeof value === 'number' && Number.isFinite(value)
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      target_weights,
+      rebalance_frequency = 21,
+      drift_threshold,
+      transaction_cost = 0.0,
+      returns,
+      frequency,
+      series,
+      start_date,
+      end_date,
+      pacing,
+      pacing_paths,
+      seed,
+      risk_free_rate
+    } = body
+
+    // Validate inputs
+    if (!Array.isArray(target_weights) || target_weights.length < 2) {
+      return NextResponse.json(
+        { error: 'target_weights must be an array with at least 2 assets' },
+        { status: 400 }
+      )
+    }
+
+    const weightSum = target_weights.reduce((a: number, b: number) => a + b, 0)
+    if (Math.abs(weightSum - 1) > 1e-6) {
+      return NextResponse.json(
+        { error: 'target_weights must sum to 1' },
+        { status: 400 }
+      )
+    }
+
+    // Replay posted returns, or stored benchmark series (one per asset)
+    if (returns == null) {
+      if (!Array.isArray(series) || series.length !== target_weights.length ||
+          !series.every((s: unknown) => typeof s === 'string' && s.length > 0)) {
+        return NextResponse.json(
+          { error: 'Provide returns, or series with one stored series name per target weight' },
+          { status: 400 }
+        )
+      }
+      if ((start_date != null && !isDate(start_date)) || (end_date != null && !isDate(end_date))) {
+        return NextResponse.json(
+          { error: 'start_date and end_date must be YYYY-MM-DD dates' },
+          { status: 400 }
+        )
+      }
+    }
+
+    if (risk_free_rate != null && !isFiniteNumber(risk_free_rate)) {
+      return NextResponse.json(
+        { error: 'risk_free_rate must be a number' },
+        { status: 400 }
+      )
+    }
+
+    if (pacing != null) {
+      const {
+        private_asset, call_rate, distribution_rate, overcommitment, commitment_interval,
+        call_volatility, distribution_volatility
+      } = pacing
+      if (!Number.isInteger(private_asset) || private_asset < 0 || private_asset >= target_weights.length) {
+        return NextResponse.json(
+          { error: 'pacing.private_asset must be the index of one of the target weights' },
+          { status: 400 }
+        )
+      }
+      if ((call_rate != null && (!isFiniteNumber(call_rate) || call_rate < 0)) ||
+          (distribution_rate != null && (!isFiniteNumber(distribution_rate) || distribution_rate < 0)) ||
+          (overcommitment != null && (!isFiniteNumber(overcommitment) || overcommitment < 1)) ||
+          (commitment_interval != null && (!Number.isInteger(commitment_interval) || commitment_interval < 1)) ||
+          (call_volatility != null && (!isFiniteNumber(call_volatility) || call_volatility < 0)) ||
+          (distribution_volatility != null && (!isFiniteNumber(distribution_volatility) || distribution_volatility < 0))) {
+        return NextResponse.json(
+          { error: 'pacing rates and volatilities must be non-negative, overcommitment at least 1 and commitment_interval a positive integer' },
+          { status: 400 }
+        )
+      }
+    }
+
+    // Bands of the commitment plan under stochastic call and distribution timing
+    if (pacing_paths != null) {
+      if (pacing == null) {
+        return NextResponse.json(
+          { error: 'pacing_paths needs a pacing rule' },
+          { status: 400 }
+        )
+      }
+      if (!Number.isInteger(pacing_paths) || pacing_paths < 1 || pacing_paths > MAX_PACING_PATHS) {
+        return NextResponse.json(
+          { error: `pacing_paths must be an integer between 1 and ${MAX_PACING_PATHS}` },
+          { status: 400 }
+        )
+      }
+      if (seed != null && (!Number.isInteger(seed) || seed < 0)) {
+        return NextResponse.json(
+          { error: 'seed must be a non-negative integer' },
+          { status: 400 }
+        )
+      }
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'backtest_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      target_weights, rebalance_frequency, drift_threshold,
+      transaction_cost, returns, frequency, series, start_date, end_date, pacing,
+      pacing_paths, seed, risk_free_rate
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Backtest failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse backtest result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
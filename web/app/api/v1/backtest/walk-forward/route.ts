@@ -0,0 +1,114 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MODEL_TYPES = ['random_forest', 'gradient_boosting']
+
+const isPositiveInteger = (value: unknown): value is number =>
+  typeof value === 'number' && Number.isInteger(value) && value > 0
+
+// Walk-forward validation of the IRR forecaster on stored funds, ordered by vintage
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      train_window,
+      test_window,
+      step,
+      expanding = false,
+      model_type = 'random_forest',
+      group_id,
+      portfolio_id
+    } = body
+
+    // Validate inputs
+    if (!isPositiveInteger(train_window) || !isPositiveInteger(test_window)) {
+      return NextResponse.json(
+        { error: 'train_window and test_window must be positive integers' },
+        { status: 400 }
+      )
+    }
+
+    if (step != null && !isPositiveInteger(step)) {
+      return NextResponse.json(
+        { error: 'step must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof expanding !== 'boolean') {
+      return NextResponse.json(
+        { error: 'expanding must be a boolean' },
+        { status: 400 }
+      )
+    }
+
+    if (!MODEL_TYPES.includes(model_type)) {
+      return NextResponse.json(
+        { error: `model_type must be one of ${MODEL_TYPES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (group_id != null && !isPositiveInteger(group_id)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (portfolio_id != null && !isPositiveInteger(portfolio_id)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'walk_forward_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ train_window, test_window, step, expanding, model_type, group_id, portfolio_id })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Walk-forward validation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse walk-forward result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
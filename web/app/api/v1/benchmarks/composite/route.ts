@@ -0,0 +1,94 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { name, components, spread_bps = 0, persist = true } = body
+
+    // Validate inputs
+    if (typeof name !== 'string' || name.trim().length === 0 || name.length > 100) {
+      return NextResponse.json(
+        { error: 'name must be a non-empty string of at most 100 characters' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(components) || components.length === 0 || components.length > 20) {
+      return NextResponse.json(
+        { error: 'components must be an array of 1 to 20 { benchmark, weight } entries' },
+        { status: 400 }
+      )
+    }
+
+    if (!components.every((c) => typeof c?.benchmark === 'string' && typeof c?.weight === 'number' && c.weight >= 0)) {
+      return NextResponse.json(
+        { error: 'each component needs a benchmark name and a non-negative weight' },
+        { status: 400 }
+      )
+    }
+
+    const totalWeight = components.reduce((sum, c) => sum + c.weight, 0)
+    if (Math.abs(totalWeight - 1) > 1e-6) {
+      return NextResponse.json(
+        { error: 'component weights must sum to 1' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof spread_bps !== 'number' || spread_bps < -2000 || spread_bps > 2000) {
+      return NextResponse.json(
+        { error: 'spread_bps must be between -2000 and 2000' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'benchmark_composite_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ name, components, spread_bps, persist })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Composite benchmark failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse composite benchmark result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
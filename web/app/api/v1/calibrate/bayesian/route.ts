@@ -0,0 +1,111 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const NIG_KEYS = ['m', 'kappa', 'alpha', 'beta']
+
+const isPositive = (value: unknown): boolean => typeof value === 'number' && Number.isFinite(value) && value > 0
+
+// Either annual assumptions with pseudo-observation confidence, or per-period NIG parameters
+const isPrior = (prior: any): boolean => {
+  if (typeof prior !== 'object' || prior === null || Array.isArray(prior)) return false
+  if (prior.nig !== undefined) {
+    return typeof prior.nig === 'object' && prior.nig !== null &&
+      typeof prior.nig.m === 'number' && Number.isFinite(prior.nig.m) &&
+      NIG_KEYS.slice(1).every((k) => isPositive(prior.nig[k]))
+  }
+  return typeof prior.mean === 'number' && Number.isFinite(prior.mean) &&
+    isPositive(prior.volatility) &&
+    (prior.mean_confidence === undefined || isPositive(prior.mean_confidence)) &&
+    (prior.volatility_confidence === undefined || isPositive(prior.volatility_confidence))
+}
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { returns, prior, frequency = 4, credible_level = 0.9 } = body
+
+    // Validate inputs
+    if (!Array.isArray(returns) || returns.length < 2 || returns.length > 5000) {
+      return NextResponse.json(
+        { error: 'returns must be an array of 2 to 5000 observations' },
+        { status: 400 }
+      )
+    }
+
+    if (!returns.every((r) => typeof r === 'number' && Number.isFinite(r))) {
+      return NextResponse.json(
+        { error: 'returns must contain only finite numbers' },
+        { status: 400 }
+      )
+    }
+
+    if (!isPrior(prior)) {
+      return NextResponse.json(
+        { error: 'prior must give an annual mean and positive volatility (with optional positive mean_confidence and volatility_confidence), or nig parameters m, kappa, alpha, beta' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(frequency) || frequency < 1 || frequency > 365) {
+      return NextResponse.json(
+        { error: 'frequency must be an integer between 1 and 365' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof credible_level !== 'number' || credible_level <= 0 || credible_level >= 1) {
+      return NextResponse.json(
+        { error: 'credible_level must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'calibrate_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ action: 'bayesian', returns, prior, frequency, credible_level })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Bayesian update failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse Bayesian update result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,174 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MODELS = ['normal', 'lognormal', 'student_t', 'garch']
+
+function runCalibrateScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'calibrate_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Calibration failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse calibration result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+const isDate = (value: unknown): boolean =>
+  typeof value === 'string' && /^\d{4}-\d{2}-\d{2}$/.test(value) && !Number.isNaN(Date.parse(value))
+
+// Fit distributions to posted returns, or to a stored series (fits are registered)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { returns, series, start_date, end_date, models, frequency, register = true, author, notes } = body
+
+    // Validate inputs
+    if ((returns === undefined) === (series === undefined)) {
+      return NextResponse.json(
+        { error: 'Give either returns or a stored series name' },
+        { status: 400 }
+      )
+    }
+
+    if (returns !== undefined) {
+      if (!Array.isArray(returns) || returns.length < 10 || returns.length > 5000) {
+        return NextResponse.json(
+          { error: 'returns must be an array of 10 to 5000 observations' },
+          { status: 400 }
+        )
+      }
+
+      if (!returns.every((r) => typeof r === 'number' && Number.isFinite(r))) {
+        return NextResponse.json(
+          { error: 'returns must contain only finite numbers' },
+          { status: 400 }
+        )
+      }
+    }
+
+    if (series !== undefined && (typeof series !== 'string' || series.trim().length === 0)) {
+      return NextResponse.json(
+        { error: 'series must be a non-empty benchmark name' },
+        { status: 400 }
+      )
+    }
+
+    for (const [name, value] of Object.entries({ start_date, end_date })) {
+      if (value !== undefined && (series === undefined || !isDate(value))) {
+        return NextResponse.json(
+          { error: `${name} must be a YYYY-MM-DD date and requires series` },
+          { status: 400 }
+        )
+      }
+    }
+
+    if (start_date !== undefined && end_date !== undefined && start_date > end_date) {
+      return NextResponse.json(
+        { error: 'start_date must not be after end_date' },
+        { status: 400 }
+      )
+    }
+
+    if (models !== undefined && (!Array.isArray(models) || !models.every((m) => MODELS.includes(m)))) {
+      return NextResponse.json(
+        { error: `models must be a subset of: ${MODELS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    // Stored series infer their frequency when it is not given
+    if (frequency !== undefined && (!Number.isInteger(frequency) || frequency < 1 || frequency > 365)) {
+      return NextResponse.json(
+        { error: 'frequency must be an integer between 1 and 365' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof register !== 'boolean') {
+      return NextResponse.json(
+        { error: 'register must be a boolean' },
+        { status: 400 }
+      )
+    }
+
+    if ((author !== undefined && typeof author !== 'string') || (notes !== undefined && typeof notes !== 'string')) {
+      return NextResponse.json(
+        { error: 'author and notes must be strings' },
+        { status: 400 }
+      )
+    }
+
+    return runCalibrateScript({
+      action: 'fit',
+      returns,
+      series,
+      start_date,
+      end_date,
+      models,
+      frequency: series === undefined ? (frequency ?? 252) : frequency,
+      register,
+      author,
+      notes
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Registered fits, optionally for one series and model
+export async function GET(request: NextRequest) {
+  try {
+    const series = request.nextUrl.searchParams.get('series') ?? undefined
+    const model = request.nextUrl.searchParams.get('model') ?? undefined
+
+    if (model !== undefined && !MODELS.includes(model)) {
+      return NextResponse.json(
+        { error: `model must be one of: ${MODELS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    return runCalibrateScript({ action: 'calibrations', series, model })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,91 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const DEFAULT_LIMIT = 1000
+const MAX_LIMIT = 10000
+const TABLES = ['portfolio_data', 'fund_tags', 'cash_flows', 'period_navs']
+
+function runChangesScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'changes_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Change feed request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse change feed result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Changes to funds, fund tags, cash flows and reported NAVs after ?since= (a change_id cursor),
+// oldest first; pass next_cursor back as since to continue (?limit=, ?table= repeatable)
+export async function GET(request: NextRequest) {
+  try {
+    const searchParams = request.nextUrl.searchParams
+
+    // Validate inputs
+    const sinceParam = searchParams.get('since')
+    const since = sinceParam === null ? 0 : Number(sinceParam)
+    if ((sinceParam !== null && !/^\d+$/.test(sinceParam)) || !Number.isSafeInteger(since)) {
+      return NextResponse.json(
+        { error: 'since must be a non-negative integer cursor (next_cursor of the previous response)' },
+        { status: 400 }
+      )
+    }
+
+    const limitParam = searchParams.get('limit')
+    const limit = limitParam === null ? DEFAULT_LIMIT : Number(limitParam)
+    if (!Number.isInteger(limit) || limit < 1 || limit > MAX_LIMIT) {
+      return NextResponse.json(
+        { error: `limit must be an integer between 1 and ${MAX_LIMIT}` },
+        { status: 400 }
+      )
+    }
+
+    const tables = searchParams.getAll('table')
+    if (tables.some((table) => !TABLES.includes(table))) {
+      return NextResponse.json(
+        { error: `table must be one of: ${TABLES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    return runChangesScript({ action: 'changes', since, limit, tables })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,209 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const SCENARIO_FIELDS = [
+  'call_rate', 'call_acceleration', 'call_volatility', 'call_market_correlation',
+  'distribution_rate', 'distribution_haircut', 'liquid_return', 'liquid_volatility',
+  'liquid_shock', 'private_return', 'private_beta', 'private_volatility', 'spending_rate'
+]
+const MAX_PATHS = 200_000
+const MAX_FUNDS = 500
+
+// Scenario overrides: known fields with finite numeric values
+const isScenario = (value: unknown): boolean =>
+  value === undefined ||
+  (typeof value === 'object' && value !== null && !Array.isArray(value) &&
+    Object.entries(value).every(([key, v]) =>
+      SCENARIO_FIELDS.includes(key) && typeof v === 'number' && Number.isFinite(v)
+    ))
+
+const isFiniteNumber = (value: unknown): value is number =>
+  typeof value === 'number' && Number.isFinite(value)
+
+const isFund = (value: any, sizeField: 'nav' | 'commitment'): boolean =>
+  typeof value === 'object' && value !== null &&
+  typeof value.name === 'string' && value.name.length > 0 &&
+  typeof value.sector === 'string' && value.sector.length > 0 &&
+  isFiniteNumber(value[sizeField]) && value[sizeField] >= 0 &&
+  isFiniteNumber(value.expected_return) &&
+  isFiniteNumber(value.volatility) && value.volatility >= 0
+
+// Compare the portfolio with and without a candidate fund (POST /api/v1/decisions/newfund)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      candidate,
+      funds,
+      group_id,
+      portfolio_id,
+      liquid_assets,
+      unfunded_commitments,
+      horizon_years = 5,
+      correlation = 0.5,
+      confidence_level = 0.95,
+      scenario,
+      quarters = 12,
+      n_paths = 20000,
+      seed = 42
+    } = body
+
+    // Validate inputs
+    if (!isFund(candidate, 'commitment') || candidate.commitment <= 0) {
+      return NextResponse.json(
+        { error: 'candidate must have name, sector, a positive commitment, expected_return and a non-negative volatility' },
+        { status: 400 }
+      )
+    }
+
+    if (funds !== undefined &&
+        (!Array.isArray(funds) || funds.length === 0 || funds.length > MAX_FUNDS ||
+          !funds.every((f: unknown) => isFund(f, 'nav')))) {
+      return NextResponse.json(
+        { error: `funds must be a list of up to ${MAX_FUNDS} funds with name, sector, nav, expected_return and volatility` },
+        { status: 400 }
+      )
+    }
+
+    // Without funds, group_id and portfolio_id limit the stored funds to a portfolio group's subtree or a portfolio
+    if (group_id !== undefined && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (portfolio_id !== undefined && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (!isFiniteNumber(liquid_assets) || liquid_assets <= candidate.commitment) {
+      return NextResponse.json(
+        { error: 'liquid_assets must be a number above the candidate commitment, which is paid from them' },
+        { status: 400 }
+      )
+    }
+
+    if (unfunded_commitments !== undefined &&
+        (!isFiniteNumber(unfunded_commitments) || unfunded_commitments < 0)) {
+      return NextResponse.json(
+        { error: 'unfunded_commitments must be a non-negative number' },
+        { status: 400 }
+      )
+    }
+
+    if (!isFiniteNumber(horizon_years) || horizon_years <= 0 || horizon_years > 30) {
+      return NextResponse.json(
+        { error: 'horizon_years must be between 0 and 30' },
+        { status: 400 }
+      )
+    }
+
+    if (!isFiniteNumber(correlation) || correlation < 0 || correlation >= 1) {
+      return NextResponse.json(
+        { error: 'correlation must be in [0, 1)' },
+        { status: 400 }
+      )
+    }
+
+    if (!isFiniteNumber(confidence_level) || confidence_level <= 0.5 || confidence_level >= 1) {
+      return NextResponse.json(
+        { error: 'confidence_level must be between 0.5 and 1' },
+        { status: 400 }
+      )
+    }
+
+    if (!isScenario(scenario)) {
+      return NextResponse.json(
+        { error: `scenario may only set numeric values for: ${SCENARIO_FIELDS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(quarters) || quarters < 1 || quarters > 40) {
+      return NextResponse.json(
+        { error: 'quarters must be an integer between 1 and 40' },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n_paths) || n_paths < 1000 || n_paths > MAX_PATHS) {
+      return NextResponse.json(
+        { error: `n_paths must be an integer between 1000 and ${MAX_PATHS}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(seed)) {
+      return NextResponse.json(
+        { error: 'seed must be an integer' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'decision_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      candidate,
+      funds,
+      group_id,
+      portfolio_id,
+      liquid_assets,
+      unfunded_commitments,
+      horizon_years,
+      correlation,
+      confidence_level,
+      scenario,
+      quarters,
+      n_paths,
+      seed
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `New fund decision failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse new fund decision result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
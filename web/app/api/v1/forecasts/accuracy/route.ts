@@ -0,0 +1,68 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+function runForecastsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'forecasts_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Forecast request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse forecast result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Forecast error metrics per model, version and fund
+export async function GET(request: NextRequest) {
+  try {
+    const model_name = request.nextUrl.searchParams.get('model') ?? undefined
+    const fundParam = request.nextUrl.searchParams.get('fund_id')
+    const fund_id = fundParam === null ? undefined : Number(fundParam)
+
+    if (fund_id !== undefined && (!Number.isInteger(fund_id) || fund_id <= 0)) {
+      return NextResponse.json(
+        { error: 'fund_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runForecastsScript({ action: 'accuracy', model_name, fund_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
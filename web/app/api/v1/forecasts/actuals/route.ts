@@ -0,0 +1,94 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MAX_ACTUALS = 10000
+
+const isDate = (value: unknown): boolean =>
+  typeof value === 'string' && /^\d{4}-\d{2}-\d{2}$/.test(value) && !Number.isNaN(Date.parse(value))
+
+function runForecastsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'forecasts_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Forecast request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse forecast result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Store realized fund IRR (re-posting a date replaces its value)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { actuals } = body
+
+    // Validate inputs
+    if (!Array.isArray(actuals) || actuals.length === 0 || actuals.length > MAX_ACTUALS) {
+      return NextResponse.json(
+        { error: `actuals must be an array of 1 to ${MAX_ACTUALS} entries` },
+        { status: 400 }
+      )
+    }
+
+    const invalid = actuals.find((a) =>
+      !Number.isInteger(a?.fund_id) ||
+      !isDate(a?.as_of_date) ||
+      typeof a?.irr !== 'number' ||
+      !Number.isFinite(a.irr)
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: 'each actual needs a fund_id, an as_of_date (YYYY-MM-DD) and a numeric irr' },
+        { status: 400 }
+      )
+    }
+
+    const keys = new Set(actuals.map((a: { fund_id: number, as_of_date: string }) => `${a.fund_id}:${a.as_of_date}`))
+    if (keys.size !== actuals.length) {
+      return NextResponse.json(
+        { error: 'each fund_id and as_of_date pair may appear only once' },
+        { status: 400 }
+      )
+    }
+
+    return runForecastsScript({ action: 'record_actuals', actuals })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,93 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const MAX_FORECASTS = 10000
+
+const isDate = (value: unknown): boolean =>
+  typeof value === 'string' && /^\d{4}-\d{2}-\d{2}$/.test(value) && !Number.isNaN(Date.parse(value))
+
+function runForecastsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'forecasts_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Forecast request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse forecast result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Store forecast versions (appended, never overwritten)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { forecasts } = body
+
+    // Validate inputs
+    if (!Array.isArray(forecasts) || forecasts.length === 0 || forecasts.length > MAX_FORECASTS) {
+      return NextResponse.json(
+        { error: `forecasts must be an array of 1 to ${MAX_FORECASTS} entries` },
+        { status: 400 }
+      )
+    }
+
+    const invalid = forecasts.find((f) =>
+      !Number.isInteger(f?.fund_id) ||
+      typeof f?.model_name !== 'string' ||
+      f.model_name.trim().length === 0 ||
+      (f?.model_version !== undefined && typeof f.model_version !== 'string') ||
+      !isDate(f?.prediction_date) ||
+      !isDate(f?.target_date) ||
+      f.target_date < f.prediction_date ||
+      typeof f?.predicted_irr !== 'number' ||
+      !Number.isFinite(f.predicted_irr) ||
+      (f?.confidence_lower !== undefined && typeof f.confidence_lower !== 'number') ||
+      (f?.confidence_upper !== undefined && typeof f.confidence_upper !== 'number')
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: 'each forecast needs a fund_id, model_name, prediction_date and target_date (YYYY-MM-DD, target on or after prediction) and a numeric predicted_irr' },
+        { status: 400 }
+      )
+    }
+
+    return runForecastsScript({ action: 'record_forecasts', forecasts })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,129 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const TAG_KEY = /^[A-Za-z0-9_.-]{1,50}$/
+const MAX_TAGS = 50
+
+function runFundsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'funds_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Fund request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse fund result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+function parseFundId(id: string): number | null {
+  const fund_id = Number(id)
+  return Number.isInteger(fund_id) && fund_id > 0 ? fund_id : null
+}
+
+// A fund's tags
+export async function GET(
+  request: NextRequest,
+  { params }: { params: Promise<{ id: string }> }
+) {
+  try {
+    const fund_id = parseFundId((await params).id)
+    if (fund_id === null) {
+      return NextResponse.json(
+        { error: 'fund id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runFundsScript({ action: 'tags', fund_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Add or change a fund's tags (null removes a tag; replace=true drops tags not given)
+export async function POST(
+  request: NextRequest,
+  { params }: { params: Promise<{ id: string }> }
+) {
+  try {
+    const fund_id = parseFundId((await params).id)
+    const body = await request.json()
+    const { tags, replace = false } = body
+
+    // Validate inputs
+    if (fund_id === null) {
+      return NextResponse.json(
+        { error: 'fund id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof tags !== 'object' || tags === null || Array.isArray(tags) ||
+        Object.keys(tags).length === 0 || Object.keys(tags).length > MAX_TAGS) {
+      return NextResponse.json(
+        { error: `tags must be an object of 1 to ${MAX_TAGS} key/value pairs` },
+        { status: 400 }
+      )
+    }
+
+    const invalid = Object.entries(tags).find(([key, value]) =>
+      !TAG_KEY.test(key) ||
+      (value !== null && (typeof value !== 'string' || value.trim().length === 0 || value.length > 100))
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: 'tag keys must be up to 50 letters, digits, _ . or -, and values strings of up to 100 characters (null removes a tag)' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof replace !== 'boolean') {
+      return NextResponse.json(
+        { error: 'replace must be a boolean' },
+        { status: 400 }
+      )
+    }
+
+    return runFundsScript({ action: 'set_tags', fund_id, tags, replace })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
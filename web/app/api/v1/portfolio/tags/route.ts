@@ -0,0 +1,120 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const STATUSES = ['Active', 'Realized', 'Written-Off']
+const TAG_KEY = /^[A-Za-z0-9_.-]{1,50}$/
+
+// ?tag=key:value, repeatable; a fund must carry every pair (null when malformed)
+function parseTags(searchParams: URLSearchParams): Record<string, string> | null {
+  const tags: Record<string, string> = {}
+  for (const tag of searchParams.getAll('tag')) {
+    const split = tag.indexOf(':')
+    const key = tag.slice(0, split)
+    const value = tag.slice(split + 1)
+    if (split < 1 || !TAG_KEY.test(key) || value.length === 0 || value.length > 100 || Object.hasOwn(tags, key)) {
+      return null
+    }
+    tags[key] = value
+  }
+  return tags
+}
+
+function runFundsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'funds_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Fund request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse fund result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// Aggregate funds by the value of one tag key (?key=, optional ?tag=key:value, ?status=, ?group_id= and ?portfolio_id= filters)
+export async function GET(request: NextRequest) {
+  try {
+    const searchParams = request.nextUrl.searchParams
+    const key = searchParams.get('key')
+    const tags = parseTags(searchParams)
+    const status = searchParams.get('status')
+
+    // Validate inputs
+    if (key === null || !TAG_KEY.test(key)) {
+      return NextResponse.json(
+        { error: 'key must be a tag key of up to 50 letters, digits, _ . or -' },
+        { status: 400 }
+      )
+    }
+
+    if (tags === null) {
+      return NextResponse.json(
+        { error: 'tag filters must be key:value with a key of up to 50 letters, digits, _ . or - and a value of up to 100 characters, one per key' },
+        { status: 400 }
+      )
+    }
+
+    if (status !== null && !STATUSES.includes(status)) {
+      return NextResponse.json(
+        { error: `status must be one of: ${STATUSES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    const groupParam = searchParams.get('group_id')
+    const group_id = groupParam === null ? null : Number(groupParam)
+    if (group_id !== null && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const portfolioParam = searchParams.get('portfolio_id')
+    const portfolio_id = portfolioParam === null ? null : Number(portfolioParam)
+    if (portfolio_id !== null && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runFundsScript({ action: 'aggregate', key, tags, status, group_id, portfolio_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
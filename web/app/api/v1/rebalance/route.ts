@@ -0,0 +1,85 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      holdings,
+      target_weights,
+      cash = 0.0,
+      lot_size = 0.0,
+      min_trade = 0.0,
+      max_turnover,
+      illiquid,
+      asset_names
+    } = body
+
+    // Validate inputs
+    if (!Array.isArray(holdings) || holdings.length < 2) {
+      return NextResponse.json(
+        { error: 'holdings must be an array with at least 2 positions' },
+        { status: 400 }
+      )
+    }
+
+    // Targets are required (typically optimizer output)
+    if (!Array.isArray(target_weights) || target_weights.length !== holdings.length) {
+      return NextResponse.json(
+        { error: 'target_weights is required, with one entry per holding' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'rebalance_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      holdings, target_weights, cash, lot_size, min_trade,
+      max_turnover, illiquid, asset_names
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Rebalance failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse rebalance result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,173 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const STATUSES = ['open', 'acknowledged', 'resolved', 'all']
+const TRIGGERS = ['manual', 'scheduled']
+
+function runRiskLimitsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'risk_limits_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Risk limit request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse risk limit result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// List recorded limit breaches
+export async function GET(request: NextRequest) {
+  try {
+    const status = request.nextUrl.searchParams.get('status') ?? 'open'
+    const limitParam = request.nextUrl.searchParams.get('limit')
+    const limit = limitParam === null ? 100 : Number(limitParam)
+
+    if (!STATUSES.includes(status)) {
+      return NextResponse.json(
+        { error: `status must be one of: ${STATUSES.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(limit) || limit < 1 || limit > 1000) {
+      return NextResponse.json(
+        { error: 'limit must be an integer between 1 and 1000' },
+        { status: 400 }
+      )
+    }
+
+    return runRiskLimitsScript({ action: 'breaches', status, limit })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Evaluate the active limits now and record any breaches (group_id or portfolio_id checks one portfolio group or portfolio without recording)
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { trigger = 'manual', correlation = 0.5, confidence_level = 0.95, horizon_years = 1.0, group_id, portfolio_id } = body
+
+    // Validate inputs
+    if (!TRIGGERS.includes(trigger)) {
+      return NextResponse.json(
+        { error: `trigger must be one of: ${TRIGGERS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    if (typeof correlation !== 'number' || correlation < -1 || correlation > 1) {
+      return NextResponse.json(
+        { error: 'correlation must be between -1 and 1' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof confidence_level !== 'number' || confidence_level <= 0 || confidence_level >= 1) {
+      return NextResponse.json(
+        { error: 'confidence_level must be between 0 and 1' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof horizon_years !== 'number' || horizon_years <= 0 || horizon_years > 10) {
+      return NextResponse.json(
+        { error: 'horizon_years must be in (0, 10]' },
+        { status: 400 }
+      )
+    }
+
+    if (group_id !== undefined && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (portfolio_id !== undefined && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    return runRiskLimitsScript({ action: 'evaluate', trigger, correlation, confidence_level, horizon_years, group_id, portfolio_id })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Acknowledge an open breach
+export async function PATCH(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { breach_id, acknowledged_by, note } = body
+
+    // Validate inputs
+    if (!Number.isInteger(breach_id) || breach_id <= 0) {
+      return NextResponse.json(
+        { error: 'breach_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    if (typeof acknowledged_by !== 'string' || acknowledged_by.trim().length === 0) {
+      return NextResponse.json(
+        { error: 'acknowledged_by is required' },
+        { status: 400 }
+      )
+    }
+
+    if (note !== undefined && typeof note !== 'string') {
+      return NextResponse.json(
+        { error: 'note must be a string' },
+        { status: 400 }
+      )
+    }
+
+    return runRiskLimitsScript({ action: 'acknowledge', breach_id, acknowledged_by, note })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
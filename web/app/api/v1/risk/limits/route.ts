@@ -0,0 +1,103 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const METRICS = ['portfolio_var', 'portfolio_volatility', 'max_fund_weight', 'max_sector_weight', 'hhi']
+
+function runRiskLimitsScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'risk_limits_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Risk limit request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse risk limit result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// List configured risk limits
+export async function GET() {
+  try {
+    return runRiskLimitsScript({ action: 'limits' })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
+
+// Create or update limits by metric
+export async function PUT(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { limits } = body
+
+    // Validate inputs
+    if (!Array.isArray(limits) || limits.length === 0 || limits.length > METRICS.length) {
+      return NextResponse.json(
+        { error: `limits must be an array of 1 to ${METRICS.length} entries` },
+        { status: 400 }
+      )
+    }
+
+    const invalid = limits.find((l) =>
+      !METRICS.includes(l?.metric) ||
+      typeof l?.limit_value !== 'number' ||
+      l.limit_value <= 0 ||
+      (l?.warning_value !== undefined && (typeof l.warning_value !== 'number' || l.warning_value <= 0 || l.warning_value > l.limit_value)) ||
+      (l?.active !== undefined && typeof l.active !== 'boolean')
+    )
+    if (invalid) {
+      return NextResponse.json(
+        { error: `each limit needs a metric (${METRICS.join(', ')}), a positive limit_value, an optional warning_value not above it and an optional active flag` },
+        { status: 400 }
+      )
+    }
+
+    if (new Set(limits.map((l: { metric: string }) => l.metric)).size !== limits.length) {
+      return NextResponse.json(
+        { error: 'each metric may appear only once' },
+        { status: 400 }
+      )
+    }
+
+    return runRiskLimitsScript({ action: 'set_limits', limits })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
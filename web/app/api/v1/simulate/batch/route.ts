@@ -0,0 +1,109 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const SWEEP_PARAMS = ['S', 'K', 'T', 'r', 'sigma', 'q']
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      S = 100, K = 100, T = 1, r = 0.05, sigma = 0.2, q = 0.0,
+      option_type = 'call',
+      grid,
+      n_paths = 100000,
+      variance_reduction = 'antithetic',
+      seed = 42,
+      max_workers,
+      bypass_cache = false,
+      cache_ttl
+    } = body
+
+    // Validate inputs
+    if (!grid || typeof grid !== 'object' || Array.isArray(grid) || Object.keys(grid).length === 0) {
+      return NextResponse.json(
+        { error: 'grid must map one or more of S, K, T, r, sigma, q to arrays of values' },
+        { status: 400 }
+      )
+    }
+
+    const invalidAxis = Object.entries(grid).find(([name, values]) =>
+      !SWEEP_PARAMS.includes(name) ||
+      !Array.isArray(values) ||
+      values.length === 0 ||
+      !values.every((v) => typeof v === 'number' && Number.isFinite(v))
+    )
+    if (invalidAxis) {
+      return NextResponse.json(
+        { error: `invalid grid axis '${invalidAxis[0]}': must be one of ${SWEEP_PARAMS.join(', ')} with a non-empty array of numbers` },
+        { status: 400 }
+      )
+    }
+
+    const gridPoints = Object.values(grid).reduce((n: number, values) => n * (values as number[]).length, 1)
+    if (gridPoints > 1000) {
+      return NextResponse.json(
+        { error: `grid has ${gridPoints} points; maximum is 1000` },
+        { status: 400 }
+      )
+    }
+
+    if (!Number.isInteger(n_paths) || n_paths < 1000 || gridPoints * n_paths > 200_000_000) {
+      return NextResponse.json(
+        { error: 'n_paths must be an integer of at least 1000, and grid points × n_paths at most 200,000,000' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'batch_simulate_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      S, K, T, r, sigma, q, option_type,
+      grid, n_paths, variance_reduction, seed, max_workers,
+      bypass_cache, cache_ttl
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Batch simulation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse batch simulation result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,130 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const isSensitivityMap = (value: unknown, factors: Set<string>): boolean =>
+  value === undefined ||
+  (typeof value === 'object' && value !== null && !Array.isArray(value) &&
+    Object.entries(value).every(([name, v]) => factors.has(name) && typeof v === 'number' && Number.isFinite(v)))
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { holdings, factors, loss_threshold, correlation } = body
+
+    // Validate inputs
+    if (typeof loss_threshold !== 'number' || loss_threshold <= 0 || loss_threshold >= 1) {
+      return NextResponse.json(
+        { error: 'loss_threshold must be a fraction between 0 and 1 (e.g. 0.2 for a 20% loss)' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(factors) || factors.length === 0 || factors.length > 50) {
+      return NextResponse.json(
+        { error: 'factors must be an array of 1 to 50 entries' },
+        { status: 400 }
+      )
+    }
+
+    const invalidFactor = factors.find((f) =>
+      typeof f?.name !== 'string' ||
+      typeof f?.volatility !== 'number' ||
+      f.volatility <= 0 ||
+      (f?.lower !== undefined && typeof f.lower !== 'number') ||
+      (f?.upper !== undefined && typeof f.upper !== 'number') ||
+      (typeof f?.lower === 'number' && typeof f?.upper === 'number' && f.lower > f.upper)
+    )
+    if (invalidFactor) {
+      return NextResponse.json(
+        { error: 'each factor needs a name, a positive volatility and optional lower <= upper shock bounds' },
+        { status: 400 }
+      )
+    }
+
+    const factorNames = new Set<string>(factors.map((f: { name: string }) => f.name))
+    if (factorNames.size !== factors.length) {
+      return NextResponse.json(
+        { error: 'factor names must be unique' },
+        { status: 400 }
+      )
+    }
+
+    if (!Array.isArray(holdings) || holdings.length === 0 || holdings.length > 1000) {
+      return NextResponse.json(
+        { error: 'holdings must be an array of 1 to 1000 entries' },
+        { status: 400 }
+      )
+    }
+
+    const invalidHolding = holdings.find((h) =>
+      typeof h?.name !== 'string' ||
+      typeof h?.value !== 'number' ||
+      !Number.isFinite(h.value) ||
+      !isSensitivityMap(h?.exposures, factorNames) ||
+      !isSensitivityMap(h?.convexity, factorNames)
+    )
+    if (invalidHolding) {
+      return NextResponse.json(
+        { error: 'each holding needs a name, a value and exposures (and optional convexity) keyed by factor name' },
+        { status: 400 }
+      )
+    }
+
+    if (correlation !== undefined &&
+        (!Array.isArray(correlation) || correlation.length !== factors.length ||
+         !correlation.every((row) => Array.isArray(row) && row.length === factors.length))) {
+      return NextResponse.json(
+        { error: 'correlation must be a square matrix with one row per factor' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'stress_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ holdings, factors, loss_threshold, correlation })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Reverse stress test failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse reverse stress test result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
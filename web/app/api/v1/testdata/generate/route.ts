@@ -0,0 +1,86 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const {
+      count = 50,
+      vintage_start = 2010,
+      vintage_end = 2023,
+      sector_mix,
+      mean_irr = 0.14,
+      irr_dispersion = 0.08,
+      volatility_range,
+      include_benchmarks = true,
+      seed
+    } = body
+
+    // Validate inputs
+    if (!Number.isInteger(count) || count < 1 || count > 5000) {
+      return NextResponse.json(
+        { error: 'count must be an integer between 1 and 5000' },
+        { status: 400 }
+      )
+    }
+
+    if (vintage_start > vintage_end) {
+      return NextResponse.json(
+        { error: 'vintage_start must not be after vintage_end' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'testdata_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({
+      count, vintage_start, vintage_end, sector_mix,
+      mean_irr, irr_dispersion, volatility_range,
+      include_benchmarks, seed
+    })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `Generation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse generated data' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
@@ -0,0 +1,94 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json()
+    const { cash_flows, valuation_date, scenarios, curve, discount_rate, curve_name } = body
+
+    // Validate inputs
+    if (!Array.isArray(cash_flows) || cash_flows.length === 0 || cash_flows.length > 2000) {
+      return NextResponse.json(
+        { error: 'cash_flows must be an array of 1 to 2000 entries' },
+        { status: 400 }
+      )
+    }
+
+    if (!cash_flows.every((cf) => typeof cf?.amount === 'number' && (typeof cf?.t === 'number' || typeof cf?.date === 'string'))) {
+      return NextResponse.json(
+        { error: 'each cash flow needs a numeric amount and either t (years) or date' },
+        { status: 400 }
+      )
+    }
+
+    if (cash_flows.some((cf) => typeof cf.date === 'string') &&
+        (typeof valuation_date !== 'string' || Number.isNaN(Date.parse(valuation_date)))) {
+      return NextResponse.json(
+        { error: 'valuation_date (YYYY-MM-DD) is required for dated cash flows' },
+        { status: 400 }
+      )
+    }
+
+    if (scenarios !== undefined && (typeof scenarios !== 'object' || Array.isArray(scenarios) || Object.keys(scenarios).length === 0)) {
+      return NextResponse.json(
+        { error: 'scenarios must be an object of name -> { shift_bps, spread_bps } or { rate }' },
+        { status: 400 }
+      )
+    }
+
+    if (curve !== undefined && (!Array.isArray(curve?.tenors) || !Array.isArray(curve?.rates) || curve.tenors.length !== curve.rates.length)) {
+      return NextResponse.json(
+        { error: 'curve must have tenors and rates arrays of equal length' },
+        { status: 400 }
+      )
+    }
+
+    const scriptPath = path.join(process.cwd(), '..', 'scripts', 'dcf_api.py')
+    const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+    const params = JSON.stringify({ cash_flows, valuation_date, scenarios, curve, discount_rate, curve_name })
+
+    return new Promise((resolve) => {
+      const pythonProcess = spawn(pythonPath, [scriptPath, params])
+      let outputData = ''
+      let errorData = ''
+
+      pythonProcess.stdout.on('data', (data) => {
+        outputData += data.toString()
+      })
+
+      pythonProcess.stderr.on('data', (data) => {
+        errorData += data.toString()
+      })
+
+      pythonProcess.on('close', (code) => {
+        if (code !== 0) {
+          resolve(
+            NextResponse.json(
+              { error: `DCF valuation failed: ${errorData}` },
+              { status: 500 }
+            )
+          )
+        } else {
+          try {
+            const result = JSON.parse(outputData)
+            resolve(NextResponse.json(result))
+          } catch (e) {
+            resolve(
+              NextResponse.json(
+                { error: 'Failed to parse DCF valuation result' },
+                { status: 500 }
+              )
+            )
+          }
+        }
+      })
+    })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}
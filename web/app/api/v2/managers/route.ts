@@ -0,0 +1,137 @@
+import { NextRequest, NextResponse } from 'next/server'
+import { spawn } from 'child_process'
+import path from 'path'
+
+const TAG_KEY = /^[A-Za-z0-9_.-]{1,50}$/
+const DEFAULT_LIMIT = 100
+const MAX_LIMIT = 1000
+const MANAGER_FIELDS = [
+  'manager_id', 'manager_name', 'num_funds', 'total_committed', 'total_invested',
+  'total_nav', 'commitment_weighted_irr', 'avg_tvpi', 'commitment_share', 'nav_share',
+  'headquarters', 'primary_strategy', 'founded_year', 'aum'
+]
+
+// ?tag=key:value, repeatable; a fund must carry every pair (null when malformed)
+function parseTags(searchParams: URLSearchParams): Record<string, string> | null {
+  const tags: Record<string, string> = {}
+  for (const tag of searchParams.getAll('tag')) {
+    const split = tag.indexOf(':')
+    const key = tag.slice(0, split)
+    const value = tag.slice(split + 1)
+    if (split < 1 || !TAG_KEY.test(key) || value.length === 0 || value.length > 100 || Object.hasOwn(tags, key)) {
+      return null
+    }
+    tags[key] = value
+  }
+  return tags
+}
+
+function runManagersScript(params: object): Promise<NextResponse> {
+  const scriptPath = path.join(process.cwd(), '..', 'scripts', 'managers_api.py')
+  const pythonPath = path.join(process.cwd(), '..', 'venv', 'bin', 'python')
+
+  return new Promise((resolve) => {
+    const pythonProcess = spawn(pythonPath, [scriptPath, JSON.stringify(params)])
+    let outputData = ''
+    let errorData = ''
+
+    pythonProcess.stdout.on('data', (data) => {
+      outputData += data.toString()
+    })
+
+    pythonProcess.stderr.on('data', (data) => {
+      errorData += data.toString()
+    })
+
+    pythonProcess.on('close', (code) => {
+      if (code !== 0) {
+        resolve(
+          NextResponse.json(
+            { error: `Manager request failed: ${errorData}` },
+            { status: 500 }
+          )
+        )
+      } else {
+        try {
+          const result = JSON.parse(outputData)
+          resolve(NextResponse.json(result))
+        } catch (e) {
+          resolve(
+            NextResponse.json(
+              { error: 'Failed to parse manager result' },
+              { status: 500 }
+            )
+          )
+        }
+      }
+    })
+  })
+}
+
+// One page of managers with their aggregates as {data, meta} (?limit=, ?offset=; filters and ?fields= as in /api/managers)
+export async function GET(request: NextRequest) {
+  try {
+    const searchParams = request.nextUrl.searchParams
+    const tags = parseTags(searchParams)
+
+    // Validate inputs
+    if (tags === null) {
+      return NextResponse.json(
+        { error: 'tag filters must be key:value with a key of up to 50 letters, digits, _ . or - and a value of up to 100 characters, one per key' },
+        { status: 400 }
+      )
+    }
+
+    const groupParam = searchParams.get('group_id')
+    const group_id = groupParam === null ? null : Number(groupParam)
+    if (group_id !== null && (!Number.isInteger(group_id) || group_id <= 0)) {
+      return NextResponse.json(
+        { error: 'group_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const portfolioParam = searchParams.get('portfolio_id')
+    const portfolio_id = portfolioParam === null ? null : Number(portfolioParam)
+    if (portfolio_id !== null && (!Number.isInteger(portfolio_id) || portfolio_id <= 0)) {
+      return NextResponse.json(
+        { error: 'portfolio_id must be a positive integer' },
+        { status: 400 }
+      )
+    }
+
+    const fieldsParam = searchParams.get('fields')
+    const fields = fieldsParam === null ? null : fieldsParam.split(',')
+    if (fields !== null && (new Set(fields).size !== fields.length || fields.some((field) => !MANAGER_FIELDS.includes(field)))) {
+      return NextResponse.json(
+        { error: `fields must be distinct columns from: ${MANAGER_FIELDS.join(', ')}` },
+        { status: 400 }
+      )
+    }
+
+    const limitParam = searchParams.get('limit')
+    const limit = limitParam === null ? DEFAULT_LIMIT : Number(limitParam)
+    if (!Number.isInteger(limit) || limit < 1 || limit > MAX_LIMIT) {
+      return NextResponse.json(
+        { error: `limit must be an integer between 1 and ${MAX_LIMIT}` },
+        { status: 400 }
+      )
+    }
+
+    const offsetParam = searchParams.get('offset')
+    const offset = offsetParam === null ? 0 : Number(offsetParam)
+    if (!Number.isInteger(offset) || offset < 0) {
+      return NextResponse.json(
+        { error: 'offset must be a non-negative integer' },
+        { status: 400 }
+      )
+    }
+
+    return runManagersScript({ action: 'page', tags, group_id, portfolio_id, fields, limit, offset })
+  } catch (error) {
+    return NextResponse.json(
+      { error: 'Server error' },
+      { status: 500 }
+    )
+  }
+}